@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/nogo/gitree/internal/export"
+	"github.com/nogo/gitree/internal/git"
+)
+
+// runExport implements "gitree export": it renders the filtered graph to
+// stdout or a file without starting the UI. Returns the exit code.
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	var (
+		format  = fs.String("format", string(export.FormatANSI), "Output format")
+		output  = fs.String("output", "", "Write to file instead of stdout")
+		filters filterFlags
+	)
+	fs.StringVar(output, "o", "", "Write to file instead of stdout")
+	filters.register(fs)
+	fs.Usage = printExportUsage
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	f, err := export.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	repoPath, err := resolveRepoPath(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	repo, err := git.NewReader().LoadRepository(repoPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	filterManager, err := filters.manager(repo, repoPath, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	commits := filterManager.ApplyFilters().Commits
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := export.Write(w, f, commits, repo); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func printExportUsage() {
+	fmt.Println("gitree export - render the commit graph without the UI")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  gitree export [flags] [path]")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  --format <format>     ansi (default), plain, json, dot or svg")
+	fmt.Println("  -o, --output <file>   Write to file instead of stdout")
+	fmt.Println("  -b, --branch <name>   Filter by branch name")
+	fmt.Println("  -a, --author <name>   Filter by author name or email")
+	fmt.Println("  -t, --tag <name>      Filter by tag name")
+	fmt.Println("  --since <date>        Show commits from date")
+	fmt.Println("  --until <date>        Show commits up to and including date")
+	fmt.Println("  --view <name>         Apply a saved view (search is ignored)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  gitree export --format plain --since 2w > history.txt")
+	fmt.Println("  gitree export --format json -b main | jq '.rows | length'")
+	fmt.Println("  gitree export --format dot | dot -Tpng > graph.png")
+	fmt.Println("  gitree export --format svg -o graph.svg ~/projects/myrepo")
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/dates"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/filtering"
+	"github.com/nogo/gitree/internal/tui/views"
+)
+
+// filterFlags are the filter flags shared by the UI and headless commands
+type filterFlags struct {
+	branch string
+	author string
+	tag    string
+	view   string
+	since  string
+	until  string
+}
+
+// register defines the filter flags on fs, with short forms for -b/-a/-t
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.branch, "branch", "", "Filter by branch name")
+	fs.StringVar(&f.author, "author", "", "Filter by author name")
+	fs.StringVar(&f.tag, "tag", "", "Filter by tag name")
+	fs.StringVar(&f.view, "view", "", "Apply a saved view")
+	fs.StringVar(&f.since, "since", "", "Show commits after date")
+	fs.StringVar(&f.until, "until", "", "Show commits before date")
+	fs.StringVar(&f.branch, "b", "", "Filter by branch name")
+	fs.StringVar(&f.author, "a", "", "Filter by author name")
+	fs.StringVar(&f.tag, "t", "", "Filter by tag name")
+}
+
+// manager returns a filter manager set up from the flags the way the UI
+// does at startup: CLI filters first, then a saved view replaces them
+func (f *filterFlags) manager(repo *domain.Repository, repoPath string, now time.Time) (*filtering.Manager, error) {
+	since, until, err := parseTimeRange(f.since, f.until, now)
+	if err != nil {
+		return nil, err
+	}
+
+	m := filtering.New(repo)
+	if f.branch != "" {
+		if _, err := m.SetBranchFilter(f.branch); err != nil {
+			return nil, err
+		}
+	}
+	if f.author != "" {
+		if _, err := m.SetAuthorFilter(f.author); err != nil {
+			return nil, err
+		}
+	}
+	if f.tag != "" {
+		if _, err := m.SetTagFilter(f.tag); err != nil {
+			return nil, err
+		}
+	}
+	m.SetTimeRange(since, until)
+
+	if f.view != "" {
+		v, err := findView(repoPath, f.view)
+		if err != nil {
+			return nil, err
+		}
+		state, err := views.State(v, now)
+		if err != nil {
+			return nil, err
+		}
+		m.ApplyState(state)
+	}
+
+	m.UpdateFilterActive()
+	return m, nil
+}
+
+// parseTimeRange parses --since/--until expressions ("" = open side)
+func parseTimeRange(sinceExpr, untilExpr string, now time.Time) (since, until time.Time, err error) {
+	if sinceExpr != "" {
+		if since, err = dates.Since(sinceExpr, now); err != nil {
+			return since, until, fmt.Errorf("--since: %w", err)
+		}
+	}
+	if untilExpr != "" {
+		if until, err = dates.Until(untilExpr, now); err != nil {
+			return since, until, fmt.Errorf("--until: %w", err)
+		}
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return since, until, fmt.Errorf("--since %s is not before --until %s", sinceExpr, untilExpr)
+	}
+	return since, until, nil
+}
+
+// findView looks up a saved view by name in the repo and global views files
+func findView(repoPath, name string) (config.View, error) {
+	views, err := config.NewViewStore(repoPath).Load()
+	if err != nil {
+		return config.View{}, err
+	}
+	v, ok := config.FindView(views, name)
+	if !ok {
+		if len(views) == 0 {
+			return config.View{}, fmt.Errorf("unknown view %q (no saved views)", name)
+		}
+		return config.View{}, fmt.Errorf("unknown view %q (available: %s)", name, strings.Join(config.ViewNames(views), ", "))
+	}
+	return v, nil
+}
+
+// resolveRepoPath expands ~ and makes the repository path absolute ("" = .)
+func resolveRepoPath(repoPath string) (string, error) {
+	if repoPath == "" {
+		repoPath = "."
+	}
+	if strings.HasPrefix(repoPath, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot resolve home directory: %v", err)
+		}
+		repoPath = filepath.Join(home, repoPath[1:])
+	}
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %s", repoPath)
+	}
+	return absPath, nil
+}
@@ -1,38 +1,59 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/nogo/gitree/internal/config"
 	"github.com/nogo/gitree/internal/git"
+	"github.com/nogo/gitree/internal/placeholder"
 	"github.com/nogo/gitree/internal/tui"
+	"github.com/nogo/gitree/internal/tui/filtering"
+	"github.com/nogo/gitree/internal/tui/theme"
 	"github.com/nogo/gitree/internal/version"
 	"github.com/nogo/gitree/internal/watcher"
 )
 
+// exitConfig is the exit status for an invalid config file (EX_CONFIG)
+const exitConfig = 78
+
 func main() {
+	// Headless subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			os.Exit(runExport(os.Args[2:]))
+		case "report":
+			os.Exit(runReport(os.Args[2:]))
+		}
+	}
+
 	// Define flags
 	var (
-		showVersion   = flag.Bool("version", false, "Show version information")
-		showHelp      = flag.Bool("help", false, "Show help message")
-		checkUpdateF  = flag.Bool("check-update", false, "Check for new releases")
-		filterBranch  = flag.String("branch", "", "Filter by branch name")
-		filterAuthor  = flag.String("author", "", "Filter by author name")
-		filterTag     = flag.String("tag", "", "Filter by tag name")
+		showVersion  = flag.Bool("version", false, "Show version information")
+		showHelp     = flag.Bool("help", false, "Show help message")
+		checkUpdateF = flag.Bool("check-update", false, "Check for new releases")
+		readStdin    = flag.Bool("stdin", false, "Read commits to highlight from stdin")
+		stdinMode    = flag.String("stdin-mode", "highlight", "highlight or show the commits read from stdin")
+		pickMode     = flag.Bool("pick", false, "Print the selected commits or files and exit")
+		pickFormat   = flag.String("format", "{hash}", "Output template for --pick")
+		filters      filterFlags
 	)
+	filters.register(flag.CommandLine)
 
 	// Short flags
 	flag.BoolVar(showVersion, "v", false, "Show version information")
 	flag.BoolVar(showHelp, "h", false, "Show help message")
-	flag.StringVar(filterBranch, "b", "", "Filter by branch name")
-	flag.StringVar(filterAuthor, "a", "", "Filter by author name")
-	flag.StringVar(filterTag, "t", "", "Filter by tag name")
 
 	flag.Usage = printUsage
 	flag.Parse()
@@ -51,32 +72,66 @@ func main() {
 		return
 	}
 
-	// Get repository path from remaining args
-	repoPath := "."
-	if flag.NArg() > 0 {
-		repoPath = flag.Arg(0)
+	// Parse time range before loading the repository so typos fail fast
+	since, until, err := parseTimeRange(filters.since, filters.until, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	// Expand ~ to home directory
-	if strings.HasPrefix(repoPath, "~") {
-		home, err := os.UserHomeDir()
+	if *pickMode {
+		if err := placeholder.Validate(*pickFormat, tui.PickPlaceholders); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --format: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Read piped commits before anything else touches stdin
+	var stdinInput []byte
+	if *readStdin {
+		if *stdinMode != "highlight" && *stdinMode != "show" {
+			fmt.Fprintf(os.Stderr, "Error: invalid --stdin-mode %q (use highlight or show)\n", *stdinMode)
+			os.Exit(1)
+		}
+		if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice != 0 {
+			fmt.Fprintln(os.Stderr, "Error: --stdin expects commits piped in, e.g. git rev-list main | gitree --stdin")
+			os.Exit(1)
+		}
+		stdinInput, err = io.ReadAll(os.Stdin)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: cannot resolve home directory: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: reading stdin: %v\n", err)
 			os.Exit(1)
 		}
-		repoPath = filepath.Join(home, repoPath[1:])
 	}
 
-	// Convert to absolute path
-	absPath, err := filepath.Abs(repoPath)
+	// Get repository path from remaining args
+	repoPath, err := resolveRepoPath(flag.Arg(0))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: invalid path: %s\n", repoPath)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-	repoPath = absPath
+
+	// Invalid config fails before the repository is loaded
+	cfg, err := config.LoadConfig(repoPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	th, err := theme.FromConfig(cfg.Theme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	tui.SetTheme(th)
+
+	// Status messages go to stderr in pick mode so stdout only carries the selection
+	status := os.Stdout
+	if *pickMode {
+		status = os.Stderr
+	}
 
 	// Show loading message
-	fmt.Printf("Loading repository: %s\n", repoPath)
+	fmt.Fprintf(status, "Loading repository: %s\n", repoPath)
 
 	reader := git.NewReader()
 	repo, err := reader.LoadRepository(repoPath)
@@ -85,31 +140,119 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Loaded %d commits, %d branches\n", len(repo.Commits), len(repo.Branches))
+	fmt.Fprintf(status, "Loaded %d commits, %d branches\n", len(repo.Commits), len(repo.Branches))
 
-	// Create watcher (graceful degradation if fails)
-	w, err := watcher.New(repoPath)
-	if err != nil {
-		// Continue without watching
-		w = nil
-	} else {
-		defer w.Stop()
-		w.Start()
+	// Resolve saved view before starting the UI so typos fail fast
+	var view *config.View
+	if filters.view != "" {
+		v, err := findView(repoPath, filters.view)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		view = &v
+	}
+
+	// Resolve piped commits against the loaded history
+	var stdinHashes []string
+	if *readStdin {
+		var unknown []string
+		stdinHashes, unknown, err = filtering.ParseCommitList(bytes.NewReader(stdinInput), repo.Commits)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: reading stdin: %v\n", err)
+			os.Exit(1)
+		}
+		if len(unknown) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d commits from stdin not found (e.g. %s)\n", len(unknown), unknown[0])
+		}
+		if len(stdinHashes) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: no known commits on stdin")
+			os.Exit(1)
+		}
+	}
+
+	// Create watcher unless disabled (graceful degradation if fails)
+	var w *watcher.Watcher
+	if cfg.Defaults.Watch == nil || *cfg.Defaults.Watch {
+		w, err = watcher.New(repoPath)
+		if err != nil {
+			// Continue without watching
+			w = nil
+		} else {
+			defer w.Stop()
+			w.Start()
+		}
 	}
 
 	model := tui.NewModel(repo, repoPath, w, reader)
+	if err := model.ApplyConfig(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	for _, warning := range model.KeyWarnings() {
+		fmt.Fprintf(os.Stderr, "Warning: config: %s\n", warning)
+	}
 
 	// Apply initial filters from CLI
-	if *filterBranch != "" || *filterAuthor != "" || *filterTag != "" {
-		model.ApplyInitialFilters(*filterBranch, *filterAuthor, *filterTag)
+	if filters.branch != "" || filters.author != "" || filters.tag != "" || !since.IsZero() || !until.IsZero() {
+		if err := model.ApplyInitialFilters(filters.branch, filters.author, filters.tag, since, until); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	// Highlight or limit to piped commits
+	if stdinHashes != nil {
+		model.SetStdinCommits(stdinHashes, *stdinMode == "show")
+	}
 
-	if _, err := p.Run(); err != nil {
+	// Apply saved view (replaces CLI filters)
+	if view != nil {
+		cmd, err := model.ApplyView(*view)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		model.AddInitCmd(cmd)
+	}
+
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if *readStdin {
+		// Stdin is the pipe; read keys from the terminal
+		opts = append(opts, tea.WithInputTTY())
+	}
+	if *pickMode {
+		model.EnablePick()
+		// Draw on the terminal; stdout is usually captured by $(...)
+		out := os.Stderr
+		if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+			defer tty.Close()
+			out = tty
+		}
+		lipgloss.SetColorProfile(termenv.NewOutput(out).EnvColorProfile())
+		opts = append(opts, tea.WithOutput(out))
+	}
+	if th.NoColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	p := tea.NewProgram(model, opts...)
+
+	final, err := p.Run()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	if *pickMode {
+		picks := final.(tui.Model).Picks()
+		if len(picks) == 0 {
+			// Quit without picking, like fzf
+			os.Exit(130)
+		}
+		for _, pick := range picks {
+			fmt.Println(pick.Format(*pickFormat))
+		}
+	}
 }
 
 func checkUpdate() {
@@ -171,11 +314,24 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  gitree [flags] [path]")
+	fmt.Println("  gitree export [flags] [path]   Render the graph without the UI (see gitree export -h)")
+	fmt.Println("  gitree report [flags] [path]   Print insights as md, json or csv (see gitree report -h)")
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  -b, --branch <name>   Filter by branch name")
-	fmt.Println("  -a, --author <name>   Filter by author name")
+	fmt.Println("  -a, --author <name>   Filter by author name or email")
 	fmt.Println("  -t, --tag <name>      Filter by tag name")
+	fmt.Println("                        Values are comma-separated substrings or globs (*, ?);")
+	fmt.Println("                        prefix with ! to exclude")
+	fmt.Println("  --since <date>        Show commits from date (2025-03-14, 2025-Q3, 2.weeks.ago, last monday)")
+	fmt.Println("  --until <date>        Show commits up to and including date")
+	fmt.Println("  --view <name>         Apply a saved view")
+	fmt.Println("  --stdin               Read commits from stdin (rev-list, log --oneline, ...)")
+	fmt.Println("                        and highlight them; other commits are dimmed")
+	fmt.Println("  --stdin-mode <mode>   highlight (default) or show only the piped commits")
+	fmt.Println("  --pick                Enter prints the commit (or file in an expanded commit)")
+	fmt.Println("                        and exits; Space selects several")
+	fmt.Println("  --format <template>   Output for --pick: {hash} (default), {short}, {path}, {subject}")
 	fmt.Println("  -v, --version         Show version information")
 	fmt.Println("  --check-update        Check for new releases")
 	fmt.Println("  -h, --help            Show this help message")
@@ -185,5 +341,19 @@ func printUsage() {
 	fmt.Println("  gitree ~/projects/myrepo    Open specific repository")
 	fmt.Println("  gitree --branch main        Filter to main branch")
 	fmt.Println("  gitree --author Alice       Filter to Alice's commits")
+	fmt.Println("  gitree -a '!*bot*'          Hide bot commits")
+	fmt.Println("  gitree -b '!main'           Show commits not yet in main")
 	fmt.Println("  gitree --tag v1.0.0         Filter to v1.0.0 tag history")
+	fmt.Println("  gitree --since 2.weeks.ago  Show the last two weeks")
+	fmt.Println("  gitree --since 2025-Q3 --until 2025-Q3")
+	fmt.Println("                              Show the third quarter of 2025")
+	fmt.Println("  gitree --view release       Apply the saved view \"release\"")
+	fmt.Println("  git rev-list --grep=fix main | gitree --stdin")
+	fmt.Println("                              Highlight commits found by another tool")
+	fmt.Println("  git rebase -i $(gitree --pick)^")
+	fmt.Println("                              Pick the rebase base interactively")
+	fmt.Println("  git show $(gitree --pick --format '{hash}:{path}')")
+	fmt.Println("                              Pick a file version")
+	fmt.Println("  gitree export --format svg -o graph.svg")
+	fmt.Println("                              Write the graph as an SVG image")
 }
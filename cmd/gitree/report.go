@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nogo/gitree/internal/git"
+	"github.com/nogo/gitree/internal/report"
+	"github.com/nogo/gitree/internal/tui/insights"
+)
+
+// runReport implements "gitree report": it computes the insights statistics
+// for the filtered commits without starting the UI. Returns the exit code.
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	var (
+		format    = fs.String("format", string(report.FormatMarkdown), "Output format")
+		output    = fs.String("output", "", "Write to file instead of stdout")
+		top       = fs.Int("top", 10, "Rows in the author and file tables (0 = all)")
+		weekStart = fs.String("week-start", "monday", "First day of the week in the heatmap")
+		filters   filterFlags
+	)
+	fs.StringVar(output, "o", "", "Write to file instead of stdout")
+	filters.register(fs)
+	fs.Usage = printReportUsage
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	f, err := report.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	opts := report.Options{Top: *top}
+	switch strings.ToLower(*weekStart) {
+	case "monday", "mon":
+		opts.WeekStart = insights.WeekStartMonday
+	case "sunday", "sun":
+		opts.WeekStart = insights.WeekStartSunday
+	default:
+		fmt.Fprintf(os.Stderr, "Error: invalid --week-start %q (use monday or sunday)\n", *weekStart)
+		return 2
+	}
+
+	repoPath, err := resolveRepoPath(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	reader := git.NewReader()
+	repo, err := reader.LoadRepository(repoPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	filterManager, err := filters.manager(repo, repoPath, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	commits := filterManager.ApplyFilters().Commits
+	state := filterManager.State()
+	opts.Repository = filepath.Base(repoPath)
+	opts.Since, opts.Until = state.Since, state.Until
+
+	// Unlike the insights view, which samples the newest commits to stay
+	// responsive, the report loads file changes for every commit
+	fileChanges := git.LoadFileChangesParallel(reader, repoPath, commits)
+	r := report.Build(commits, fileChanges, opts)
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := report.Write(w, f, r); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func printReportUsage() {
+	fmt.Println("gitree report - print the insights statistics without the UI")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  gitree report [flags] [path]")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  --format <format>     md (default), json or csv")
+	fmt.Println("  -o, --output <file>   Write to file instead of stdout")
+	fmt.Println("  --top <n>             Rows in the author and file tables (default 10, 0 = all)")
+	fmt.Println("  --week-start <day>    monday (default) or sunday")
+	fmt.Println("  -b, --branch <name>   Filter by branch name")
+	fmt.Println("  -a, --author <name>   Filter by author name or email")
+	fmt.Println("  -t, --tag <name>      Filter by tag name")
+	fmt.Println("  --since <date>        Show commits from date")
+	fmt.Println("  --until <date>        Show commits up to and including date")
+	fmt.Println("  --view <name>         Apply a saved view (search is ignored)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  gitree report --since 30d > review.md")
+	fmt.Println("  gitree report --since \"last month\" --format json -b main")
+	fmt.Println("  gitree report --since 2025-Q3 --until 2025-Q3 --format csv -o q3.csv")
+}
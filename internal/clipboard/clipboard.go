@@ -0,0 +1,59 @@
+// Package clipboard copies text to the user's clipboard, using OSC 52 so it
+// works over SSH and the system clipboard where that is available.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Copy sends text to the terminal with OSC 52 and, in local sessions,
+// also writes it to the system clipboard, since not every terminal
+// supports OSC 52. Fails only if neither is possible.
+func Copy(text string) error {
+	oscErr := copyOSC52(text)
+	if oscErr == nil && remote() {
+		// The system clipboard of the remote machine is of no use
+		return nil
+	}
+	sysErr := clipboard.WriteAll(text)
+	if oscErr == nil || sysErr == nil {
+		return nil
+	}
+	return fmt.Errorf("no clipboard available (%v; %v)", oscErr, sysErr)
+}
+
+// copyOSC52 writes the OSC 52 sequence for text to the terminal
+func copyOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return errors.New("no terminal for OSC 52")
+	}
+	defer tty.Close()
+	return writeOSC52(tty, text, os.Getenv("TERM"), os.Getenv("TMUX") != "")
+}
+
+// writeOSC52 writes the OSC 52 sequence for text to w, wrapped for tmux
+// or screen so the multiplexer passes it on to the outer terminal
+func writeOSC52(w io.Writer, text, term string, tmux bool) error {
+	seq := osc52.New(text)
+	switch {
+	case tmux:
+		seq = seq.Tmux()
+	case strings.HasPrefix(term, "screen"):
+		seq = seq.Screen()
+	}
+	_, err := seq.WriteTo(w)
+	return err
+}
+
+// remote returns whether gitree runs in an SSH session
+func remote() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
@@ -0,0 +1,34 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestWriteOSC52(t *testing.T) {
+	payload := base64.StdEncoding.EncodeToString([]byte("abc1234"))
+	tests := []struct {
+		name   string
+		term   string
+		tmux   bool
+		prefix string
+	}{
+		{"plain terminal", "xterm-256color", false, "\x1b]52;c;" + payload},
+		{"tmux passthrough", "screen-256color", true, "\x1bPtmux;\x1b\x1b]52;c;" + payload},
+		{"screen passthrough", "screen", false, "\x1bP\x1b]52;c;" + payload},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeOSC52(&buf, "abc1234", tc.term, tc.tmux); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(buf.String(), tc.prefix) {
+				t.Errorf("sequence = %q, want prefix %q", buf.String(), tc.prefix)
+			}
+		})
+	}
+}
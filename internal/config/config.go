@@ -0,0 +1,362 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nogo/gitree/internal/placeholder"
+	"gopkg.in/yaml.v3"
+)
+
+// configBaseName is the main config file in both config directories,
+// followed by one of configExtensions
+const configBaseName = "config"
+
+// configExtensions are the supported config formats
+var configExtensions = []string{".toml", ".yaml", ".yml"}
+
+// Command modes
+const (
+	CommandSuspend    = "suspend"    // hand the terminal to the command until it exits
+	CommandBackground = "background" // run detached and show the output in a popup
+)
+
+// CommandPlaceholders are the placeholders available in command templates
+var CommandPlaceholders = []string{"hash", "short", "subject", "path", "branch", "root"}
+
+// Config is the main configuration file.
+// The repo config overrides the global one setting by setting.
+type Config struct {
+	Theme    Theme                         `toml:"theme" yaml:"theme"`
+	Keys     map[string]map[string]KeyList `toml:"keys" yaml:"keys"` // mode → action → keys; an empty list unbinds
+	Defaults Defaults                      `toml:"defaults" yaml:"defaults"`
+	Commands []Command                     `toml:"commands" yaml:"commands"`
+}
+
+// Theme selects a built-in theme and overrides some of its colors.
+// Colors are xterm-256 numbers or #rrggbb.
+type Theme struct {
+	Name     string            `toml:"name" yaml:"name"`     // dark, light or none
+	Colors   map[string]string `toml:"colors" yaml:"colors"` // role → color
+	Lanes    []string          `toml:"lanes" yaml:"lanes"`
+	Branches []string          `toml:"branches" yaml:"branches"`
+	Heat     []string          `toml:"heat" yaml:"heat"`
+}
+
+// Defaults are the initial states of toggles. Nil means built-in default.
+type Defaults struct {
+	Histogram       *bool  `toml:"histogram" yaml:"histogram"`               // show the histogram
+	HistogramHeight *int   `toml:"histogram_height" yaml:"histogram_height"` // rows of density bars
+	Insights        *bool  `toml:"insights" yaml:"insights"`                 // open the insights view
+	Watch           *bool  `toml:"watch" yaml:"watch"`                       // reload when the repository changes
+	SearchMode      string `toml:"search_mode" yaml:"search_mode"`           // substring, regex or fuzzy
+	WeekStart       string `toml:"week_start" yaml:"week_start"`             // monday or sunday
+	DiffLayout      string `toml:"diff_layout" yaml:"diff_layout"`           // unified, split or auto
+	WordDiff        string `toml:"word_diff" yaml:"word_diff"`               // word, char, whitespace or none
+	Syntax          *bool  `toml:"syntax" yaml:"syntax"`                     // highlight code in diffs
+}
+
+// Limits of Defaults.HistogramHeight
+const (
+	MinHistogramHeight = 1
+	MaxHistogramHeight = 8
+)
+
+// KeyList is one key or a list of keys, e.g. "?" or ["?", "f1"]
+type KeyList []string
+
+// UnmarshalTOML accepts a string or an array of strings
+func (k *KeyList) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case string:
+		*k = KeyList{v}
+		return nil
+	case []any:
+		keys := make(KeyList, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("keys must be strings, got %v", item)
+			}
+			keys[i] = s
+		}
+		*k = keys
+		return nil
+	}
+	return fmt.Errorf("want a key or a list of keys, got %v", v)
+}
+
+// UnmarshalYAML accepts a string or a sequence of strings
+func (k *KeyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = KeyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return fmt.Errorf("line %d: want a key or a list of keys", node.Line)
+	}
+	*k = keys
+	return nil
+}
+
+// Command is an external command bound to a key. Placeholders in Run are
+// replaced with shell-quoted values, so they must not be quoted again.
+type Command struct {
+	Key     string `toml:"key" yaml:"key"`
+	Name    string `toml:"name" yaml:"name"`
+	Run     string `toml:"run" yaml:"run"`
+	Mode    string `toml:"mode" yaml:"mode"`       // CommandSuspend (default) or CommandBackground
+	Confirm bool   `toml:"confirm" yaml:"confirm"` // ask before running, for commands that change things
+}
+
+// Title returns the name shown in help and popups
+func (c Command) Title() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.Run
+}
+
+// Background returns whether the command runs without suspending the UI
+func (c Command) Background() bool {
+	return c.Mode == CommandBackground
+}
+
+// LoadConfig reads the global config and the config of the repository at
+// repoPath; the repo config wins. Each directory may hold config.toml or
+// config.yaml, but not both.
+func LoadConfig(repoPath string) (Config, error) {
+	var paths []string
+	if dir, err := Dir(); err == nil {
+		path, err := findConfig(dir)
+		if err != nil {
+			return Config{}, err
+		}
+		paths = append(paths, path)
+	}
+	if dir := RepoDir(repoPath); dir != "" {
+		path, err := findConfig(dir)
+		if err != nil {
+			return Config{}, err
+		}
+		paths = append(paths, path)
+	}
+	return loadConfig(paths...)
+}
+
+// findConfig returns the config file in dir ("" if there is none)
+func findConfig(dir string) (string, error) {
+	found := ""
+	for _, ext := range configExtensions {
+		path := filepath.Join(dir, configBaseName+ext)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if found != "" {
+			return "", fmt.Errorf("both %s and %s exist; keep one", found, path)
+		}
+		found = path
+	}
+	return found, nil
+}
+
+// loadConfig merges the config files at paths, later files winning.
+// Empty paths are skipped.
+func loadConfig(paths ...string) (Config, error) {
+	var cfg Config
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		file, err := readConfig(path)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.merge(file)
+	}
+	return cfg, nil
+}
+
+// merge overrides cfg with every setting present in other
+func (cfg *Config) merge(other Config) {
+	if other.Theme.Name != "" {
+		cfg.Theme.Name = other.Theme.Name
+	}
+	for name, color := range other.Theme.Colors {
+		if cfg.Theme.Colors == nil {
+			cfg.Theme.Colors = make(map[string]string)
+		}
+		cfg.Theme.Colors[name] = color
+	}
+	if other.Theme.Lanes != nil {
+		cfg.Theme.Lanes = other.Theme.Lanes
+	}
+	if other.Theme.Branches != nil {
+		cfg.Theme.Branches = other.Theme.Branches
+	}
+	if other.Theme.Heat != nil {
+		cfg.Theme.Heat = other.Theme.Heat
+	}
+
+	for mode, actions := range other.Keys {
+		if cfg.Keys == nil {
+			cfg.Keys = make(map[string]map[string]KeyList)
+		}
+		if cfg.Keys[mode] == nil {
+			cfg.Keys[mode] = make(map[string]KeyList)
+		}
+		for action, keys := range actions {
+			cfg.Keys[mode][action] = keys
+		}
+	}
+
+	d := other.Defaults
+	if d.Histogram != nil {
+		cfg.Defaults.Histogram = d.Histogram
+	}
+	if d.HistogramHeight != nil {
+		cfg.Defaults.HistogramHeight = d.HistogramHeight
+	}
+	if d.Insights != nil {
+		cfg.Defaults.Insights = d.Insights
+	}
+	if d.Watch != nil {
+		cfg.Defaults.Watch = d.Watch
+	}
+	if d.SearchMode != "" {
+		cfg.Defaults.SearchMode = d.SearchMode
+	}
+	if d.WeekStart != "" {
+		cfg.Defaults.WeekStart = d.WeekStart
+	}
+	if d.DiffLayout != "" {
+		cfg.Defaults.DiffLayout = d.DiffLayout
+	}
+	if d.WordDiff != "" {
+		cfg.Defaults.WordDiff = d.WordDiff
+	}
+	if d.Syntax != nil {
+		cfg.Defaults.Syntax = d.Syntax
+	}
+
+	for _, c := range other.Commands {
+		cfg.Commands = replaceCommand(cfg.Commands, c)
+	}
+}
+
+// replaceCommand adds c, dropping any command bound to the same key
+func replaceCommand(commands []Command, c Command) []Command {
+	for i, existing := range commands {
+		if existing.Key == c.Key {
+			commands[i] = c
+			return commands
+		}
+	}
+	return append(commands, c)
+}
+
+// readConfig parses and validates a config file; a missing file is empty
+func readConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if strings.HasSuffix(path, ".toml") {
+		err = decodeTOML(data, &cfg)
+	} else {
+		err = decodeYAML(data, &cfg)
+	}
+	if err == nil {
+		err = cfg.validate()
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// decodeTOML decodes data, rejecting unknown settings
+func decodeTOML(data []byte, cfg *Config) error {
+	meta, err := toml.Decode(string(data), cfg)
+	if err != nil {
+		return err
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return fmt.Errorf("unknown setting %q", undecoded[0].String())
+	}
+	return nil
+}
+
+// decodeYAML decodes data, rejecting unknown settings
+func decodeYAML(data []byte, cfg *Config) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// validate checks the settings that decoding cannot. Theme colors and key
+// actions are checked by the UI, which defines them.
+func (cfg Config) validate() error {
+	d := cfg.Defaults
+	if h := d.HistogramHeight; h != nil && (*h < MinHistogramHeight || *h > MaxHistogramHeight) {
+		return fmt.Errorf("defaults.histogram_height: %d is out of range %d-%d", *h, MinHistogramHeight, MaxHistogramHeight)
+	}
+	switch d.SearchMode {
+	case "", "substring", "regex", "fuzzy":
+	default:
+		return fmt.Errorf("defaults.search_mode: invalid mode %q (use substring, regex or fuzzy)", d.SearchMode)
+	}
+	switch d.WeekStart {
+	case "", "monday", "sunday":
+	default:
+		return fmt.Errorf("defaults.week_start: invalid day %q (use monday or sunday)", d.WeekStart)
+	}
+	switch d.DiffLayout {
+	case "", "unified", "split", "auto":
+	default:
+		return fmt.Errorf("defaults.diff_layout: invalid layout %q (use unified, split or auto)", d.DiffLayout)
+	}
+	switch d.WordDiff {
+	case "", "word", "char", "whitespace", "none":
+	default:
+		return fmt.Errorf("defaults.word_diff: invalid mode %q (use word, char, whitespace or none)", d.WordDiff)
+	}
+
+	keys := make(map[string]bool)
+	for i, c := range cfg.Commands {
+		if strings.TrimSpace(c.Key) == "" {
+			return fmt.Errorf("commands[%d]: key is empty", i)
+		}
+		if keys[c.Key] {
+			return fmt.Errorf("commands[%d]: key %q is bound twice", i, c.Key)
+		}
+		keys[c.Key] = true
+		if strings.TrimSpace(c.Run) == "" {
+			return fmt.Errorf("commands[%d] (%s): run is empty", i, c.Key)
+		}
+		switch c.Mode {
+		case "", CommandSuspend, CommandBackground:
+		default:
+			return fmt.Errorf("commands[%d] (%s): invalid mode %q (use %s or %s)", i, c.Key, c.Mode, CommandSuspend, CommandBackground)
+		}
+		if err := placeholder.Validate(c.Run, CommandPlaceholders); err != nil {
+			return fmt.Errorf("commands[%d] (%s): %w", i, c.Key, err)
+		}
+	}
+	return nil
+}
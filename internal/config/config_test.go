@@ -0,0 +1,195 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigRepoOverridesGlobal(t *testing.T) {
+	global := writeConfig(t, "config.toml", `
+[[commands]]
+key = "o"
+name = "Open in review"
+run = "xdg-open https://review.example.com/c/{hash}"
+mode = "background"
+
+[[commands]]
+key = "S"
+run = "git show {hash}"
+`)
+	repo := writeConfig(t, "config.toml", `
+[[commands]]
+key = "o"
+run = "open-review {short}"
+confirm = true
+`)
+
+	cfg, err := loadConfig(global, repo, filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if len(cfg.Commands) != 2 {
+		t.Fatalf("got %d commands, want 2", len(cfg.Commands))
+	}
+	open := cfg.Commands[0]
+	if open.Run != "open-review {short}" || !open.Confirm || open.Background() {
+		t.Errorf("repo command should replace global one, got %+v", open)
+	}
+	if show := cfg.Commands[1]; show.Title() != "git show {hash}" || show.Background() {
+		t.Errorf("unexpected command %+v", show)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"syntax", `[[commands]`, "toml"},
+		{"unknown setting", "[[commands]]\nkey = \"x\"\nrun = \"true\"\nshell = \"zsh\"", `unknown setting "commands.shell"`},
+		{"empty key", "[[commands]]\nrun = \"true\"", "key is empty"},
+		{"empty run", "[[commands]]\nkey = \"x\"", "run is empty"},
+		{"bad mode", "[[commands]]\nkey = \"x\"\nrun = \"true\"\nmode = \"popup\"", `invalid mode "popup"`},
+		{"bad placeholder", "[[commands]]\nkey = \"x\"\nrun = \"echo {sha}\"", "unknown placeholder {sha}"},
+		{"duplicate key", "[[commands]]\nkey = \"x\"\nrun = \"a\"\n[[commands]]\nkey = \"x\"\nrun = \"b\"", `key "x" is bound twice`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeConfig(t, "config.toml", tc.content)
+			_, err := loadConfig(path)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.want) || !strings.Contains(err.Error(), path) {
+				t.Errorf("error %q should mention %q and the file", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigMergesSettings(t *testing.T) {
+	global := writeConfig(t, "config.toml", `
+[theme]
+name = "light"
+lanes = ["1", "2"]
+[theme.colors]
+accent = "162"
+muted = "#777777"
+
+[keys.list]
+help = "?"
+quit = ["q", "ctrl+c"]
+
+[keys.diff]
+close = "esc"
+
+[defaults]
+histogram = false
+histogram_height = 3
+week_start = "sunday"
+`)
+	repo := writeConfig(t, "config.yaml", `
+theme:
+  colors:
+    accent: "99"
+keys:
+  list:
+    help: [f1, "?"]
+    insights: []
+defaults:
+  histogram: true
+  search_mode: fuzzy
+  diff_layout: auto
+`)
+
+	cfg, err := loadConfig(global, repo)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	th := cfg.Theme
+	if th.Name != "light" || th.Colors["accent"] != "99" || th.Colors["muted"] != "#777777" || len(th.Lanes) != 2 {
+		t.Errorf("theme not merged: %+v", th)
+	}
+	list := cfg.Keys["list"]
+	if got := strings.Join(list["help"], ","); got != "f1,?" {
+		t.Errorf("help keys = %q, want f1,?", got)
+	}
+	if got := strings.Join(list["quit"], ","); got != "q,ctrl+c" {
+		t.Errorf("quit keys = %q, want q,ctrl+c", got)
+	}
+	if got := strings.Join(cfg.Keys["diff"]["close"], ","); got != "esc" {
+		t.Errorf("diff close keys = %q, want esc", got)
+	}
+	if keys, ok := list["insights"]; !ok || len(keys) != 0 {
+		t.Errorf("insights should be unbound, got %q (present: %v)", keys, ok)
+	}
+	d := cfg.Defaults
+	if d.Histogram == nil || !*d.Histogram || d.HistogramHeight == nil || *d.HistogramHeight != 3 {
+		t.Errorf("defaults histogram not merged: %+v", d)
+	}
+	if d.SearchMode != "fuzzy" || d.WeekStart != "sunday" || d.DiffLayout != "auto" || d.Insights != nil {
+		t.Errorf("defaults not merged: %+v", d)
+	}
+}
+
+func TestLoadConfigSettingErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"yaml unknown setting", "config.yaml", "defaults:\n  histogam: true", "field histogam not found"},
+		{"yaml wrong type", "config.yml", "defaults:\n  watch: sometimes", "cannot unmarshal"},
+		{"histogram height", "config.toml", "[defaults]\nhistogram_height = 20", "defaults.histogram_height: 20 is out of range 1-8"},
+		{"search mode", "config.toml", "[defaults]\nsearch_mode = \"glob\"", `defaults.search_mode: invalid mode "glob"`},
+		{"week start", "config.yaml", "defaults:\n  week_start: friday", `defaults.week_start: invalid day "friday"`},
+		{"diff layout", "config.toml", "[defaults]\ndiff_layout = \"wide\"", `defaults.diff_layout: invalid layout "wide"`},
+		{"word diff", "config.yaml", "defaults:\n  word_diff: line", `defaults.word_diff: invalid mode "line"`},
+		{"key type", "config.toml", "[keys.list]\nhelp = 1", "want a key or a list of keys"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeConfig(t, tc.file, tc.content)
+			_, err := loadConfig(path)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.want) || !strings.Contains(err.Error(), path) {
+				t.Errorf("error %q should mention %q and the file", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRejectsTwoFormats(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", root)
+	dir := filepath.Join(root, "gitree")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"config.toml", "config.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := LoadConfig(t.TempDir()); err == nil || !strings.Contains(err.Error(), "keep one") {
+		t.Errorf("error = %v, want both files reported", err)
+	}
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// marksFileName is the file holding the marks of a repository
+const marksFileName = "marks.toml"
+
+// Mark is a named bookmark on a commit. Subject and author are kept to
+// find the commit again after it is rewritten (rebase, amend).
+type Mark struct {
+	Name    string    `toml:"-"`
+	Hash    string    `toml:"hash"`
+	Subject string    `toml:"subject"`
+	Author  string    `toml:"author,omitempty"`
+	Set     time.Time `toml:"set"`
+}
+
+// marksFile is the on-disk layout: one [marks.<name>] table per mark
+type marksFile struct {
+	Marks map[string]Mark `toml:"marks"`
+}
+
+// MarkStore loads and saves the marks of one repository
+type MarkStore struct {
+	path string // "" if the repository has no .git directory
+}
+
+// NewMarkStore returns a store for the repository at repoPath, kept in
+// .git/gitree/marks.toml
+func NewMarkStore(repoPath string) MarkStore {
+	var s MarkStore
+	if dir := RepoDir(repoPath); dir != "" {
+		s.path = filepath.Join(dir, marksFileName)
+	}
+	return s
+}
+
+// ValidMarkName reports whether name can name a mark: one letter or digit
+func ValidMarkName(name string) bool {
+	if len(name) != 1 {
+		return false
+	}
+	c := name[0]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// Load returns all marks sorted by name
+func (s MarkStore) Load() ([]Mark, error) {
+	if s.path == "" {
+		return nil, nil
+	}
+	file, err := readMarks(s.path)
+	if err != nil {
+		return nil, err
+	}
+	marks := make([]Mark, 0, len(file.Marks))
+	for name, m := range file.Marks {
+		m.Name = name
+		marks = append(marks, m)
+	}
+	sort.Slice(marks, func(i, j int) bool {
+		return marks[i].Name < marks[j].Name
+	})
+	return marks, nil
+}
+
+// Save stores m under m.Name, replacing any mark with that name
+func (s MarkStore) Save(m Mark) error {
+	if !ValidMarkName(m.Name) {
+		return fmt.Errorf("invalid mark name %q (use a letter or digit)", m.Name)
+	}
+	if s.path == "" {
+		return errors.New("no repo config location available")
+	}
+	file, err := readMarks(s.path)
+	if err != nil {
+		return err
+	}
+	if file.Marks == nil {
+		file.Marks = make(map[string]Mark)
+	}
+	file.Marks[m.Name] = m
+	return writeMarks(s.path, file)
+}
+
+// Delete removes the mark with the given name
+func (s MarkStore) Delete(name string) error {
+	if s.path == "" {
+		return errors.New("no repo config location available")
+	}
+	file, err := readMarks(s.path)
+	if err != nil {
+		return err
+	}
+	if _, ok := file.Marks[name]; !ok {
+		return fmt.Errorf("mark %q not found", name)
+	}
+	delete(file.Marks, name)
+	return writeMarks(s.path, file)
+}
+
+// readMarks parses a marks file; a missing file yields no marks
+func readMarks(path string) (marksFile, error) {
+	var file marksFile
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return file, err
+	}
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return file, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// writeMarks encodes marks to path
+func writeMarks(path string, file marksFile) error {
+	var buf bytes.Buffer
+	buf.WriteString("# gitree marks\n\n")
+	if err := toml.NewEncoder(&buf).Encode(file); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMarkStoreRoundTrip(t *testing.T) {
+	repo := filepath.Join(t.TempDir(), "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	store := NewMarkStore(repo)
+
+	set := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, m := range []Mark{
+		{Name: "b", Hash: "bbb", Subject: "second", Set: set},
+		{Name: "a", Hash: "aaa", Subject: "first", Author: "Alice", Set: set},
+		{Name: "b", Hash: "ccc", Subject: "moved", Set: set}, // replaces b
+	} {
+		if err := store.Save(m); err != nil {
+			t.Fatalf("Save(%s): %v", m.Name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".git", "gitree", "marks.toml")); err != nil {
+		t.Fatalf("marks file not written: %v", err)
+	}
+
+	marks, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := []Mark{
+		{Name: "a", Hash: "aaa", Subject: "first", Author: "Alice", Set: set},
+		{Name: "b", Hash: "ccc", Subject: "moved", Set: set},
+	}
+	if len(marks) != len(want) {
+		t.Fatalf("Load = %+v, want %+v", marks, want)
+	}
+	for i := range want {
+		if !marks[i].Set.Equal(want[i].Set) {
+			t.Errorf("mark %s set = %v, want %v", marks[i].Name, marks[i].Set, want[i].Set)
+		}
+		marks[i].Set = want[i].Set
+		if marks[i] != want[i] {
+			t.Errorf("mark %d = %+v, want %+v", i, marks[i], want[i])
+		}
+	}
+
+	if err := store.Delete("a"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := store.Delete("a"); err == nil {
+		t.Error("deleting a missing mark should fail")
+	}
+	if marks, _ := store.Load(); len(marks) != 1 || marks[0].Name != "b" {
+		t.Errorf("after Delete: %+v", marks)
+	}
+}
+
+func TestMarkStoreRejectsInvalidNames(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"", "ab", "'", " "} {
+		if err := NewMarkStore(repo).Save(Mark{Name: name, Hash: "aaa"}); err == nil {
+			t.Errorf("Save(%q) should fail", name)
+		}
+	}
+}
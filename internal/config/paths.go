@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// appName is the directory name used under the config directories
+const appName = "gitree"
+
+// Dir returns the global config directory: $XDG_CONFIG_HOME/gitree,
+// falling back to ~/.config/gitree.
+func Dir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.New("cannot resolve config directory: $XDG_CONFIG_HOME and $HOME are unset")
+	}
+	return filepath.Join(home, ".config", appName), nil
+}
+
+// RepoDir returns the per-repository config directory inside .git, so repo
+// settings stay private and never show up as untracked files.
+// Returns "" if repoPath has no .git directory (e.g. worktrees, bare repos).
+func RepoDir(repoPath string) string {
+	gitDir := filepath.Join(repoPath, ".git")
+	info, err := os.Stat(gitDir)
+	if err != nil || !info.IsDir() {
+		return ""
+	}
+	return filepath.Join(gitDir, appName)
+}
+
+// writeFileAtomic writes data to a temp file and renames it over path,
+// creating parent directories as needed
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// viewsFileName is the file holding saved views in both config directories
+const viewsFileName = "views.toml"
+
+// Scope tells where a view is stored
+type Scope int
+
+const (
+	ScopeRepo   Scope = iota // .git/gitree/views.toml of the current repository
+	ScopeGlobal              // $XDG_CONFIG_HOME/gitree/views.toml
+)
+
+// String returns the scope name shown in the views picker
+func (s Scope) String() string {
+	if s == ScopeGlobal {
+		return "global"
+	}
+	return "repo"
+}
+
+// View is a named preset of filters and search.
+// Dates are kept as written so they can be absolute or relative.
+type View struct {
+	Name  string `toml:"-"`
+	Scope Scope  `toml:"-"`
+
+	Branches []string `toml:"branches,omitempty"`
+	Authors  []string `toml:"authors,omitempty"`
+	Tags     []string `toml:"tags,omitempty"`
+	// Excluded entries, hidden even when otherwise selected
+	ExcludeBranches []string `toml:"exclude_branches,omitempty"`
+	ExcludeAuthors  []string `toml:"exclude_authors,omitempty"`
+	ExcludeTags     []string `toml:"exclude_tags,omitempty"`
+	Highlight       string   `toml:"highlight,omitempty"`
+	Since           string   `toml:"since,omitempty"`
+	Until           string   `toml:"until,omitempty"`
+	Search          string   `toml:"search,omitempty"`
+	SearchMode      string   `toml:"search_mode,omitempty"`
+}
+
+// viewsFile is the on-disk layout: one [views.<name>] table per view
+type viewsFile struct {
+	Views map[string]View `toml:"views"`
+}
+
+// ViewStore loads and saves views for one repository
+type ViewStore struct {
+	globalPath string // "" if the global config dir cannot be resolved
+	repoPath   string // "" if the repository has no .git directory
+}
+
+// NewViewStore returns a store for the repository at repoPath
+func NewViewStore(repoPath string) ViewStore {
+	var s ViewStore
+	if dir, err := Dir(); err == nil {
+		s.globalPath = filepath.Join(dir, viewsFileName)
+	}
+	if dir := RepoDir(repoPath); dir != "" {
+		s.repoPath = filepath.Join(dir, viewsFileName)
+	}
+	return s
+}
+
+// path returns the views file for a scope
+func (s ViewStore) path(scope Scope) (string, error) {
+	path := s.repoPath
+	if scope == ScopeGlobal {
+		path = s.globalPath
+	}
+	if path == "" {
+		return "", fmt.Errorf("no %s config location available", scope)
+	}
+	return path, nil
+}
+
+// Load returns all views sorted by name. Repo views shadow global views
+// with the same name.
+func (s ViewStore) Load() ([]View, error) {
+	byName := make(map[string]View)
+	for _, scope := range []Scope{ScopeGlobal, ScopeRepo} {
+		path, err := s.path(scope)
+		if err != nil {
+			continue
+		}
+		file, err := readViews(path)
+		if err != nil {
+			return nil, err
+		}
+		for name, v := range file.Views {
+			v.Name = name
+			v.Scope = scope
+			byName[name] = v
+		}
+	}
+
+	views := make([]View, 0, len(byName))
+	for _, v := range byName {
+		views = append(views, v)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+	return views, nil
+}
+
+// Save stores v under v.Name in v.Scope, replacing any view with that name
+func (s ViewStore) Save(v View) error {
+	name := strings.TrimSpace(v.Name)
+	if name == "" {
+		return errors.New("view name is empty")
+	}
+	path, err := s.path(v.Scope)
+	if err != nil {
+		return err
+	}
+	file, err := readViews(path)
+	if err != nil {
+		return err
+	}
+	if file.Views == nil {
+		file.Views = make(map[string]View)
+	}
+	file.Views[name] = v
+	return writeViews(path, file)
+}
+
+// Delete removes the view with the given name from scope
+func (s ViewStore) Delete(name string, scope Scope) error {
+	path, err := s.path(scope)
+	if err != nil {
+		return err
+	}
+	file, err := readViews(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := file.Views[name]; !ok {
+		return fmt.Errorf("view %q not found", name)
+	}
+	delete(file.Views, name)
+	return writeViews(path, file)
+}
+
+// FindView returns the view with the given name
+func FindView(views []View, name string) (View, bool) {
+	for _, v := range views {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return View{}, false
+}
+
+// ViewNames returns the names of views
+func ViewNames(views []View) []string {
+	names := make([]string, len(views))
+	for i, v := range views {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// readViews parses a views file; a missing file yields no views
+func readViews(path string) (viewsFile, error) {
+	var file viewsFile
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return file, err
+	}
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return file, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// writeViews encodes views to path
+func writeViews(path string, file viewsFile) error {
+	var buf bytes.Buffer
+	buf.WriteString("# gitree saved views\n\n")
+	if err := toml.NewEncoder(&buf).Encode(file); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestStore returns a store with a fake repository and global config dir
+func newTestStore(t *testing.T) (ViewStore, string) {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return NewViewStore(repo), repo
+}
+
+func TestViewStoreRoundTrip(t *testing.T) {
+	store, repo := newTestStore(t)
+
+	want := View{
+		Name:       "release",
+		Scope:      ScopeRepo,
+		Branches:   []string{"main", "release/1.x"},
+		Authors:    []string{"alice"},
+		Tags:       []string{"v1.0.0"},
+		Highlight:  "bob",
+		Since:      "2025-01-01",
+		Until:      "2025-07-01",
+		Search:     `fix -author:"bot"`,
+		SearchMode: "regex",
+	}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(repo, ".git", "gitree", "views.toml")); err != nil {
+		t.Fatalf("repo views file not written: %v", err)
+	}
+
+	views, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(views) != 1 {
+		t.Fatalf("Load returned %d views, want 1", len(views))
+	}
+	got := views[0]
+	if got.Name != want.Name || got.Scope != want.Scope || got.Search != want.Search ||
+		got.Since != want.Since || got.Until != want.Until || got.Highlight != want.Highlight ||
+		got.SearchMode != want.SearchMode ||
+		strings.Join(got.Branches, ",") != strings.Join(want.Branches, ",") ||
+		strings.Join(got.Authors, ",") != strings.Join(want.Authors, ",") ||
+		strings.Join(got.Tags, ",") != strings.Join(want.Tags, ",") {
+		t.Errorf("Load = %+v, want %+v", got, want)
+	}
+}
+
+func TestViewStoreRepoShadowsGlobal(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	views := []View{
+		{Name: "mine", Scope: ScopeGlobal, Authors: []string{"alice"}},
+		{Name: "bots", Scope: ScopeGlobal, Search: "author:*bot*"},
+		{Name: "mine", Scope: ScopeRepo, Authors: []string{"alice", "al"}},
+	}
+	for _, v := range views {
+		if err := store.Save(v); err != nil {
+			t.Fatalf("Save(%s): %v", v.Name, err)
+		}
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if names := strings.Join(ViewNames(loaded), ","); names != "bots,mine" {
+		t.Fatalf("names = %s, want bots,mine", names)
+	}
+	mine, _ := FindView(loaded, "mine")
+	if mine.Scope != ScopeRepo || len(mine.Authors) != 2 {
+		t.Errorf("mine = %+v, want repo view with 2 authors", mine)
+	}
+
+	// Deleting the repo view uncovers the global one
+	if err := store.Delete("mine", ScopeRepo); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	loaded, _ = store.Load()
+	mine, ok := FindView(loaded, "mine")
+	if !ok || mine.Scope != ScopeGlobal {
+		t.Errorf("after delete mine = %+v, want global view", mine)
+	}
+
+	if err := store.Delete("missing", ScopeGlobal); err == nil {
+		t.Error("Delete of unknown view should fail")
+	}
+}
+
+func TestViewStoreErrors(t *testing.T) {
+	store, repo := newTestStore(t)
+
+	if err := store.Save(View{Name: "  "}); err == nil {
+		t.Error("Save with empty name should fail")
+	}
+
+	path := filepath.Join(repo, ".git", "gitree", "views.toml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("[views.broken\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := store.Load()
+	if err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Load error = %v, want error mentioning %s", err, path)
+	}
+
+	// Without a .git directory only global views are available
+	noRepo := NewViewStore(t.TempDir())
+	if err := noRepo.Save(View{Name: "x", Scope: ScopeRepo}); err == nil {
+		t.Error("Save to repo scope without .git should fail")
+	}
+}
@@ -0,0 +1,185 @@
+// Package dates parses the absolute and relative date expressions accepted by
+// --since/--until and saved views.
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Range is the span of time an expression denotes. Calendar expressions
+// cover whole periods ("2025-Q3" is Jul 1 - Oct 1); instants such as
+// "2.weeks.ago" have Start == End. End is exclusive.
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+var (
+	// 2.weeks.ago, 2 weeks ago, 1.day.ago
+	agoRe = regexp.MustCompile(`^(\d+)[. ]+([a-z]+)[. ]+ago$`)
+	// 30d, 2w, 6m, 1y, 12h
+	compactRe = regexp.MustCompile(`^(\d+)(h|d|w|m|y)$`)
+	// 2025-Q3
+	quarterRe = regexp.MustCompile(`^(\d{4})-q([1-4])$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+	"wednesday": time.Wednesday, "thursday": time.Thursday, "friday": time.Friday,
+	"saturday": time.Saturday,
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Parse parses expr relative to now. Supported forms:
+//
+//	2025-03-14, 2025-03, 2025, 2025-Q3, RFC 3339 timestamps
+//	now, today, yesterday
+//	2.weeks.ago, 3 days ago, 30d, 2w, 6m, 1y, 12h
+//	monday, last monday, this week, last month, last year
+//
+// Calendar expressions use the location of now; weeks start on Monday.
+func Parse(expr string, now time.Time) (Range, error) {
+	s := strings.ToLower(strings.TrimSpace(expr))
+	if s == "" {
+		return Range{}, fmt.Errorf("empty date")
+	}
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch s {
+	case "now":
+		return instant(now), nil
+	case "today":
+		return days(today, 1), nil
+	case "yesterday":
+		return days(today.AddDate(0, 0, -1), 1), nil
+	}
+
+	if m := agoRe.FindStringSubmatch(s); m != nil {
+		return ago(now, m[1], m[2], expr)
+	}
+	if m := compactRe.FindStringSubmatch(s); m != nil {
+		units := map[string]string{"h": "hours", "d": "days", "w": "weeks", "m": "months", "y": "years"}
+		return ago(now, m[1], units[m[2]], expr)
+	}
+	if m := quarterRe.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		q, _ := strconv.Atoi(m[2])
+		start := time.Date(year, time.Month(3*(q-1)+1), 1, 0, 0, 0, 0, loc)
+		return Range{Start: start, End: start.AddDate(0, 3, 0)}, nil
+	}
+
+	// Absolute dates, most specific first
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return days(t, 1), nil
+	}
+	if t, err := time.ParseInLocation("2006-01", s, loc); err == nil {
+		return Range{Start: t, End: t.AddDate(0, 1, 0)}, nil
+	}
+	if len(s) == 4 {
+		if t, err := time.ParseInLocation("2006", s, loc); err == nil {
+			return Range{Start: t, End: t.AddDate(1, 0, 0)}, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(expr)); err == nil {
+		return instant(t), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, loc); err == nil {
+		return instant(t), nil
+	}
+
+	// Weekdays and named periods
+	words := strings.Fields(s)
+	if len(words) == 1 {
+		if wd, ok := weekdays[words[0]]; ok {
+			// Most recent such day, today included
+			back := (int(today.Weekday()) - int(wd) + 7) % 7
+			return days(today.AddDate(0, 0, -back), 1), nil
+		}
+	}
+	if len(words) == 2 && (words[0] == "last" || words[0] == "this") {
+		last := words[0] == "last"
+		if wd, ok := weekdays[words[1]]; ok && last {
+			// Most recent such day before today
+			back := (int(today.Weekday()) - int(wd) + 7) % 7
+			if back == 0 {
+				back = 7
+			}
+			return days(today.AddDate(0, 0, -back), 1), nil
+		}
+		switch words[1] {
+		case "week":
+			start := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+			if last {
+				start = start.AddDate(0, 0, -7)
+			}
+			return days(start, 7), nil
+		case "month":
+			start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, loc)
+			if last {
+				start = start.AddDate(0, -1, 0)
+			}
+			return Range{Start: start, End: start.AddDate(0, 1, 0)}, nil
+		case "year":
+			start := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, loc)
+			if last {
+				start = start.AddDate(-1, 0, 0)
+			}
+			return Range{Start: start, End: start.AddDate(1, 0, 0)}, nil
+		}
+	}
+
+	return Range{}, fmt.Errorf("invalid date %q (try 2025-03-14, 2025-Q3, 2.weeks.ago, 30d or last monday)", expr)
+}
+
+// Since returns the start of the range denoted by expr
+func Since(expr string, now time.Time) (time.Time, error) {
+	r, err := Parse(expr, now)
+	return r.Start, err
+}
+
+// Until returns the (exclusive) end of the range denoted by expr,
+// so "--until 2025-03-14" includes that whole day
+func Until(expr string, now time.Time) (time.Time, error) {
+	r, err := Parse(expr, now)
+	return r.End, err
+}
+
+// ago subtracts n units from now
+func ago(now time.Time, n, unit, expr string) (Range, error) {
+	count, err := strconv.Atoi(n)
+	if err != nil {
+		return Range{}, fmt.Errorf("invalid date %q", expr)
+	}
+	unit = strings.TrimSuffix(unit, "s")
+	switch unit {
+	case "second", "sec":
+		return instant(now.Add(-time.Duration(count) * time.Second)), nil
+	case "minute", "min":
+		return instant(now.Add(-time.Duration(count) * time.Minute)), nil
+	case "hour":
+		return instant(now.Add(-time.Duration(count) * time.Hour)), nil
+	case "day":
+		return instant(now.AddDate(0, 0, -count)), nil
+	case "week":
+		return instant(now.AddDate(0, 0, -7*count)), nil
+	case "month":
+		return instant(now.AddDate(0, -count, 0)), nil
+	case "year":
+		return instant(now.AddDate(-count, 0, 0)), nil
+	}
+	return Range{}, fmt.Errorf("invalid date %q: unknown unit %q", expr, unit)
+}
+
+func instant(t time.Time) Range {
+	return Range{Start: t, End: t}
+}
+
+func days(start time.Time, n int) Range {
+	return Range{Start: start, End: start.AddDate(0, 0, n)}
+}
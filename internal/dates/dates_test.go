@@ -0,0 +1,73 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 10, 15, 14, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		expr       string
+		start, end time.Time
+	}{
+		{"2025-03-14", day(2025, 3, 14), day(2025, 3, 15)},
+		{"2025-03", day(2025, 3, 1), day(2025, 4, 1)},
+		{"2024", day(2024, 1, 1), day(2025, 1, 1)},
+		{"2025-Q3", day(2025, 7, 1), day(2025, 10, 1)},
+		{"2025-q4", day(2025, 10, 1), day(2026, 1, 1)},
+		{"2025-03-14T10:00:00Z", time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC), time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)},
+		{"now", now, now},
+		{"today", day(2025, 10, 15), day(2025, 10, 16)},
+		{"yesterday", day(2025, 10, 14), day(2025, 10, 15)},
+		{"2.weeks.ago", now.AddDate(0, 0, -14), now.AddDate(0, 0, -14)},
+		{"3 days ago", now.AddDate(0, 0, -3), now.AddDate(0, 0, -3)},
+		{"1.month.ago", now.AddDate(0, -1, 0), now.AddDate(0, -1, 0)},
+		{"30d", now.AddDate(0, 0, -30), now.AddDate(0, 0, -30)},
+		{"2w", now.AddDate(0, 0, -14), now.AddDate(0, 0, -14)},
+		{"6m", now.AddDate(0, -6, 0), now.AddDate(0, -6, 0)},
+		{"12h", now.Add(-12 * time.Hour), now.Add(-12 * time.Hour)},
+		{"monday", day(2025, 10, 13), day(2025, 10, 14)},
+		{"wednesday", day(2025, 10, 15), day(2025, 10, 16)},
+		{"last monday", day(2025, 10, 13), day(2025, 10, 14)},
+		{"last wednesday", day(2025, 10, 8), day(2025, 10, 9)},
+		{"this week", day(2025, 10, 13), day(2025, 10, 20)},
+		{"last week", day(2025, 10, 6), day(2025, 10, 13)},
+		{"last month", day(2025, 9, 1), day(2025, 10, 1)},
+		{"this year", day(2025, 1, 1), day(2026, 1, 1)},
+		{"  Last  Monday ", day(2025, 10, 13), day(2025, 10, 14)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			r, err := Parse(tc.expr, now)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tc.expr, err)
+			}
+			if !r.Start.Equal(tc.start) || !r.End.Equal(tc.end) {
+				t.Errorf("Parse(%q) = %v..%v, want %v..%v", tc.expr, r.Start, r.End, tc.start, tc.end)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	now := time.Date(2025, 10, 15, 14, 30, 0, 0, time.UTC)
+	for _, expr := range []string{"", "soon", "2025-Q5", "3.fortnights.ago", "2025-13-01", "next monday"} {
+		if _, err := Parse(expr, now); err == nil {
+			t.Errorf("Parse(%q) expected error", expr)
+		}
+	}
+}
+
+func TestSinceUntil(t *testing.T) {
+	now := time.Date(2025, 10, 15, 14, 30, 0, 0, time.UTC)
+	since, _ := Since("2025-Q3", now)
+	until, _ := Until("2025-Q3", now)
+	if !since.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)) || !until.Equal(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("2025-Q3 = %v..%v", since, until)
+	}
+}
@@ -4,8 +4,13 @@ type GitReader interface {
 	LoadRepository(path string) (*Repository, error)
 	LoadCommits(path string, limit int) ([]Commit, error)
 	LoadBranches(path string) ([]Branch, error)
-	LoadFileDiff(path, commitHash, filePath string) (string, bool, error)
+	LoadFileDiff(path, commitHash, filePath string, opts DiffOptions) (string, bool, error)
 	LoadFileChanges(path, commitHash string) ([]FileChange, error)
+	LoadTreeDiff(path, fromHash, toHash string) ([]FileChange, error)
+	LoadTreeFileDiff(path, fromHash, toHash, filePath string, opts DiffOptions) (string, bool, error)
+	ResolveRevision(path, rev string) (string, error)
+	MergeBase(path, a, b string) (string, error)
+	RangeDiff(path, oldRange, newRange string) ([]RangePair, error)
 }
 
 type RepositoryWatcher interface {
@@ -46,3 +46,42 @@ type FileChange struct {
 	Additions int
 	Deletions int
 }
+
+// RangeStatus tells how a commit of one version of a series relates to
+// the other version, as in git range-diff
+type RangeStatus int
+
+const (
+	RangeMatched  RangeStatus = iota // same message and patch
+	RangeModified                    // paired, but the message or patch changed
+	RangeAdded                       // only in the new series
+	RangeDropped                     // only in the old series
+)
+
+// RangePair is a line of a range-diff
+type RangePair struct {
+	Status    RangeStatus
+	Old       *Commit // nil if added
+	New       *Commit // nil if dropped
+	OldIndex  int     // 1-based position in the old series (0 if added)
+	NewIndex  int     // 1-based position in the new series (0 if dropped)
+	Interdiff string  // diff between the old and new patch of a modified pair
+}
+
+// DiffOptions change how the diff of a file is computed, as the flags of
+// git diff do
+type DiffOptions struct {
+	IgnoreAllSpace    bool // -w: whitespace is ignored within lines
+	IgnoreSpaceChange bool // -b: runs of whitespace compare equal
+	IgnoreBlankLines  bool // changes that only add or remove blank lines are not shown
+	Context           int  // lines of context around changes
+	FunctionContext   bool // -W: hunks cover the whole function they change
+}
+
+// DefaultContext is the number of context lines git diff shows by default
+const DefaultContext = 3
+
+// DefaultDiffOptions are the options of a plain git diff
+func DefaultDiffOptions() DiffOptions {
+	return DiffOptions{Context: DefaultContext}
+}
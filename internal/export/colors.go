@@ -0,0 +1,36 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ansi16 are the xterm defaults for the 16 basic colors
+var ansi16 = []string{
+	"#000000", "#800000", "#008000", "#808000", "#000080", "#800080", "#008080", "#c0c0c0",
+	"#808080", "#ff0000", "#00ff00", "#ffff00", "#0000ff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// hexColor converts a 256-color terminal code to its xterm #rrggbb value.
+// Colors that already are hex values are returned unchanged.
+func hexColor(c lipgloss.Color) string {
+	n, err := strconv.Atoi(string(c))
+	if err != nil || n < 0 || n > 255 {
+		return string(c)
+	}
+	switch {
+	case n < 16:
+		return ansi16[n]
+	case n < 232:
+		// 6x6x6 color cube
+		levels := []int{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[n/36], levels[n/6%6], levels[n%6])
+	default:
+		// Grayscale ramp
+		v := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", v, v, v)
+	}
+}
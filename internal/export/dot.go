@@ -0,0 +1,46 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nogo/gitree/internal/tui/graph"
+)
+
+// writeDOT writes a Graphviz digraph with edges from child to parent.
+// Nodes are colored by lane and labeled with hash, refs and subject.
+func writeDOT(w io.Writer, g *exportGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph gitree {\n")
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"monospace\", fontsize=10, fontcolor=\"#000000\"];\n")
+	b.WriteString("  edge [arrowhead=none, penwidth=2];\n")
+
+	for i, c := range g.commits {
+		node := g.layout.Nodes[i]
+		// Escaped lines are joined with a DOT line break
+		label := dotQuote(strings.TrimSpace(c.ShortHash+" "+refLabel(c.BranchRefs, c.Tags))) + `\n` + dotQuote(c.Message)
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\", fillcolor=%q, tooltip=\"%s\"];\n",
+			dotQuote(c.Hash), label, hexColor(graph.LaneColor(node.Lane)),
+			dotQuote(c.Author+" "+c.Date.Format(dateLayout)))
+	}
+	for _, e := range g.edges {
+		style := ""
+		if e.Merge {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  \"%s\" -> \"%s\" [color=%q%s];\n",
+			dotQuote(e.From), dotQuote(e.To), hexColor(graph.LaneColor(e.Lane)), style)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote escapes a string for use inside a quoted DOT string
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
@@ -0,0 +1,121 @@
+// Package export renders the commit graph without the UI, for piping into
+// files, docs and other tools.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/graph"
+)
+
+// Format is an output format of the export command
+type Format string
+
+const (
+	FormatANSI  Format = "ansi"  // colored graph as shown in the terminal
+	FormatPlain Format = "plain" // same as ansi without colors
+	FormatJSON  Format = "json"  // lanes, rows and edges
+	FormatDOT   Format = "dot"   // Graphviz digraph
+	FormatSVG   Format = "svg"   // standalone image
+)
+
+// Formats lists all supported formats
+var Formats = []Format{FormatANSI, FormatPlain, FormatJSON, FormatDOT, FormatSVG}
+
+// ParseFormat parses a format name (case-insensitive)
+func ParseFormat(name string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(name)))
+	for _, known := range Formats {
+		if f == known {
+			return f, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, known := range Formats {
+		names[i] = string(known)
+	}
+	return "", fmt.Errorf("unknown format %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// Write renders commits (in display order, newest first) in the given format.
+// The graph uses the same layout as the UI.
+func Write(w io.Writer, format Format, commits []domain.Commit, repo *domain.Repository) error {
+	g := newGraph(commits, repo)
+	switch format {
+	case FormatANSI:
+		return writeText(w, g, true)
+	case FormatPlain:
+		return writeText(w, g, false)
+	case FormatJSON:
+		return writeJSON(w, g)
+	case FormatDOT:
+		return writeDOT(w, g)
+	case FormatSVG:
+		return writeSVG(w, g)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// Edge connects a commit to one of its parents
+type Edge struct {
+	From     string // child hash
+	To       string // parent hash
+	FromRow  int
+	ToRow    int
+	FromLane int
+	ToLane   int
+	Lane     int  // lane the line runs down in between the two rows
+	Merge    bool // edge to a second or later parent
+}
+
+// exportGraph is the layout shared by all formats
+type exportGraph struct {
+	commits []domain.Commit
+	repo    *domain.Repository
+	layout  *graph.GraphLayout
+	edges   []Edge
+}
+
+func newGraph(commits []domain.Commit, repo *domain.Repository) *exportGraph {
+	layout := graph.BuildLayout(commits)
+	return &exportGraph{
+		commits: commits,
+		repo:    repo,
+		layout:  layout,
+		edges:   buildEdges(layout),
+	}
+}
+
+// buildEdges derives parent edges from the layout. A first parent continues
+// in the child's lane; further parents run down the lane forked for them.
+// Both join the parent's lane at the parent row. Parents that are not
+// among the exported commits have no edge.
+func buildEdges(layout *graph.GraphLayout) []Edge {
+	var edges []Edge
+	for _, node := range layout.Nodes {
+		for i, parentHash := range node.Parents {
+			parent, ok := layout.HashToNode[parentHash]
+			if !ok {
+				continue
+			}
+			lane := node.Lane
+			if i > 0 && i-1 < len(node.ForkTo) {
+				lane = node.ForkTo[i-1]
+			}
+			edges = append(edges, Edge{
+				From:     node.Hash,
+				To:       parent.Hash,
+				FromRow:  node.Row,
+				ToRow:    parent.Row,
+				FromLane: node.Lane,
+				ToLane:   parent.Lane,
+				Lane:     lane,
+				Merge:    i > 0,
+			})
+		}
+	}
+	return edges
+}
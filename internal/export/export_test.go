@@ -0,0 +1,152 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/domain"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// fixture is a small history with a merged feature branch, tags and refs
+func fixture() *domain.Repository {
+	at := func(day int) time.Time {
+		return time.Date(2025, 3, day, 10, 30, 0, 0, time.UTC)
+	}
+	commit := func(hash, msg, author string, day int, parents ...string) domain.Commit {
+		return domain.Commit{
+			Hash:      hash,
+			ShortHash: hash[:7],
+			Author:    author,
+			Email:     author + "@example.com",
+			Date:      at(day),
+			Message:   msg,
+			Parents:   parents,
+		}
+	}
+
+	commits := []domain.Commit{
+		commit("f000000000", "Merge branch 'feature'", "alice", 6, "e000000000", "d000000000"),
+		commit("e000000000", "Fix \"quoted\" <title>", "alice", 5, "b000000000"),
+		commit("d000000000", "Add feature & tests", "bob", 4, "c000000000"),
+		commit("c000000000", "Start feature", "bob", 3, "b000000000"),
+		commit("b000000000", "Second commit", "alice", 2, "a000000000"),
+		commit("a000000000", "Initial commit", "alice", 1),
+	}
+	commits[0].BranchRefs = []string{"origin/main", "main"}
+	commits[2].BranchRefs = []string{"feature"}
+	commits[4].Tags = []string{"v0.1.0"}
+
+	return &domain.Repository{
+		Path:    "/tmp/fixture",
+		Commits: commits,
+		Branches: []domain.Branch{
+			{Name: "main", HeadHash: "f000000000"},
+			{Name: "origin/main", IsRemote: true, HeadHash: "f000000000"},
+			{Name: "feature", HeadHash: "d000000000"},
+		},
+		HEAD: "main",
+	}
+}
+
+func TestWriteGolden(t *testing.T) {
+	repo := fixture()
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, format, repo.Commits, repo); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+
+			golden := filepath.Join("testdata", "fixture."+string(format)+".golden")
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update to create): %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("output differs from %s\ngot:\n%s\nwant:\n%s", golden, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestWriteJSONEdges(t *testing.T) {
+	repo := fixture()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, repo.Commits, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	var out jsonGraph
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(out.Rows) != 6 {
+		t.Errorf("rows = %d, want 6", len(out.Rows))
+	}
+	if len(out.Lanes) != 2 {
+		t.Errorf("lanes = %d, want 2", len(out.Lanes))
+	}
+	// 6 commits, 1 root, 1 merge: 5 first-parent edges + 1 merge edge
+	if len(out.Edges) != 6 {
+		t.Fatalf("edges = %d, want 6", len(out.Edges))
+	}
+
+	merge := out.Edges[1]
+	if merge.From != "f000000000" || merge.To != "d000000000" || merge.Type != "merge" || merge.Lane != 1 {
+		t.Errorf("merge edge = %+v, want f->d in lane 1", merge)
+	}
+}
+
+func TestWriteFilteredParents(t *testing.T) {
+	// Parents outside the exported commits get no edge
+	repo := fixture()
+	commits := repo.Commits[:2]
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, commits, repo); err != nil {
+		t.Fatal(err)
+	}
+	var out jsonGraph
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Edges) != 1 || out.Edges[0].To != "e000000000" {
+		t.Errorf("edges = %+v, want only f->e", out.Edges)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("SVG"); err != nil || f != FormatSVG {
+		t.Errorf("ParseFormat(SVG) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("png"); err == nil {
+		t.Error("ParseFormat(png) should fail")
+	}
+}
+
+func TestHexColor(t *testing.T) {
+	tests := map[string]string{
+		"0":       "#000000",
+		"205":     "#ff5faf",
+		"86":      "#5fffd7",
+		"242":     "#6c6c6c",
+		"#abcdef": "#abcdef",
+	}
+	for in, want := range tests {
+		if got := hexColor(lipgloss.Color(in)); got != want {
+			t.Errorf("hexColor(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/nogo/gitree/internal/tui/graph"
+)
+
+type jsonGraph struct {
+	Lanes []jsonLane `json:"lanes"`
+	Rows  []jsonRow  `json:"rows"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+type jsonLane struct {
+	Lane  int    `json:"lane"`
+	Color string `json:"color"`
+}
+
+type jsonRow struct {
+	Row         int       `json:"row"`
+	Hash        string    `json:"hash"`
+	ShortHash   string    `json:"short_hash"`
+	Lane        int       `json:"lane"`
+	ActiveLanes []int     `json:"active_lanes"`
+	MergeFrom   []int     `json:"merge_from,omitempty"`
+	ForkTo      []int     `json:"fork_to,omitempty"`
+	Parents     []string  `json:"parents"`
+	Children    []string  `json:"children"`
+	Author      string    `json:"author"`
+	Email       string    `json:"email"`
+	Date        time.Time `json:"date"`
+	Subject     string    `json:"subject"`
+	Branches    []string  `json:"branches,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+}
+
+type jsonEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	FromRow  int    `json:"from_row"`
+	ToRow    int    `json:"to_row"`
+	FromLane int    `json:"from_lane"`
+	ToLane   int    `json:"to_lane"`
+	Lane     int    `json:"lane"`
+	Type     string `json:"type"` // "parent" or "merge"
+}
+
+// writeJSON writes the layout as a single indented JSON document
+func writeJSON(w io.Writer, g *exportGraph) error {
+	out := jsonGraph{
+		Lanes: make([]jsonLane, g.layout.MaxLanes),
+		Rows:  make([]jsonRow, len(g.commits)),
+		Edges: make([]jsonEdge, len(g.edges)),
+	}
+	for lane := range out.Lanes {
+		out.Lanes[lane] = jsonLane{Lane: lane, Color: hexColor(graph.LaneColor(lane))}
+	}
+	for i, c := range g.commits {
+		node := g.layout.Nodes[i]
+		var active []int
+		for lane := range g.layout.ActiveLanesAt(i) {
+			active = append(active, lane)
+		}
+		slices.Sort(active)
+		out.Rows[i] = jsonRow{
+			Row:         i,
+			Hash:        c.Hash,
+			ShortHash:   c.ShortHash,
+			Lane:        node.Lane,
+			ActiveLanes: active,
+			MergeFrom:   node.MergeFrom,
+			ForkTo:      node.ForkTo,
+			Parents:     nonNil(c.Parents),
+			Children:    nonNil(node.Children),
+			Author:      c.Author,
+			Email:       c.Email,
+			Date:        c.Date,
+			Subject:     c.Message,
+			Branches:    c.BranchRefs,
+			Tags:        c.Tags,
+		}
+	}
+	for i, e := range g.edges {
+		typ := "parent"
+		if e.Merge {
+			typ = "merge"
+		}
+		out.Edges[i] = jsonEdge{
+			From:     e.From,
+			To:       e.To,
+			FromRow:  e.FromRow,
+			ToRow:    e.ToRow,
+			FromLane: e.FromLane,
+			ToLane:   e.ToLane,
+			Lane:     e.Lane,
+			Type:     typ,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// nonNil makes empty lists encode as [] instead of null
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
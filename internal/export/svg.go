@@ -0,0 +1,92 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/nogo/gitree/internal/tui/graph"
+)
+
+// SVG geometry in pixels
+const (
+	svgRowHeight  = 24
+	svgLaneWidth  = 16
+	svgMargin     = 12
+	svgNodeRadius = 4
+	svgCharWidth  = 7 // approximate advance of the 12px monospace font
+)
+
+// SVG colors, matching the list styles
+const (
+	svgBackground = "#1c1c1c"
+	svgHashColor  = "#ffaf00" // 214
+	svgTextColor  = "#d0d0d0" // 252
+	svgDimColor   = "#6c6c6c" // 242
+	svgTagColor   = "#ffd700" // 220
+)
+
+// writeSVG draws the graph as lines and dots with commit text on the right
+func writeSVG(w io.Writer, g *exportGraph) error {
+	textX := svgMargin + g.layout.MaxLanes*svgLaneWidth + svgMargin
+	textWidth := 0
+	lines := make([]string, len(g.commits))
+	for i, c := range g.commits {
+		plain := c.ShortHash + " " + refLabel(c.BranchRefs, c.Tags) + c.Message + "  " + c.Author + " " + c.Date.Format(dateLayout)
+		textWidth = max(textWidth, len([]rune(plain))*svgCharWidth)
+		lines[i] = plain
+	}
+	width := textX + textWidth + svgMargin
+	height := svgMargin*2 + len(g.commits)*svgRowHeight
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgBackground)
+
+	b.WriteString(`  <g fill="none" stroke-width="2">` + "\n")
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, `    <path d="M%d %d H%d V%d H%d" stroke="%s"/>`+"\n",
+			laneX(e.FromLane), rowY(e.FromRow), laneX(e.Lane), rowY(e.ToRow), laneX(e.ToLane),
+			hexColor(graph.LaneColor(e.Lane)))
+	}
+	b.WriteString("  </g>\n")
+
+	b.WriteString(`  <g font-family="monospace" font-size="12">` + "\n")
+	for i, c := range g.commits {
+		node := g.layout.Nodes[i]
+		y := rowY(i)
+		fmt.Fprintf(&b, `    <circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n",
+			laneX(node.Lane), y, svgNodeRadius, hexColor(graph.LaneColor(node.Lane)))
+		fmt.Fprintf(&b, `    <text x="%d" y="%d" dominant-baseline="middle" xml:space="preserve">`, textX, y)
+		fmt.Fprintf(&b, `<tspan fill="%s">%s</tspan> `, svgHashColor, html.EscapeString(c.ShortHash))
+		if refs := refLabel(c.BranchRefs, c.Tags); refs != "" {
+			fmt.Fprintf(&b, `<tspan fill="%s">%s</tspan>`, svgTagColor, html.EscapeString(refs))
+		}
+		fmt.Fprintf(&b, `<tspan fill="%s">%s</tspan>  `, svgTextColor, html.EscapeString(c.Message))
+		fmt.Fprintf(&b, `<tspan fill="%s">%s %s</tspan>`, svgDimColor, html.EscapeString(c.Author), c.Date.Format(dateLayout))
+		b.WriteString("</text>\n")
+	}
+	b.WriteString("  </g>\n")
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// refLabel formats branch and tag names as "(main, v1.0) ", or "" if none
+func refLabel(branches, tags []string) string {
+	refs := append(append([]string{}, branches...), tags...)
+	if len(refs) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(refs, ", ") + ") "
+}
+
+func laneX(lane int) int {
+	return svgMargin + lane*svgLaneWidth + svgLaneWidth/2
+}
+
+func rowY(row int) int {
+	return svgMargin + row*svgRowHeight + svgRowHeight/2
+}
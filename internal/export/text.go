@@ -0,0 +1,40 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/nogo/gitree/internal/tui/graph"
+	"github.com/nogo/gitree/internal/tui/list"
+)
+
+// dateLayout is used for commit dates in text output
+const dateLayout = "2006-01-02 15:04"
+
+// writeText renders one line per commit: graph, hash, refs, subject,
+// author and date. Colors are forced on or off regardless of the terminal.
+func writeText(w io.Writer, g *exportGraph, color bool) error {
+	profile := termenv.Ascii
+	if color {
+		profile = termenv.ANSI256
+	}
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(profile)
+	defer lipgloss.SetColorProfile(prev)
+
+	r := graph.NewRenderer(g.commits, g.repo.Branches, g.repo.HEAD)
+	for i, c := range g.commits {
+		line := r.RenderGraphCell(i) +
+			list.HashStyle.Render(c.ShortHash) + " " +
+			r.RenderBranchBadges(c) + r.RenderTagBadges(c) +
+			list.MessageStyle.Render(c.Message) + "  " +
+			list.AuthorStyle.Render(c.Author) + " " +
+			list.DateStyle.Render(c.Date.Format(dateLayout))
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
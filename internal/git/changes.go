@@ -0,0 +1,43 @@
+package git
+
+import "github.com/nogo/gitree/internal/domain"
+
+// LoadFileChangesParallel loads file changes for commits using a bounded worker pool.
+// Commits without changes, like empty commits, map to an empty slice; commits
+// whose changes fail to load are omitted from the result.
+func LoadFileChangesParallel(reader domain.GitReader, repoPath string, commits []domain.Commit) map[string][]domain.FileChange {
+	type result struct {
+		hash  string
+		files []domain.FileChange
+		err   error
+	}
+	resultChan := make(chan result, len(commits))
+
+	// Use worker pool to limit concurrency
+	const maxWorkers = 20
+	sem := make(chan struct{}, maxWorkers)
+
+	for i := range commits {
+		go func(commit domain.Commit) {
+			sem <- struct{}{}        // acquire
+			defer func() { <-sem }() // release
+
+			files, err := reader.LoadFileChanges(repoPath, commit.Hash)
+			resultChan <- result{hash: commit.Hash, files: files, err: err}
+		}(commits[i])
+	}
+
+	// Collect results
+	fileChanges := make(map[string][]domain.FileChange)
+	for range commits {
+		r := <-resultChan
+		if r.err != nil {
+			continue
+		}
+		if r.files == nil {
+			r.files = []domain.FileChange{}
+		}
+		fileChanges[r.hash] = r.files
+	}
+	return fileChanges
+}
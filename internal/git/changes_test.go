@@ -0,0 +1,43 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nogo/gitree/internal/domain"
+)
+
+func TestLoadFileChangesParallel_EmptyCommit(t *testing.T) {
+	tr := setupTestRepo(t)
+	wt, err := tr.repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	empty, err := wt.Commit("Empty commit", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author: &object.Signature{
+			Name:  "Test Author",
+			Email: "test@example.com",
+			When:  time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	commits := []domain.Commit{{Hash: empty.String()}, {Hash: tr.hashes[0]}, {Hash: "0000000000000000000000000000000000000000"}}
+	changes := LoadFileChangesParallel(NewReader(), tr.path, commits)
+
+	files, ok := changes[empty.String()]
+	if !ok || files == nil || len(files) != 0 {
+		t.Errorf("empty commit = %v (present %v), want an empty slice", files, ok)
+	}
+	if len(changes[tr.hashes[0]]) != 1 {
+		t.Errorf("expected 1 file change for %s, got %d", tr.hashes[0], len(changes[tr.hashes[0]]))
+	}
+	if _, ok := changes[commits[2].Hash]; ok {
+		t.Error("a commit that failed to load should be omitted")
+	}
+}
@@ -0,0 +1,333 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// maxHeadingWidth cuts the function name after a hunk header, as git does
+const maxHeadingWidth = 80
+
+// diffLine is a line of a diff between two files
+type diffLine struct {
+	op     byte // ' ', '-' or '+'
+	old    int  // lines of the old file before this one
+	new    int  // lines of the new file before this one
+	ignore bool // a change the options hide, e.g. only blank lines
+}
+
+// patchWithOptions builds the patch of change with opts. go-git has no
+// diff options, so the hunks are made here and follow the file header of
+// patch, the patch go-git made.
+func patchWithOptions(change *object.Change, patch string, opts domain.DiffOptions) (string, error) {
+	from, to, err := change.Files()
+	if err != nil {
+		return "", err
+	}
+	oldText, err := fileContents(from)
+	if err != nil {
+		return "", err
+	}
+	newText, err := fileContents(to)
+	if err != nil {
+		return "", err
+	}
+
+	header := patch
+	if i := strings.Index(patch, "\n@@"); i >= 0 {
+		header = patch[:i+1]
+	}
+	return header + unifiedHunks(oldText, newText, opts), nil
+}
+
+func fileContents(f *object.File) (string, error) {
+	if f == nil {
+		return "", nil
+	}
+	return f.Contents()
+}
+
+// unifiedHunks returns the hunks of a unified diff from oldText to newText
+func unifiedHunks(oldText, newText string, opts domain.DiffOptions) string {
+	oldLines, oldEOL := splitLines(oldText)
+	newLines, newEOL := splitLines(newText)
+	lines := diffLines(oldLines, newLines, oldEOL, newEOL, opts)
+
+	var b strings.Builder
+	for _, h := range hunkRanges(lines, oldLines, newLines, opts) {
+		writeHunk(&b, lines[h[0]:h[1]], oldLines, newLines, oldEOL, newEOL)
+	}
+	return b.String()
+}
+
+// splitLines splits text into lines and returns whether it ends in a newline
+func splitLines(text string) ([]string, bool) {
+	if text == "" {
+		return nil, true
+	}
+	eol := strings.HasSuffix(text, "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), eol
+}
+
+// diffLines compares the files line by line, as opts normalizes them
+func diffLines(oldLines, newLines []string, oldEOL, newEOL bool, opts domain.DiffOptions) []diffLine {
+	normalize := func(lines []string, eol bool) string {
+		var b strings.Builder
+		for i, line := range lines {
+			b.WriteString(normalizeLine(line, opts))
+			if i < len(lines)-1 || eol {
+				b.WriteString("\n")
+			}
+		}
+		return b.String()
+	}
+
+	dmp := diffmatchpatch.New()
+	src, dst, table := dmp.DiffLinesToChars(normalize(oldLines, oldEOL), normalize(newLines, newEOL))
+	var lines []diffLine
+	o, n := 0, 0
+	for _, d := range dmp.DiffCharsToLines(dmp.DiffMain(src, dst, false), table) {
+		count := len(strings.SplitAfter(strings.TrimSuffix(d.Text, "\n"), "\n"))
+		for range count {
+			switch d.Type {
+			case diffmatchpatch.DiffEqual:
+				lines = append(lines, diffLine{op: ' ', old: o, new: n})
+				o++
+				n++
+			case diffmatchpatch.DiffDelete:
+				lines = append(lines, diffLine{op: '-', old: o, new: n})
+				o++
+			case diffmatchpatch.DiffInsert:
+				lines = append(lines, diffLine{op: '+', old: o, new: n})
+				n++
+			}
+		}
+	}
+	if opts.IgnoreBlankLines {
+		ignoreBlankChanges(lines, oldLines, newLines)
+	}
+	return lines
+}
+
+// ignoreBlankChanges marks the runs of changed lines that are all blank
+func ignoreBlankChanges(lines []diffLine, oldLines, newLines []string) {
+	for start := 0; start < len(lines); {
+		if lines[start].op == ' ' {
+			start++
+			continue
+		}
+		end, blank := start, true
+		for ; end < len(lines) && lines[end].op != ' '; end++ {
+			blank = blank && strings.TrimSpace(lineText(lines[end], oldLines, newLines)) == ""
+		}
+		for i := start; i < end; i++ {
+			lines[i].ignore = blank
+		}
+		start = end
+	}
+}
+
+// normalizeLine returns the line as opts compares it
+func normalizeLine(line string, opts domain.DiffOptions) string {
+	switch {
+	case opts.IgnoreAllSpace:
+		return strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, line)
+	case opts.IgnoreSpaceChange:
+		// Runs of whitespace count as one, trailing whitespace as none
+		var b strings.Builder
+		space := false
+		for _, r := range strings.TrimRightFunc(line, unicode.IsSpace) {
+			if unicode.IsSpace(r) {
+				space = true
+				continue
+			}
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+	return line
+}
+
+// hunkRanges returns the ranges of lines that make up each hunk: the
+// changes shown, their context, and with FunctionContext the functions
+// around them
+func hunkRanges(lines []diffLine, oldLines, newLines []string, opts domain.DiffOptions) [][2]int {
+	var fn functions
+	if opts.FunctionContext {
+		fn = findFunctions(lines, oldLines, newLines)
+	}
+	var ranges [][2]int
+	for i, l := range lines {
+		if l.op == ' ' || l.ignore {
+			continue
+		}
+		// The context stops at the hunk before, and at the next change,
+		// whose own context reaches further
+		start, end := i, i+1
+		floor := 0
+		if n := len(ranges); n > 0 {
+			floor = ranges[n-1][1]
+		}
+		for ctx := 0; start > floor && ctx < opts.Context; start-- {
+			if lines[start-1].op == ' ' {
+				ctx++
+			}
+		}
+		for ctx := 0; end < len(lines) && ctx < opts.Context; end++ {
+			if next := lines[end]; next.op == ' ' {
+				ctx++
+			} else if !next.ignore {
+				break
+			}
+		}
+		if opts.FunctionContext {
+			start = min(start, fn.start[i])
+			end = max(end, fn.end[i])
+		}
+
+		// Hunks that touch are merged
+		if n := len(ranges); n > 0 && start <= ranges[n-1][1] {
+			ranges[n-1][1] = max(ranges[n-1][1], end)
+			continue
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	return ranges
+}
+
+// functions holds, for each line of a diff, the lines of the function it
+// is in. They are found in two passes, so that a change looks them up
+// instead of scanning to the headers around it.
+type functions struct {
+	start []int // the function header, or the comment right above it
+	end   []int // the next function header, without the blank lines before it
+}
+
+func findFunctions(lines []diffLine, oldLines, newLines []string) functions {
+	text := func(i int) string { return lineText(lines[i], oldLines, newLines) }
+	header := make([]bool, len(lines))
+	for i := range lines {
+		header[i] = isFuncName(text(i))
+	}
+	fn := functions{start: make([]int, len(lines)), end: make([]int, len(lines))}
+
+	// Lines above the first header belong to a function starting at 0
+	start := 0
+	for i := range lines {
+		if header[i] {
+			start = i
+			for start > 0 && !header[start-1] && strings.TrimSpace(text(start-1)) != "" {
+				start--
+			}
+		}
+		fn.start[i] = start
+	}
+
+	trimBlank := func(end int) int {
+		for end > 0 && strings.TrimSpace(text(end-1)) == "" {
+			end--
+		}
+		return end
+	}
+	end := trimBlank(len(lines))
+	for i := len(lines) - 1; i >= 0; i-- {
+		fn.end[i] = max(i+1, end)
+		if header[i] {
+			end = trimBlank(i)
+		}
+	}
+	return fn
+}
+
+// lineText returns the text of a line, from the new file if it is in both
+func lineText(l diffLine, oldLines, newLines []string) string {
+	if l.op == '-' {
+		return oldLines[l.old]
+	}
+	return newLines[l.new]
+}
+
+// isFuncName tells function headers as git does without a diff driver:
+// lines that start with a letter, an underscore or a dollar sign
+func isFuncName(line string) bool {
+	if line == "" {
+		return false
+	}
+	r := rune(line[0])
+	return unicode.IsLetter(r) || r == '_' || r == '$'
+}
+
+// writeHunk writes a hunk header and its lines. Context lines are taken
+// from the new file, as git does when whitespace is ignored.
+func writeHunk(b *strings.Builder, lines []diffLine, oldLines, newLines []string, oldEOL, newEOL bool) {
+	oldStart, newStart := lines[0].old, lines[0].new
+	oldCount, newCount := 0, 0
+	for _, l := range lines {
+		if l.op != '+' {
+			oldCount++
+		}
+		if l.op != '-' {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@", hunkSide(oldStart, oldCount), hunkSide(newStart, newCount))
+	if heading := hunkHeading(oldLines, oldStart); heading != "" {
+		b.WriteString(" " + heading)
+	}
+	b.WriteString("\n")
+
+	for _, l := range lines {
+		var text string
+		var eol bool
+		if l.op == '-' {
+			text, eol = oldLines[l.old], oldEOL || l.old < len(oldLines)-1
+		} else {
+			text, eol = newLines[l.new], newEOL || l.new < len(newLines)-1
+		}
+		b.WriteString(string(l.op) + text + "\n")
+		if !eol {
+			b.WriteString("\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkSide formats a side of a hunk header from the lines before the hunk
+// and the lines in it; an empty side names the line before it
+func hunkSide(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// hunkHeading returns the function header above line start of the old file
+func hunkHeading(oldLines []string, start int) string {
+	for i := min(start, len(oldLines)) - 1; i >= 0; i-- {
+		if isFuncName(oldLines[i]) {
+			heading := strings.TrimRightFunc(oldLines[i], unicode.IsSpace)
+			if len(heading) > maxHeadingWidth {
+				heading = heading[:maxHeadingWidth]
+			}
+			return heading
+		}
+	}
+	return ""
+}
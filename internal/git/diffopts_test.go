@@ -0,0 +1,130 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+const optsOld = `package main
+
+func a() {
+	x := 1
+	y := 2
+	return
+}
+
+func b() {
+	z := 3
+}
+`
+
+func TestUnifiedHunks(t *testing.T) {
+	tests := []struct {
+		name   string
+		new    string
+		modify func(*domain.DiffOptions)
+		want   string
+	}{
+		{
+			name: "default context",
+			new:  "package main\n\nfunc a() {\n\tx := 1\n\ty := 20\n\treturn\n}\n\nfunc b() {\n\tz := 3\n}\n",
+			want: "@@ -2,7 +2,7 @@ package main\n" +
+				" \n func a() {\n \tx := 1\n-\ty := 2\n+\ty := 20\n \treturn\n }\n \n",
+		},
+		{
+			name:   "no context",
+			new:    "package main\n\nfunc a() {\n\tx := 1\n\ty := 20\n\treturn\n}\n\nfunc b() {\n\tz := 3\n}\n",
+			modify: func(o *domain.DiffOptions) { o.Context = 0 },
+			want:   "@@ -5 +5 @@ func a() {\n-\ty := 2\n+\ty := 20\n",
+		},
+		{
+			name:   "ignore all space",
+			new:    "package main\n\nfunc a() {\n    x := 1\n\ty:=2\n\treturn\n}\n\nfunc b() {\n\tz := 3\n}\n",
+			modify: func(o *domain.DiffOptions) { o.IgnoreAllSpace = true },
+			want:   "",
+		},
+		{
+			name:   "ignore space change",
+			new:    "package main\n\nfunc a() {\n\tx  :=  1\n\ty:=2\n\treturn\n}\n\nfunc b() {\n\tz := 3\n}\n",
+			modify: func(o *domain.DiffOptions) { o.IgnoreSpaceChange = true; o.Context = 0 },
+			want:   "@@ -5 +5 @@ func a() {\n-\ty := 2\n+\ty:=2\n",
+		},
+		{
+			name:   "ignore blank lines",
+			new:    "package main\n\n\nfunc a() {\n\tx := 1\n\ty := 2\n\treturn\n}\n\nfunc b() {\n\tz := 3\n}\n",
+			modify: func(o *domain.DiffOptions) { o.IgnoreBlankLines = true },
+			want:   "",
+		},
+		{
+			name:   "function context",
+			new:    "package main\n\nfunc a() {\n\tx := 1\n\ty := 2\n\treturn\n}\n\nfunc b() {\n\tz := 30\n}\n",
+			modify: func(o *domain.DiffOptions) { o.FunctionContext = true; o.Context = 0 },
+			want:   "@@ -9,3 +9,3 @@ func a() {\n func b() {\n-\tz := 3\n+\tz := 30\n }\n",
+		},
+		{
+			name:   "no newline at end",
+			new:    "package main\n\nfunc a() {\n\tx := 1\n\ty := 2\n\treturn\n}\n\nfunc b() {\n\tz := 3\n}",
+			modify: func(o *domain.DiffOptions) { o.Context = 0 },
+			want:   "@@ -11 +11 @@ func b() {\n-}\n+}\n\\ No newline at end of file\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := domain.DefaultDiffOptions()
+			if tt.modify != nil {
+				tt.modify(&opts)
+			}
+			if got := unifiedHunks(optsOld, tt.new, opts); got != tt.want {
+				t.Errorf("unifiedHunks =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedHunks_NewFile(t *testing.T) {
+	got := unifiedHunks("", "a\nb\n", domain.DefaultDiffOptions())
+	if want := "@@ -0,0 +1,2 @@\n+a\n+b\n"; got != want {
+		t.Errorf("unifiedHunks = %q, want %q", got, want)
+	}
+}
+
+func TestFindFunctions(t *testing.T) {
+	newLines := []string{
+		"package main", // 0
+		"",             // 1
+		"// a does it", // 2
+		"func a() {",   // 3
+		"\tx := 1",     // 4
+		"}",            // 5
+		"",             // 6
+		"",             // 7
+		"func b() {",   // 8
+		"}",            // 9
+	}
+	lines := make([]diffLine, len(newLines))
+	for i := range lines {
+		lines[i] = diffLine{op: '+', new: i}
+	}
+	fn := findFunctions(lines, nil, newLines)
+	wantStart := []int{0, 0, 0, 2, 2, 2, 2, 2, 8, 8}
+	wantEnd := []int{3, 3, 3, 6, 6, 6, 7, 8, 10, 10}
+	for i := range lines {
+		if fn.start[i] != wantStart[i] || fn.end[i] != wantEnd[i] {
+			t.Errorf("line %d: function %d-%d, want %d-%d", i, fn.start[i], fn.end[i], wantStart[i], wantEnd[i])
+		}
+	}
+}
+
+func TestUnifiedHunks_LargeFileFunctionContext(t *testing.T) {
+	// Without function headers every change is in one function, which
+	// must not be scanned again for each line
+	newText := "package main\n" + strings.Repeat("\tx++\n", 200000)
+	opts := domain.DefaultDiffOptions()
+	opts.FunctionContext = true
+	got := unifiedHunks("", newText, opts)
+	if want := "@@ -0,0 +1,200001 @@\n"; !strings.HasPrefix(got, want) {
+		t.Errorf("unifiedHunks starts with %q, want %q", got[:min(len(got), 40)], want)
+	}
+}
@@ -0,0 +1,363 @@
+package git
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// maxSeriesCommits bounds the commits of a range-diff series; pairing
+	// compares every old commit with every new one
+	maxSeriesCommits = 500
+
+	// minPairSimilarity is how alike two patches must be to pair them;
+	// less alike commits count as dropped and added
+	minPairSimilarity = 0.5
+
+	// interdiffContext is the number of unchanged lines around changes in
+	// an interdiff
+	interdiffContext = 3
+)
+
+// seriesCommit is a commit of a range-diff series with its normalized
+// message and patch
+type seriesCommit struct {
+	commit domain.Commit
+	patch  string
+}
+
+// MergeBase returns the best common ancestor of two revisions
+func (r *Reader) MergeBase(path string, a string, b string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", err
+	}
+	ca, err := r.resolveCommit(repo, path, a)
+	if err != nil {
+		return "", err
+	}
+	cb, err := r.resolveCommit(repo, path, b)
+	if err != nil {
+		return "", err
+	}
+	bases, err := ca.MergeBase(cb)
+	if err != nil {
+		return "", err
+	}
+	if len(bases) == 0 {
+		return "", fmt.Errorf("%s and %s have no common ancestor", a, b)
+	}
+	return bases[0].Hash.String(), nil
+}
+
+// RangeDiff pairs the commits of two versions of a series, like git
+// range-diff. Each range is "base..tip" in revision expressions; merges
+// are left out. Pairs are in the order of the new series, with dropped
+// commits placed near their old position.
+func (r *Reader) RangeDiff(path string, oldRange string, newRange string) ([]domain.RangePair, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, err
+	}
+	oldSeries, err := r.loadSeries(repo, path, oldRange)
+	if err != nil {
+		return nil, err
+	}
+	newSeries, err := r.loadSeries(repo, path, newRange)
+	if err != nil {
+		return nil, err
+	}
+	return pairSeries(oldSeries, newSeries), nil
+}
+
+// resolveCommit returns the commit a revision expression names
+func (r *Reader) resolveCommit(repo *git.Repository, path, rev string) (*object.Commit, error) {
+	hash, err := r.ResolveRevision(path, rev)
+	if err != nil {
+		return nil, err
+	}
+	return repo.CommitObject(plumbing.NewHash(hash))
+}
+
+// loadSeries returns the non-merge commits of a "base..tip" range, oldest
+// first, with their patches
+func (r *Reader) loadSeries(repo *git.Repository, path, spec string) ([]seriesCommit, error) {
+	baseRev, tipRev, ok := strings.Cut(spec, "..")
+	if !ok || baseRev == "" || tipRev == "" || strings.HasPrefix(tipRev, ".") {
+		return nil, fmt.Errorf("range %q is not base..tip", spec)
+	}
+	base, err := r.resolveCommit(repo, path, baseRev)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := r.resolveCommit(repo, path, tipRev)
+	if err != nil {
+		return nil, err
+	}
+
+	// Everything reachable from base is left out, including side branches
+	// that forked below it and were merged into the series
+	var ignore []plumbing.Hash
+	err = object.NewCommitPreorderIter(base, nil, nil).ForEach(func(c *object.Commit) error {
+		ignore = append(ignore, c.Hash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+	err = object.NewCommitPreorderIter(tip, nil, ignore).ForEach(func(c *object.Commit) error {
+		if c.NumParents() > 1 {
+			return nil
+		}
+		if len(commits) == maxSeriesCommits {
+			return fmt.Errorf("range %s has more than %d commits", spec, maxSeriesCommits)
+		}
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(commits)
+
+	series := make([]seriesCommit, len(commits))
+	for i, c := range commits {
+		patch, err := commitPatch(repo, c)
+		if err != nil {
+			return nil, err
+		}
+		series[i] = seriesCommit{commit: toCommit(c), patch: patch}
+	}
+	return series, nil
+}
+
+// commitPatch returns the message and patch of a commit in the form
+// range-diff compares them: the message indented, index lines dropped
+// and hunk headers without line numbers, which change with every rebase
+func commitPatch(repo *git.Repository, c *object.Commit) (string, error) {
+	changes, err := getCommitChanges(repo, c.Hash.String())
+	if err != nil {
+		return "", err
+	}
+	patch, err := changes.Patch()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(c.Message, "\n"), "\n") {
+		b.WriteString(strings.TrimRight("    "+line, " ") + "\n")
+	}
+	b.WriteString("\n")
+	for _, line := range strings.Split(patch.String(), "\n") {
+		switch {
+		case strings.HasPrefix(line, "index "):
+			continue
+		case strings.HasPrefix(line, "@@"):
+			// "@@ -1,4 +1,5 @@ func main" keeps "@@ func main"
+			if end := strings.Index(line[2:], "@@"); end >= 0 {
+				line = strings.TrimRight("@@ "+strings.TrimSpace(line[end+4:]), " ")
+			}
+		}
+		b.WriteString(line + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n", nil
+}
+
+// pairSeries pairs old and new commits: identical patches first, then the
+// most similar pairs above minPairSimilarity
+func pairSeries(oldSeries, newSeries []seriesCommit) []domain.RangePair {
+	oldPair := make([]int, len(oldSeries)) // new index paired with, -1 if none
+	newPair := make([]int, len(newSeries))
+	for i := range oldPair {
+		oldPair[i] = -1
+	}
+	for j := range newPair {
+		newPair[j] = -1
+	}
+
+	for j, n := range newSeries {
+		for i, o := range oldSeries {
+			if oldPair[i] < 0 && o.patch == n.patch {
+				oldPair[i], newPair[j] = j, i
+				break
+			}
+		}
+	}
+
+	type candidate struct {
+		i, j       int
+		similarity float64
+	}
+	var candidates []candidate
+	oldLines := make([]map[string]int, len(oldSeries))
+	for i, o := range oldSeries {
+		if oldPair[i] < 0 {
+			oldLines[i] = changedLines(o.patch)
+		}
+	}
+	for j, n := range newSeries {
+		if newPair[j] >= 0 {
+			continue
+		}
+		lines := changedLines(n.patch)
+		for i := range oldSeries {
+			if oldPair[i] >= 0 {
+				continue
+			}
+			if s := similarity(oldLines[i], lines); s >= minPairSimilarity {
+				candidates = append(candidates, candidate{i, j, s})
+			}
+		}
+	}
+	// Most similar first; ties go to commits at the same position
+	sort.SliceStable(candidates, func(a, b int) bool {
+		ca, cb := candidates[a], candidates[b]
+		if ca.similarity != cb.similarity {
+			return ca.similarity > cb.similarity
+		}
+		return abs(ca.i-ca.j) < abs(cb.i-cb.j)
+	})
+	for _, c := range candidates {
+		if oldPair[c.i] < 0 && newPair[c.j] < 0 {
+			oldPair[c.i], newPair[c.j] = c.j, c.i
+		}
+	}
+
+	var pairs []domain.RangePair
+	nextOld := 0 // dropped commits before this are listed
+	dropped := func(upTo int) {
+		for ; nextOld < upTo; nextOld++ {
+			if oldPair[nextOld] < 0 {
+				pairs = append(pairs, domain.RangePair{
+					Status:   domain.RangeDropped,
+					Old:      &oldSeries[nextOld].commit,
+					OldIndex: nextOld + 1,
+				})
+			}
+		}
+	}
+	for j := range newSeries {
+		i := newPair[j]
+		if i < 0 {
+			pairs = append(pairs, domain.RangePair{
+				Status:   domain.RangeAdded,
+				New:      &newSeries[j].commit,
+				NewIndex: j + 1,
+			})
+			continue
+		}
+		dropped(i)
+		pair := domain.RangePair{
+			Status:   domain.RangeMatched,
+			Old:      &oldSeries[i].commit,
+			New:      &newSeries[j].commit,
+			OldIndex: i + 1,
+			NewIndex: j + 1,
+		}
+		if oldSeries[i].patch != newSeries[j].patch {
+			pair.Status = domain.RangeModified
+			pair.Interdiff = interdiff(oldSeries[i].patch, newSeries[j].patch)
+		}
+		pairs = append(pairs, pair)
+	}
+	dropped(len(oldSeries))
+	return pairs
+}
+
+// changedLines counts the added and removed lines of a patch, and the
+// subject, which pairs rewordings that change no code
+func changedLines(patch string) map[string]int {
+	lines := make(map[string]int)
+	for i, line := range strings.Split(patch, "\n") {
+		if i == 0 || (strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-")) &&
+			!strings.HasPrefix(line, "+++") && !strings.HasPrefix(line, "---") {
+			lines[line]++
+		}
+	}
+	return lines
+}
+
+// similarity is the share of lines two patches have in common (Dice
+// coefficient), from 0 to 1
+func similarity(a, b map[string]int) float64 {
+	total, common := 0, 0
+	for line, n := range a {
+		total += n
+		common += min(n, b[line])
+	}
+	for _, n := range b {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(2*common) / float64(total)
+}
+
+// interdiff returns a unified diff between two patches: lines start with
+// "-" or "+" for the patch side they are in, followed by the patch line
+// itself. Unchanged runs are cut to interdiffContext lines around changes.
+func interdiff(oldPatch, newPatch string) string {
+	type line struct {
+		op   byte
+		text string
+	}
+	var lines []line
+	dmp := diffmatchpatch.New()
+	src, dst, table := dmp.DiffLinesToChars(oldPatch, newPatch)
+	for _, d := range dmp.DiffCharsToLines(dmp.DiffMain(src, dst, false), table) {
+		op := byte(' ')
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			op = '-'
+		case diffmatchpatch.DiffInsert:
+			op = '+'
+		}
+		for _, text := range strings.SplitAfter(d.Text, "\n") {
+			if text != "" {
+				lines = append(lines, line{op, strings.TrimSuffix(text, "\n")})
+			}
+		}
+	}
+
+	// Keep lines within interdiffContext of a change
+	keep := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for k := max(0, i-interdiffContext); k <= min(len(lines)-1, i+interdiffContext); k++ {
+			keep[k] = true
+		}
+	}
+	var out strings.Builder
+	for i, l := range lines {
+		if !keep[i] {
+			continue
+		}
+		if i == 0 || !keep[i-1] {
+			out.WriteString("@@\n")
+		}
+		out.WriteByte(l.op)
+		out.WriteString(l.text + "\n")
+	}
+	return out.String()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
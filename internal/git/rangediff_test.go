@@ -0,0 +1,144 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nogo/gitree/internal/domain"
+)
+
+func series(patches ...string) []seriesCommit {
+	s := make([]seriesCommit, len(patches))
+	for i, p := range patches {
+		s[i] = seriesCommit{commit: domain.Commit{Hash: p[:strings.Index(p, "\n")]}, patch: p}
+	}
+	return s
+}
+
+func TestPairSeries(t *testing.T) {
+	oldSeries := series(
+		"    add parser\n\n+func parse() {}\n+func lex() {}\n+func token() {}\n",
+		"    add docs\n\n+docs\n",
+		"    add cache\n\n+cache one\n+cache two\n+cache three\n",
+	)
+	newSeries := series(
+		"    add parser\n\n+func parse() {}\n+func lex() {}\n+func token() {}\n",
+		"    add cache\n\n+cache one\n+cache two\n+cache 3\n",
+		"    add tests\n\n+test\n",
+	)
+
+	pairs := pairSeries(oldSeries, newSeries)
+	var got []string
+	for _, p := range pairs {
+		got = append(got, map[domain.RangeStatus]string{
+			domain.RangeMatched:  "=",
+			domain.RangeModified: "!",
+			domain.RangeAdded:    ">",
+			domain.RangeDropped:  "<",
+		}[p.Status])
+	}
+	// docs is dropped before the cache commit that followed it
+	if want := "= < ! >"; strings.Join(got, " ") != want {
+		t.Fatalf("statuses = %v, want %s", got, want)
+	}
+
+	modified := pairs[2]
+	if modified.OldIndex != 3 || modified.NewIndex != 2 {
+		t.Errorf("modified pair = %d/%d, want 3/2", modified.OldIndex, modified.NewIndex)
+	}
+	if !strings.Contains(modified.Interdiff, "-+cache three\n++cache 3\n") {
+		t.Errorf("interdiff misses the changed line:\n%s", modified.Interdiff)
+	}
+	if pairs[1].Old == nil || pairs[1].New != nil || pairs[3].New == nil || pairs[3].Old != nil {
+		t.Error("dropped and added pairs should only have their own side")
+	}
+}
+
+func TestInterdiff_Context(t *testing.T) {
+	var lines []string
+	for i := 0; i < 20; i++ {
+		lines = append(lines, "+line "+string(rune('a'+i)))
+	}
+	oldPatch := strings.Join(lines, "\n") + "\n"
+	lines[10] = "+changed"
+	newPatch := strings.Join(lines, "\n") + "\n"
+
+	got := interdiff(oldPatch, newPatch)
+	want := "@@\n +line h\n +line i\n +line j\n-+line k\n++changed\n +line l\n +line m\n +line n\n"
+	if got != want {
+		t.Errorf("interdiff =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRangeDiff(t *testing.T) {
+	tr := setupTestRepo(t)
+	r := NewReader()
+
+	// The old series stopped before the README update
+	pairs, err := r.RangeDiff(tr.path, tr.hashes[2]+".."+tr.hashes[1], tr.hashes[2]+"..HEAD")
+	if err != nil {
+		t.Fatalf("RangeDiff failed: %v", err)
+	}
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 pairs, got %d", len(pairs))
+	}
+	if pairs[0].Status != domain.RangeMatched || pairs[0].New.Hash != tr.hashes[1] {
+		t.Errorf("first pair should match the main.go commit, got %+v", pairs[0])
+	}
+	if pairs[1].Status != domain.RangeAdded || pairs[1].New.Message != "Update README" {
+		t.Errorf("second pair should add the README update, got %+v", pairs[1])
+	}
+
+	if _, err := r.RangeDiff(tr.path, "HEAD", "HEAD~1..HEAD"); err == nil {
+		t.Error("RangeDiff should reject a range without ..")
+	}
+}
+
+func TestRangeDiff_SideBranch(t *testing.T) {
+	tr := setupTestRepo(t)
+	r := NewReader()
+	wt, err := tr.repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	sig := &object.Signature{Name: "Test Author", Email: "test@example.com", When: time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC)}
+
+	// A side branch forked from the first commit, merged after the third
+	writeFile(t, tr.path, "side.txt", "side\n")
+	wt.Add("side.txt")
+	side, err := wt.Commit("Add side", &git.CommitOptions{Author: sig, Parents: []plumbing.Hash{plumbing.NewHash(tr.hashes[2])}})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	merge, err := wt.Commit("Merge side", &git.CommitOptions{Author: sig, Parents: []plumbing.Hash{plumbing.NewHash(tr.hashes[0]), side}})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	// The first commit is reachable from the base through main.go, so only
+	// the side commit is in the range
+	pairs, err := r.RangeDiff(tr.path, tr.hashes[0]+".."+merge.String(), tr.hashes[0]+".."+merge.String())
+	if err != nil {
+		t.Fatalf("RangeDiff failed: %v", err)
+	}
+	if len(pairs) != 1 || pairs[0].New.Hash != side.String() {
+		t.Fatalf("expected only the side commit, got %+v", pairs)
+	}
+}
+
+func TestMergeBase(t *testing.T) {
+	tr := setupTestRepo(t)
+	r := NewReader()
+
+	base, err := r.MergeBase(tr.path, "HEAD", "HEAD~1")
+	if err != nil {
+		t.Fatalf("MergeBase failed: %v", err)
+	}
+	if base != tr.hashes[1] {
+		t.Errorf("MergeBase = %s, want %s", base, tr.hashes[1])
+	}
+}
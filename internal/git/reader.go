@@ -1,6 +1,9 @@
 package git
 
 import (
+	"errors"
+	"fmt"
+	"os/exec"
 	"sort"
 	"strings"
 
@@ -96,25 +99,10 @@ func (r *Reader) loadCommitsFromRepo(repo *git.Repository, limit int) ([]domain.
 
 	var commits []domain.Commit
 	err = iter.ForEach(func(c *object.Commit) error {
-		hash := c.Hash.String()
-
-		parents := make([]string, len(c.ParentHashes))
-		for i, p := range c.ParentHashes {
-			parents[i] = p.String()
-		}
-
-		commits = append(commits, domain.Commit{
-			Hash:        hash,
-			ShortHash:   hash[:7],
-			Author:      c.Author.Name,
-			Email:       c.Author.Email,
-			Date:        c.Committer.When,
-			Message:     firstLine(c.Message),
-			FullMessage: c.Message,
-			Parents:     parents,
-			BranchRefs:  branchRefs[hash],
-			Tags:        tagRefs[hash],
-		})
+		commit := toCommit(c)
+		commit.BranchRefs = branchRefs[commit.Hash]
+		commit.Tags = tagRefs[commit.Hash]
+		commits = append(commits, commit)
 		return nil
 	})
 	if err != nil {
@@ -132,6 +120,27 @@ func (r *Reader) loadCommitsFromRepo(repo *git.Repository, limit int) ([]domain.
 	return commits, nil
 }
 
+// toCommit converts a go-git commit, without the refs pointing to it
+func toCommit(c *object.Commit) domain.Commit {
+	hash := c.Hash.String()
+
+	parents := make([]string, len(c.ParentHashes))
+	for i, p := range c.ParentHashes {
+		parents[i] = p.String()
+	}
+
+	return domain.Commit{
+		Hash:        hash,
+		ShortHash:   hash[:7],
+		Author:      c.Author.Name,
+		Email:       c.Author.Email,
+		Date:        c.Committer.When,
+		Message:     firstLine(c.Message),
+		FullMessage: c.Message,
+		Parents:     parents,
+	}
+}
+
 // topoSortCommits sorts commits topologically (children before parents)
 // with date as secondary sort key for commits at the same level
 func topoSortCommits(commits []domain.Commit) []domain.Commit {
@@ -352,7 +361,7 @@ func getCommitChanges(repo *git.Repository, commitHash string) (object.Changes,
 }
 
 // LoadFileDiff returns the diff for a specific file in a commit
-func (r *Reader) LoadFileDiff(path string, commitHash string, filePath string) (string, bool, error) {
+func (r *Reader) LoadFileDiff(path string, commitHash string, filePath string, opts domain.DiffOptions) (string, bool, error) {
 	repo, err := git.PlainOpen(path)
 	if err != nil {
 		return "", false, err
@@ -363,7 +372,64 @@ func (r *Reader) LoadFileDiff(path string, commitHash string, filePath string) (
 		return "", false, err
 	}
 
-	// Find the change for the requested file
+	return findPatch(changes, filePath, opts)
+}
+
+// LoadTreeDiff returns the files that differ between the trees of two
+// commits, with line stats, as LoadFileChanges does for a single commit
+func (r *Reader) LoadTreeDiff(path string, fromHash string, toHash string) ([]domain.FileChange, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := treeChanges(repo, fromHash, toHash)
+	if err != nil {
+		return nil, err
+	}
+	return toFileChanges(changes), nil
+}
+
+// LoadTreeFileDiff returns the diff of a file between the trees of two commits
+func (r *Reader) LoadTreeFileDiff(path string, fromHash string, toHash string, filePath string, opts domain.DiffOptions) (string, bool, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", false, err
+	}
+
+	changes, err := treeChanges(repo, fromHash, toHash)
+	if err != nil {
+		return "", false, err
+	}
+	return findPatch(changes, filePath, opts)
+}
+
+// treeChanges returns the changes from the tree of one commit to another's
+func treeChanges(repo *git.Repository, fromHash, toHash string) (object.Changes, error) {
+	from, err := repo.CommitObject(plumbing.NewHash(fromHash))
+	if err != nil {
+		return nil, err
+	}
+	to, err := repo.CommitObject(plumbing.NewHash(toHash))
+	if err != nil {
+		return nil, err
+	}
+
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	return object.DiffTree(fromTree, toTree)
+}
+
+// findPatch returns the patch of filePath among changes, computed with
+// opts. A file that is not among them has an empty diff.
+func findPatch(changes object.Changes, filePath string, opts domain.DiffOptions) (string, bool, error) {
 	for _, change := range changes {
 		var changePath string
 		if change.To.Name != "" {
@@ -385,6 +451,12 @@ func (r *Reader) LoadFileDiff(path string, commitHash string, filePath string) (
 			if strings.Contains(patchStr, "Binary files") {
 				return "", true, nil
 			}
+			if opts != domain.DefaultDiffOptions() {
+				patchStr, err = patchWithOptions(change, patchStr, opts)
+				if err != nil {
+					return "", false, err
+				}
+			}
 			return patchStr, false, nil
 		}
 	}
@@ -392,6 +464,37 @@ func (r *Reader) LoadFileDiff(path string, commitHash string, filePath string) (
 	return "", false, nil // File not found
 }
 
+// ResolveRevision returns the commit hash a revision expression names, e.g.
+// v1.4.0, origin/main~3, HEAD^2 or an abbreviated hash. go-git reads no
+// reflog, so expressions like @{yesterday} are resolved by the git command.
+func (r *Reader) ResolveRevision(path string, rev string) (string, error) {
+	if strings.Contains(rev, "@{") {
+		return revParse(path, rev)
+	}
+
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", fmt.Errorf("unknown revision %q", rev)
+	}
+	return hash.String(), nil
+}
+
+// revParse resolves rev to a commit with git rev-parse
+func revParse(path, rev string) (string, error) {
+	out, err := exec.Command("git", "-C", path, "rev-parse", "--verify", "--quiet", "--end-of-options", rev+"^{commit}").Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", fmt.Errorf("resolving %q needs git in PATH", rev)
+	}
+	if err != nil {
+		return "", fmt.Errorf("unknown revision %q", rev)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func (r *Reader) LoadFileChanges(path string, commitHash string) ([]domain.FileChange, error) {
 	repo, err := git.PlainOpen(path)
 	if err != nil {
@@ -403,6 +506,11 @@ func (r *Reader) LoadFileChanges(path string, commitHash string) ([]domain.FileC
 		return nil, err
 	}
 
+	return toFileChanges(changes), nil
+}
+
+// toFileChanges converts changes to file changes with line stats
+func toFileChanges(changes object.Changes) []domain.FileChange {
 	var result []domain.FileChange
 	for _, change := range changes {
 		fc := domain.FileChange{}
@@ -442,5 +550,5 @@ func (r *Reader) LoadFileChanges(path string, commitHash string) ([]domain.FileC
 		result = append(result, fc)
 	}
 
-	return result, nil
+	return result
 }
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nogo/gitree/internal/domain"
 )
 
 // testRepo holds a temporary git repository for testing
@@ -302,7 +304,7 @@ func TestLoadFileDiff(t *testing.T) {
 	r := NewReader()
 
 	// Get diff for README.md in the modify commit
-	diff, isBinary, err := r.LoadFileDiff(tr.path, tr.hashes[0], "README.md")
+	diff, isBinary, err := r.LoadFileDiff(tr.path, tr.hashes[0], "README.md", domain.DefaultDiffOptions())
 	if err != nil {
 		t.Fatalf("LoadFileDiff failed: %v", err)
 	}
@@ -321,12 +323,31 @@ func TestLoadFileDiff(t *testing.T) {
 	}
 }
 
+func TestLoadFileDiff_Options(t *testing.T) {
+	tr := setupTestRepo(t)
+	r := NewReader()
+
+	opts := domain.DefaultDiffOptions()
+	opts.Context = 0
+	opts.IgnoreBlankLines = true
+	diff, _, err := r.LoadFileDiff(tr.path, tr.hashes[0], "README.md", opts)
+	if err != nil {
+		t.Fatalf("LoadFileDiff failed: %v", err)
+	}
+
+	// The file header is kept; the blank line goes with the line added
+	// after it
+	if !contains(diff, "+++ b/README.md\n@@ -1,0 +2,2 @@\n+\n+Updated content.\n") {
+		t.Errorf("unexpected diff with options:\n%s", diff)
+	}
+}
+
 func TestLoadFileDiff_AddedFile(t *testing.T) {
 	tr := setupTestRepo(t)
 	r := NewReader()
 
 	// Get diff for main.go when it was added
-	diff, isBinary, err := r.LoadFileDiff(tr.path, tr.hashes[1], "main.go")
+	diff, isBinary, err := r.LoadFileDiff(tr.path, tr.hashes[1], "main.go", domain.DefaultDiffOptions())
 	if err != nil {
 		t.Fatalf("LoadFileDiff failed: %v", err)
 	}
@@ -344,7 +365,7 @@ func TestLoadFileDiff_FileNotFound(t *testing.T) {
 	tr := setupTestRepo(t)
 	r := NewReader()
 
-	diff, _, err := r.LoadFileDiff(tr.path, tr.hashes[0], "nonexistent.txt")
+	diff, _, err := r.LoadFileDiff(tr.path, tr.hashes[0], "nonexistent.txt", domain.DefaultDiffOptions())
 	if err != nil {
 		t.Fatalf("LoadFileDiff should not error for missing file: %v", err)
 	}
@@ -354,6 +375,107 @@ func TestLoadFileDiff_FileNotFound(t *testing.T) {
 	}
 }
 
+func TestLoadTreeDiff(t *testing.T) {
+	tr := setupTestRepo(t)
+	r := NewReader()
+
+	// Initial commit to newest spans both later commits
+	changes, err := r.LoadTreeDiff(tr.path, tr.hashes[2], tr.hashes[0])
+	if err != nil {
+		t.Fatalf("LoadTreeDiff failed: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 file changes, got %d", len(changes))
+	}
+	byPath := map[string]int{}
+	for _, fc := range changes {
+		byPath[fc.Path] = int(fc.Status)
+	}
+	if status, ok := byPath["main.go"]; !ok || status != 0 { // FileAdded = 0
+		t.Errorf("expected main.go added, got %v", byPath)
+	}
+	if status, ok := byPath["README.md"]; !ok || status != 1 { // FileModified = 1
+		t.Errorf("expected README.md modified, got %v", byPath)
+	}
+
+	// Reversed, main.go is deleted
+	changes, err = r.LoadTreeDiff(tr.path, tr.hashes[0], tr.hashes[2])
+	if err != nil {
+		t.Fatalf("LoadTreeDiff failed: %v", err)
+	}
+	for _, fc := range changes {
+		if fc.Path == "main.go" && fc.Status != 2 { // FileDeleted = 2
+			t.Errorf("expected main.go deleted, got %d", fc.Status)
+		}
+	}
+}
+
+func TestLoadTreeFileDiff(t *testing.T) {
+	tr := setupTestRepo(t)
+	r := NewReader()
+
+	diff, isBinary, err := r.LoadTreeFileDiff(tr.path, tr.hashes[2], tr.hashes[0], "README.md", domain.DefaultDiffOptions())
+	if err != nil {
+		t.Fatalf("LoadTreeFileDiff failed: %v", err)
+	}
+	if isBinary {
+		t.Error("README.md should not be binary")
+	}
+	if !contains(diff, "Updated content") {
+		t.Errorf("diff should contain 'Updated content', got: %s", diff)
+	}
+
+	// Same tree on both sides
+	diff, _, err = r.LoadTreeFileDiff(tr.path, tr.hashes[0], tr.hashes[0], "README.md", domain.DefaultDiffOptions())
+	if err != nil {
+		t.Fatalf("LoadTreeFileDiff failed: %v", err)
+	}
+	if diff != "" {
+		t.Errorf("diff of a commit with itself should be empty, got: %s", diff)
+	}
+}
+
+func TestResolveRevision(t *testing.T) {
+	tr := setupTestRepo(t)
+	r := NewReader()
+
+	_, err := tr.repo.CreateTag("v1.0.0", plumbing.NewHash(tr.hashes[1]), &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "Test Author", Email: "test@example.com", When: time.Now()},
+		Message: "release",
+	})
+	if err != nil {
+		t.Fatalf("failed to tag: %v", err)
+	}
+
+	tests := []struct {
+		rev  string
+		want string
+	}{
+		{"HEAD", tr.hashes[0]},
+		{"master~2", tr.hashes[2]},
+		{"HEAD^", tr.hashes[1]},
+		{"v1.0.0", tr.hashes[1]}, // annotated tag peeled to its commit
+		{tr.hashes[2][:7], tr.hashes[2]},
+	}
+	for _, tc := range tests {
+		got, err := r.ResolveRevision(tr.path, tc.rev)
+		if err != nil {
+			t.Errorf("ResolveRevision(%q): %v", tc.rev, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ResolveRevision(%q) = %s, want %s", tc.rev, got, tc.want)
+		}
+	}
+
+	for _, rev := range []string{"nope", "HEAD^2", "HEAD@{nope}"} {
+		if _, err := r.ResolveRevision(tr.path, rev); err == nil {
+			t.Errorf("ResolveRevision(%q) should fail", rev)
+		}
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && len(substr) > 0 && findSubstring(s, substr)))
@@ -0,0 +1,113 @@
+// Package glob matches the name patterns of the branch, author and tag
+// filters and of the search fields.
+package glob
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Pattern is a compiled name pattern, matched case-insensitively. Patterns
+// containing '*', '?' or '[' are globs that must match the whole name:
+// '*' matches any run of characters including '/' (so "*/dependabot/*"
+// matches remote branches), '?' any one character and "[a-z]" or "[!0-9]"
+// one character of a class. Other patterns match as substrings.
+type Pattern struct {
+	text string         // lowercase
+	re   *regexp.Regexp // nil for a substring
+}
+
+// HasMeta reports whether s contains glob metacharacters
+func HasMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// Compile compiles pattern. The only malformed patterns are globs with an
+// unclosed '['.
+func Compile(pattern string) (Pattern, error) {
+	p := Pattern{text: strings.ToLower(pattern)}
+	if !HasMeta(p.text) {
+		return p, nil
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	runes := []rune(p.text)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := classEnd(runes, i)
+			if end < 0 {
+				return Pattern{}, fmt.Errorf("unclosed '[' in %q", pattern)
+			}
+			b.WriteString(class(runes[i+1 : end]))
+			i = end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		// e.g. a reversed range such as "[z-a]"
+		return Pattern{}, fmt.Errorf("invalid class in %q", pattern)
+	}
+	p.re = re
+	return p, nil
+}
+
+// classEnd returns the index of the ']' closing the class opened at i, -1
+// if none. A ']' first in the class (after an optional '!' or '^') is a
+// member, as in "[]]".
+func classEnd(runes []rune, i int) int {
+	j := i + 1
+	if j < len(runes) && (runes[j] == '!' || runes[j] == '^') {
+		j++
+	}
+	if j < len(runes) && runes[j] == ']' {
+		j++
+	}
+	for ; j < len(runes); j++ {
+		if runes[j] == ']' {
+			return j
+		}
+	}
+	return -1
+}
+
+// class converts the members of a glob class to a regexp class
+func class(members []rune) string {
+	var b strings.Builder
+	b.WriteString("[")
+	if len(members) > 0 && (members[0] == '!' || members[0] == '^') {
+		b.WriteString("^")
+		members = members[1:]
+	}
+	for _, r := range members {
+		if r == '-' {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(regexp.QuoteMeta(string(r)))
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Match reports whether s matches the pattern
+func (p Pattern) Match(s string) bool {
+	s = strings.ToLower(s)
+	if p.re == nil {
+		return strings.Contains(s, p.text)
+	}
+	return p.re.MatchString(s)
+}
+
+// String returns the pattern as written, in lowercase
+func (p Pattern) String() string {
+	return p.text
+}
@@ -0,0 +1,47 @@
+package glob
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		expected   bool
+	}{
+		{"ali", "Alice", true},
+		{"ALICE", "alice", true},
+		{"bob", "alice", false},
+		{"bot@*", "bot@github.com", true},
+		{"bot@*", "dependabot@github.com", false},
+		{"*bot*", "dependabot[bot]", true},
+		{"*/dependabot/*", "origin/dependabot/npm/lodash", true},
+		{"v1.?.0", "v1.2.0", true},
+		{"v1.?.0", "v1.10.0", false},
+		{"release/*", "release/1.x", true},
+		{"release/*", "origin/release/1.x", false},
+		{"v[12].*", "v2.0", true},
+		{"v[12].*", "v3.0", false},
+		{"v[!12].*", "v3.0", true},
+		{"v[0-9]*", "V7", true},
+		{"*[]]", "a]", true},
+		{"a.c*", "abc", false},
+		{"dependabot[bot]", "dependabot[bot]", false},
+		{"dependabot", "dependabot[bot]", true},
+	}
+	for _, tc := range tests {
+		p, err := Compile(tc.pattern)
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", tc.pattern, err)
+		}
+		if got := p.Match(tc.s); got != tc.expected {
+			t.Errorf("Compile(%q).Match(%q) = %v, want %v", tc.pattern, tc.s, got, tc.expected)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, pattern := range []string{"[a", "v[!", "*[]", "[z-a]"} {
+		if _, err := Compile(pattern); err == nil {
+			t.Errorf("Compile(%q) = nil error, want one", pattern)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package placeholder expands {name} placeholders in user-supplied
+// templates such as --pick formats.
+package placeholder
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var placeholderRe = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// Expand replaces each {name} in tmpl with values[name].
+// Placeholders without a value are left unchanged.
+func Expand(tmpl string, values map[string]string) string {
+	return placeholderRe.ReplaceAllStringFunc(tmpl, func(m string) string {
+		if v, ok := values[m[1:len(m)-1]]; ok {
+			return v
+		}
+		return m
+	})
+}
+
+// Validate returns an error naming the first placeholder in tmpl that is
+// not one of names
+func Validate(tmpl string, names []string) error {
+	for _, m := range placeholderRe.FindAllStringSubmatch(tmpl, -1) {
+		if !slices.Contains(names, m[1]) {
+			known := make([]string, len(names))
+			for i, n := range names {
+				known[i] = "{" + n + "}"
+			}
+			return fmt.Errorf("unknown placeholder %s (use %s)", m[0], strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
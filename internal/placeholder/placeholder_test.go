@@ -0,0 +1,34 @@
+package placeholder
+
+import "testing"
+
+func TestExpand(t *testing.T) {
+	values := map[string]string{"hash": "abc123", "path": "main.go", "subject": "Fix {path}"}
+	tests := []struct {
+		tmpl, want string
+	}{
+		{"{hash}", "abc123"},
+		{"{hash}:{path}", "abc123:main.go"},
+		{"{subject}", "Fix {path}"}, // values are not expanded again
+		{"{unknown} {hash}", "{unknown} abc123"},
+		{"no placeholders", "no placeholders"},
+		{"{}", "{}"},
+	}
+	for _, tc := range tests {
+		if got := Expand(tc.tmpl, values); got != tc.want {
+			t.Errorf("Expand(%q) = %q, want %q", tc.tmpl, got, tc.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	names := []string{"hash", "path"}
+	if err := Validate("{hash}:{path} {x", names); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate("{hash} {sha}", names); err == nil {
+		t.Error("expected error for {sha}")
+	} else if want := "unknown placeholder {sha} (use {hash}, {path})"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
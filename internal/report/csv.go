@@ -0,0 +1,51 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// writeCSV writes the summary, author, file and heatmap tables one after
+// another. Every row starts with the table name so the sections can be
+// split with grep or a spreadsheet filter; each table has its own header.
+func writeCSV(w io.Writer, r Report) error {
+	cw := csv.NewWriter(w)
+	itoa := strconv.Itoa
+
+	s := r.Summary
+	cw.Write([]string{"table", "metric", "value"})
+	cw.Write([]string{"summary", "commits", itoa(s.TotalCommits)})
+	cw.Write([]string{"summary", "authors", itoa(s.TotalAuthors)})
+	cw.Write([]string{"summary", "files", itoa(s.TotalFiles)})
+	cw.Write([]string{"summary", "additions", itoa(s.TotalAdditions)})
+	cw.Write([]string{"summary", "deletions", itoa(s.TotalDeletions)})
+	if s.TotalCommits > 0 {
+		cw.Write([]string{"summary", "first_commit", s.FirstCommit.Format(dateLayout)})
+		cw.Write([]string{"summary", "last_commit", s.LastCommit.Format(dateLayout)})
+	}
+
+	cw.Write(nil)
+	cw.Write([]string{"table", "rank", "name", "email", "commits", "share"})
+	for i, a := range r.Authors {
+		cw.Write([]string{"authors", itoa(i + 1), a.Name, a.Email, itoa(a.Commits), fmt.Sprintf("%.1f", r.share(a))})
+	}
+
+	cw.Write(nil)
+	cw.Write([]string{"table", "rank", "path", "changes", "additions", "deletions"})
+	for i, f := range r.Files {
+		cw.Write([]string{"files", itoa(i + 1), f.Path, itoa(f.ChangeCount), itoa(f.Additions), itoa(f.Deletions)})
+	}
+
+	cw.Write(nil)
+	cw.Write([]string{"table", "date", "weekday", "count", "level"})
+	if s.TotalCommits > 0 {
+		for _, d := range r.days() {
+			cw.Write([]string{"heatmap", d.Date.Format(dateLayout), d.Date.Weekday().String()[:3], itoa(d.Count), itoa(d.Level)})
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,108 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type jsonReport struct {
+	Repository string       `json:"repository,omitempty"`
+	Since      *time.Time   `json:"since,omitempty"`
+	Until      *time.Time   `json:"until,omitempty"` // exclusive
+	Summary    jsonSummary  `json:"summary"`
+	Authors    []jsonAuthor `json:"authors"`
+	Files      []jsonFile   `json:"files"`
+	Heatmap    jsonHeatmap  `json:"heatmap"`
+}
+
+type jsonSummary struct {
+	Commits     int        `json:"commits"`
+	Authors     int        `json:"authors"`
+	Files       int        `json:"files"`
+	Additions   int        `json:"additions"`
+	Deletions   int        `json:"deletions"`
+	FirstCommit *time.Time `json:"first_commit,omitempty"`
+	LastCommit  *time.Time `json:"last_commit,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name    string  `json:"name"`
+	Email   string  `json:"email"`
+	Commits int     `json:"commits"`
+	Share   float64 `json:"share"` // percent of all commits
+}
+
+type jsonFile struct {
+	Path      string `json:"path"`
+	Changes   int    `json:"changes"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+type jsonHeatmap struct {
+	WeekStart string    `json:"week_start"`
+	Start     string    `json:"start"`
+	End       string    `json:"end"`
+	MaxCount  int       `json:"max_count"`
+	Days      []jsonDay `json:"days"`
+}
+
+type jsonDay struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+	Level int    `json:"level"` // 0-4
+}
+
+// writeJSON writes the report as a single indented JSON document
+func writeJSON(w io.Writer, r Report) error {
+	s := r.Summary
+	out := jsonReport{
+		Repository: r.Repository,
+		Since:      timePtr(r.Since),
+		Until:      timePtr(r.Until),
+		Summary: jsonSummary{
+			Commits:   s.TotalCommits,
+			Authors:   s.TotalAuthors,
+			Files:     s.TotalFiles,
+			Additions: s.TotalAdditions,
+			Deletions: s.TotalDeletions,
+		},
+		Authors: make([]jsonAuthor, len(r.Authors)),
+		Files:   make([]jsonFile, len(r.Files)),
+		Heatmap: jsonHeatmap{
+			WeekStart: r.weekdayNames()[0],
+			Start:     r.Calendar.StartDate.Format(dateLayout),
+			End:       r.Calendar.EndDate.Format(dateLayout),
+			MaxCount:  r.Calendar.MaxCount,
+			Days:      []jsonDay{},
+		},
+	}
+	if s.TotalCommits > 0 {
+		out.Summary.FirstCommit = timePtr(s.FirstCommit)
+		out.Summary.LastCommit = timePtr(s.LastCommit)
+	}
+	for i, a := range r.Authors {
+		out.Authors[i] = jsonAuthor{Name: a.Name, Email: a.Email, Commits: a.Commits, Share: r.share(a)}
+	}
+	for i, f := range r.Files {
+		out.Files[i] = jsonFile{Path: f.Path, Changes: f.ChangeCount, Additions: f.Additions, Deletions: f.Deletions}
+	}
+	if s.TotalCommits > 0 {
+		for _, d := range r.days() {
+			out.Heatmap.Days = append(out.Heatmap.Days, jsonDay{Date: d.Date.Format(dateLayout), Count: d.Count, Level: d.Level})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// timePtr returns nil for zero times so they are omitted
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// writeMarkdown writes the report as GitHub-flavored Markdown tables
+func writeMarkdown(w io.Writer, r Report) error {
+	var b strings.Builder
+
+	title := "Repository report"
+	if r.Repository != "" {
+		title = "Report: " + r.Repository
+	}
+	fmt.Fprintf(&b, "# %s\n\n", mdEscape(title))
+	if period := r.period(); period != "" {
+		fmt.Fprintf(&b, "**Period:** %s\n\n", period)
+	}
+
+	s := r.Summary
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Metric | Value |\n|---|---:|\n")
+	fmt.Fprintf(&b, "| Commits | %d |\n", s.TotalCommits)
+	fmt.Fprintf(&b, "| Authors | %d |\n", s.TotalAuthors)
+	fmt.Fprintf(&b, "| Files changed | %d |\n", s.TotalFiles)
+	fmt.Fprintf(&b, "| Lines added | +%d |\n", s.TotalAdditions)
+	fmt.Fprintf(&b, "| Lines deleted | -%d |\n", s.TotalDeletions)
+	if s.TotalCommits > 0 {
+		fmt.Fprintf(&b, "| First commit | %s |\n", s.FirstCommit.Format(dateLayout))
+		fmt.Fprintf(&b, "| Last commit | %s |\n", s.LastCommit.Format(dateLayout))
+	}
+
+	b.WriteString("\n## Top authors\n\n")
+	if len(r.Authors) == 0 {
+		b.WriteString("No commits.\n")
+	} else {
+		b.WriteString("| # | Author | Email | Commits | Share |\n|---:|---|---|---:|---:|\n")
+		for i, a := range r.Authors {
+			fmt.Fprintf(&b, "| %d | %s | %s | %d | %.1f%% |\n", i+1, mdEscape(a.Name), mdEscape(a.Email), a.Commits, r.share(a))
+		}
+	}
+
+	b.WriteString("\n## Most changed files\n\n")
+	if len(r.Files) == 0 {
+		b.WriteString("No file changes.\n")
+	} else {
+		b.WriteString("| # | File | Changes | Added | Deleted |\n|---:|---|---:|---:|---:|\n")
+		for i, f := range r.Files {
+			fmt.Fprintf(&b, "| %d | `%s` | %d | +%d | -%d |\n", i+1, strings.ReplaceAll(f.Path, "|", `\|`), f.ChangeCount, f.Additions, f.Deletions)
+		}
+	}
+
+	b.WriteString("\n## Activity\n\n")
+	if s.TotalCommits == 0 {
+		b.WriteString("No commits.\n")
+	} else {
+		// One row per week, one column per weekday
+		b.WriteString("| Week | " + strings.Join(r.weekdayNames(), " | ") + " | Total |\n")
+		b.WriteString("|---|" + strings.Repeat("---:|", 8) + "\n")
+		for _, week := range r.Calendar.Cells {
+			total := 0
+			cols := make([]string, len(week))
+			for i, cell := range week {
+				if cell.Date.Before(r.Calendar.StartDate) || cell.Date.After(r.Calendar.EndDate) {
+					continue
+				}
+				cols[i] = fmt.Sprint(cell.Count)
+				total += cell.Count
+			}
+			fmt.Fprintf(&b, "| %s | %s | %d |\n", week[0].Date.Format(dateLayout), strings.Join(cols, " | "), total)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// period describes the --since/--until range, or "" if unbounded
+func (r Report) period() string {
+	switch {
+	case !r.Since.IsZero() && !r.Until.IsZero():
+		return fmt.Sprintf("%s to %s", r.Since.Format(dateLayout), lastDay(r.Until))
+	case !r.Since.IsZero():
+		return "since " + r.Since.Format(dateLayout)
+	case !r.Until.IsZero():
+		return "until " + lastDay(r.Until)
+	}
+	return ""
+}
+
+// mdEscape escapes characters that break Markdown table cells
+func mdEscape(s string) string {
+	r := strings.NewReplacer("|", `\|`, "<", "&lt;", ">", "&gt;", "*", `\*`, "_", `\_`)
+	return r.Replace(s)
+}
@@ -0,0 +1,134 @@
+// Package report renders the insights statistics (authors, file churn,
+// summary and activity heatmap) as Markdown, JSON or CSV.
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/insights"
+)
+
+// Format is an output format of the report command
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+)
+
+// Formats lists all supported formats
+var Formats = []Format{FormatMarkdown, FormatJSON, FormatCSV}
+
+// ParseFormat parses a format name (case-insensitive, "markdown" = md)
+func ParseFormat(name string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(name)))
+	if f == "markdown" {
+		return FormatMarkdown, nil
+	}
+	for _, known := range Formats {
+		if f == known {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q (use md, json or csv)", name)
+}
+
+// Options control what goes into a report
+type Options struct {
+	Repository string    // name shown in the title
+	Since      time.Time // start of the filtered time range (zero = open)
+	Until      time.Time // exclusive end of the filtered time range (zero = open)
+	Top        int       // rows in the author and file tables (0 = all)
+	WeekStart  insights.WeekStartDay
+}
+
+// Report holds the computed statistics
+type Report struct {
+	Options
+	Summary  insights.Summary
+	Authors  []insights.AuthorStats
+	Files    []insights.FileStats
+	Calendar insights.CalendarData
+}
+
+// Build computes a report from commits and their file changes.
+// The summary covers all authors and files; only the tables are cut to Top.
+func Build(commits []domain.Commit, fileChanges map[string][]domain.FileChange, opts Options) Report {
+	authors := insights.ComputeAuthorStats(commits, 0)
+	files := insights.ComputeFileStats(fileChanges, 0)
+
+	commitPtrs := make([]*domain.Commit, len(commits))
+	for i := range commits {
+		commitPtrs[i] = &commits[i]
+	}
+
+	r := Report{
+		Options:  opts,
+		Summary:  insights.ComputeSummary(commits, authors, files),
+		Authors:  authors,
+		Files:    files,
+		Calendar: insights.ComputeCalendarData(commitPtrs, opts.WeekStart),
+	}
+	if opts.Top > 0 {
+		r.Authors = r.Authors[:min(opts.Top, len(r.Authors))]
+		r.Files = r.Files[:min(opts.Top, len(r.Files))]
+	}
+	return r
+}
+
+// Write renders the report in the given format
+func Write(w io.Writer, format Format, r Report) error {
+	switch format {
+	case FormatMarkdown:
+		return writeMarkdown(w, r)
+	case FormatJSON:
+		return writeJSON(w, r)
+	case FormatCSV:
+		return writeCSV(w, r)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// dateLayout is used for days in all formats
+const dateLayout = "2006-01-02"
+
+// share returns an author's percentage of all commits
+func (r Report) share(a insights.AuthorStats) float64 {
+	if r.Summary.TotalCommits == 0 {
+		return 0
+	}
+	return float64(a.Commits) * 100 / float64(r.Summary.TotalCommits)
+}
+
+// days returns the heatmap cells within the commit date range, oldest first
+func (r Report) days() []insights.DayCell {
+	var days []insights.DayCell
+	for _, week := range r.Calendar.Cells {
+		for _, cell := range week {
+			if cell.Date.Before(r.Calendar.StartDate) || cell.Date.After(r.Calendar.EndDate) {
+				continue
+			}
+			days = append(days, cell)
+		}
+	}
+	return days
+}
+
+// weekdayNames returns the heatmap column names in week order
+func (r Report) weekdayNames() []string {
+	names := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+	if r.WeekStart == insights.WeekStartMonday {
+		names = append(names[1:], names[0])
+	}
+	return names
+}
+
+// lastDay returns the last day included by an exclusive until bound
+func lastDay(until time.Time) string {
+	return until.Add(-time.Nanosecond).Format(dateLayout)
+}
@@ -0,0 +1,136 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/insights"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func fixture() ([]domain.Commit, map[string][]domain.FileChange) {
+	at := func(day int) time.Time {
+		return time.Date(2025, 3, day, 10, 0, 0, 0, time.UTC)
+	}
+	commits := []domain.Commit{
+		{Hash: "e", Author: "Alice", Email: "alice@example.com", Date: at(11)},
+		{Hash: "d", Author: "Bob", Email: "bob@example.com", Date: at(10)},
+		{Hash: "c", Author: "Alice", Email: "alice@example.com", Date: at(5)},
+		{Hash: "b", Author: "Alice", Email: "alice@example.com", Date: at(5)},
+		{Hash: "a", Author: "Carol | QA", Email: "carol@example.com", Date: at(3)},
+	}
+	files := map[string][]domain.FileChange{
+		"e": {{Path: "main.go", Additions: 10, Deletions: 2}},
+		"d": {{Path: "main.go", Additions: 1, Deletions: 1}, {Path: "README.md", Additions: 5}},
+		"c": {{Path: "internal/app.go", Additions: 40, Deletions: 8}},
+		"b": {{Path: "main.go", Additions: 3}},
+		"a": {{Path: "README.md", Additions: 20}},
+	}
+	return commits, files
+}
+
+func fixtureReport(top int) Report {
+	commits, files := fixture()
+	return Build(commits, files, Options{
+		Repository: "demo",
+		Since:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Until:      time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		Top:        top,
+		WeekStart:  insights.WeekStartMonday,
+	})
+}
+
+func TestWriteGolden(t *testing.T) {
+	r := fixtureReport(10)
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, format, r); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+
+			golden := filepath.Join("testdata", "report."+string(format)+".golden")
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update to create): %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("output differs from %s\ngot:\n%s\nwant:\n%s", golden, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestBuildTopKeepsTotals(t *testing.T) {
+	r := fixtureReport(1)
+	if len(r.Authors) != 1 || r.Authors[0].Email != "alice@example.com" {
+		t.Errorf("authors = %+v, want only alice", r.Authors)
+	}
+	if len(r.Files) != 1 || r.Files[0].Path != "main.go" {
+		t.Errorf("files = %+v, want only main.go", r.Files)
+	}
+	// The summary is computed before the tables are cut
+	if r.Summary.TotalAuthors != 3 || r.Summary.TotalFiles != 3 {
+		t.Errorf("summary authors/files = %d/%d, want 3/3", r.Summary.TotalAuthors, r.Summary.TotalFiles)
+	}
+	if r.Summary.TotalAdditions != 79 || r.Summary.TotalDeletions != 11 {
+		t.Errorf("summary +%d -%d, want +79 -11", r.Summary.TotalAdditions, r.Summary.TotalDeletions)
+	}
+}
+
+func TestWriteCSVParses(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, fixtureReport(0)); err != nil {
+		t.Fatal(err)
+	}
+	rd := csv.NewReader(&buf)
+	rd.FieldsPerRecord = -1
+	records, err := rd.ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	heatmap := 0
+	for _, rec := range records {
+		if rec[0] == "heatmap" {
+			heatmap++
+		}
+	}
+	// Mar 3 through Mar 11
+	if heatmap != 9 {
+		t.Errorf("heatmap rows = %d, want 9", heatmap)
+	}
+}
+
+func TestWriteEmpty(t *testing.T) {
+	r := Build(nil, nil, Options{})
+	for _, format := range Formats {
+		var buf bytes.Buffer
+		if err := Write(&buf, format, r); err != nil {
+			t.Errorf("%s: %v", format, err)
+		}
+		if buf.Len() == 0 {
+			t.Errorf("%s: empty output", format)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("Markdown"); err != nil || f != FormatMarkdown {
+		t.Errorf("ParseFormat(Markdown) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("xlsx"); err == nil {
+		t.Error("ParseFormat(xlsx) should fail")
+	}
+}
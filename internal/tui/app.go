@@ -5,13 +5,21 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/config"
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/git"
+	"github.com/nogo/gitree/internal/tui/command"
+	"github.com/nogo/gitree/internal/tui/compare"
 	"github.com/nogo/gitree/internal/tui/diff"
 	"github.com/nogo/gitree/internal/tui/filtering"
 	"github.com/nogo/gitree/internal/tui/histogram"
 	"github.com/nogo/gitree/internal/tui/insights"
+	"github.com/nogo/gitree/internal/tui/keys"
 	"github.com/nogo/gitree/internal/tui/list"
+	"github.com/nogo/gitree/internal/tui/palette"
+	"github.com/nogo/gitree/internal/tui/rangediff"
 	"github.com/nogo/gitree/internal/tui/search"
+	"github.com/nogo/gitree/internal/tui/views"
 	"github.com/nogo/gitree/internal/watcher"
 )
 
@@ -26,25 +34,58 @@ func spinnerTick() tea.Cmd {
 }
 
 type Model struct {
-	repo     *domain.Repository
-	repoPath string
-	reader   domain.GitReader
-	list     list.Model
-	diffView diff.DiffView
-	filters  *filtering.Manager
-	search   search.Search
+	repo                *domain.Repository
+	repoPath            string
+	reader              domain.GitReader
+	list                list.Model
+	diffView            diff.DiffView
+	filters             *filtering.Manager
+	search              search.Search
 	histogram           histogram.Histogram
 	insights            insights.InsightsView
 	watcher             *watcher.Watcher
 	watching            bool
 	showDiff            bool
+	showCompare         bool
+	compareView         compare.View
+	compareBase         string // commit compared with the next one = is pressed on
+	showRangeDiff       bool
+	rangeView           rangediff.View
 	showBranchFilter    bool
 	showAuthorFilter    bool
 	showAuthorHighlight bool
 	showTagFilter       bool
 	showHelp            bool
+	helpScroll          int // first visible line of the help overlay
 	showInsights        bool
+	showViews           bool
+	viewPicker          views.Picker
+	viewStore           config.ViewStore
+	showPalette         bool
+	palette             palette.Palette
+	keys                keys.Map
+	keyWarnings         []string         // shadowed bindings, reported on startup
+	commands            []config.Command // user commands from the config file
+	showCommand         bool
+	commandPopup        command.Popup
+	yankPending         bool                // y pressed, waiting for what to copy
+	pendingGoto         RevisionResolvedMsg // goto target hidden by filters, awaiting y/n
+	jumps               jumpList            // positions to go back and forward to
+	restoringJump       *jump               // jump waiting for the files of its expanded commit
+	parentCycle         parentCycle         // merge whose parents P steps through
+	markStore           config.MarkStore
+	marks               []config.Mark
+	markTargets         map[string]markTarget // mark name → commit in the loaded history
+	markPending         string                // keys.SetMark or keys.GotoMark, waiting for the mark name
+	flash               string                // transient footer message (e.g. "copied hash")
+	flashErr            bool
+	flashID             int        // identifies the flash a FlashExpiredMsg clears
+	initCmd             tea.Cmd    // pending work from setup before the program starts (e.g. --view)
+	stdinCommits        int        // commits read with --stdin (0 = none)
+	pick                *pickState // selection in --pick mode (nil = off)
 	insightsLoading     bool
+	searchLoading       bool                           // loading file changes for file: search terms
+	fileCache           map[string][]domain.FileChange // commit hash → file changes (immutable per hash)
 	spinnerFrame        int
 	width               int
 	height              int
@@ -53,26 +94,50 @@ type Model struct {
 }
 
 func NewModel(repo *domain.Repository, repoPath string, w *watcher.Watcher, reader domain.GitReader) Model {
-	return Model{
-		repo:      repo,
-		repoPath:  repoPath,
-		reader:    reader,
-		list:      list.New(repo),
-		diffView:  diff.New(),
-		filters:   filtering.New(repo),
-		search:    search.New(),
-		histogram: histogram.New(repo.Commits, 80), // default width, will resize
-		insights:  insights.New(),
-		watcher:   w,
-		watching:  w != nil,
+	m := Model{
+		repo:         repo,
+		repoPath:     repoPath,
+		reader:       reader,
+		list:         list.New(repo),
+		diffView:     diff.New(),
+		compareView:  compare.New(),
+		rangeView:    rangediff.New(),
+		filters:      filtering.New(repo),
+		search:       search.New(),
+		histogram:    histogram.New(repo.Commits, 80), // default width, will resize
+		insights:     insights.New(),
+		watcher:      w,
+		watching:     w != nil,
+		fileCache:    make(map[string][]domain.FileChange),
+		viewPicker:   views.New(),
+		commandPopup: command.NewPopup(),
+		palette:      palette.New(),
+		viewStore:    config.NewViewStore(repoPath),
+		markStore:    config.NewMarkStore(repoPath),
+		keys:         keys.Default(),
 	}
+	if err := m.reloadMarks(); err != nil {
+		m.initCmd = m.setFlash("marks: "+err.Error(), true)
+	}
+	return m
+}
+
+// AddInitCmd queues cmd to run when the program starts, after the commands
+// queued during setup
+func (m *Model) AddInitCmd(cmd tea.Cmd) {
+	m.initCmd = tea.Batch(m.initCmd, cmd)
 }
 
 func (m Model) Init() tea.Cmd {
+	cmd := m.initCmd
+	if m.insightsLoading {
+		// Insights opened on start by the config file
+		cmd = tea.Batch(cmd, m.loadInsights(), spinnerTick())
+	}
 	if m.watcher != nil {
-		return m.watchForChanges()
+		return tea.Batch(cmd, m.watchForChanges())
 	}
-	return nil
+	return cmd
 }
 
 // watchForChanges returns a command that waits for watcher signal
@@ -95,18 +160,36 @@ func (m Model) reloadRepo() tea.Cmd {
 
 // loadFileDiff returns a command that loads the diff for the current file
 func (m Model) loadFileDiff() tea.Cmd {
-	commit := m.list.SelectedCommit()
-	if commit == nil {
-		return nil
-	}
 	reader := m.reader
 	filePath := m.diffView.CurrentFile()
 	fileIndex := m.diffView.FileIndex()
+	opts := m.diffView.Options()
 	repoPath := m.repoPath
+
+	// A diff opened from the compare view is between the two trees
+	if m.showCompare {
+		from := m.compareView.From().Hash
+		to := m.compareView.To().Hash
+		return func() tea.Msg {
+			diff, isBinary, err := reader.LoadTreeFileDiff(repoPath, from, to, filePath, opts)
+			return DiffLoadedMsg{
+				FilePath:  filePath,
+				Diff:      diff,
+				IsBinary:  isBinary,
+				FileIndex: fileIndex,
+				Err:       err,
+			}
+		}
+	}
+
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return nil
+	}
 	commitHash := commit.Hash
 
 	return func() tea.Msg {
-		diff, isBinary, err := reader.LoadFileDiff(repoPath, commitHash, filePath)
+		diff, isBinary, err := reader.LoadFileDiff(repoPath, commitHash, filePath, opts)
 		return DiffLoadedMsg{
 			FilePath:  filePath,
 			Diff:      diff,
@@ -133,11 +216,61 @@ func (m Model) loadExpandedFiles() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Results of background work must not be dropped by an open overlay
+	switch msg := msg.(type) {
+	case CommandFinishedMsg:
+		if msg.Command.Background() || msg.Err != nil {
+			m.commandPopup.ShowResult(msg.Command, msg.Line, msg.Output, msg.Err)
+			m.showCommand = true
+		}
+		return m, nil
+
+	case YankedMsg:
+		if msg.Err != nil {
+			return m, m.setFlash("copy failed: "+msg.Err.Error(), true)
+		}
+		return m, m.setFlash("copied "+msg.What, false)
+
+	case FlashExpiredMsg:
+		if msg.ID == m.flashID {
+			m.flash = ""
+			m.flashErr = false
+			m.diffView.SetStatus("")
+			m.compareView.SetStatus("")
+			m.rangeView.SetStatus("")
+			m.list.SetFlash("")
+		}
+		return m, nil
+
+	case RevisionResolvedMsg:
+		return m, m.handleRevisionResolved(msg)
+	}
+
+	// Handle command confirmation and output popup
+	if m.showCommand {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			var confirmed, closed bool
+			m.commandPopup, _, confirmed, closed = m.commandPopup.Update(keyMsg)
+			if closed {
+				m.showCommand = false
+			}
+			if confirmed {
+				return m, m.runCommand(m.commandPopup.Command())
+			}
+			return m, nil
+		}
+		return m, nil
+	}
+
 	// Handle help overlay
 	if m.showHelp {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			switch keyMsg.String() {
-			case "esc", "h", "q", "enter", " ":
+			switch key := keyMsg.String(); {
+			case m.keys.Is(key, keys.Down):
+				m.scrollHelp(1)
+			case m.keys.Is(key, keys.Up):
+				m.scrollHelp(-1)
+			default:
 				m.showHelp = false
 			}
 			return m, nil
@@ -220,6 +353,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Handle saved views overlay
+	if m.showViews {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			var req views.Request
+			var cancelled bool
+			m.viewPicker, cmd, req, cancelled = m.viewPicker.Update(keyMsg)
+			if req.Action != views.ActionNone {
+				cmd = tea.Batch(cmd, m.handleViewRequest(req))
+			}
+			if cancelled {
+				m.showViews = false
+			}
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	// Handle command palette
+	if m.showPalette {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			var req palette.Request
+			var closed bool
+			m.palette, cmd, req, closed = m.palette.Update(keyMsg)
+			if closed {
+				m.showPalette = false
+			}
+			if req.ID != "" {
+				cmd = tea.Batch(cmd, m.runPaletteRequest(req))
+			}
+			return m, cmd
+		}
+		return m, nil
+	}
+
 	// Handle search input mode
 	if m.search.IsInputMode() {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
@@ -227,7 +396,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var done, cancelled bool
 			m.search, cmd, done, cancelled = m.search.Update(keyMsg)
 			if done {
-				m.executeSearch()
+				cmd = tea.Batch(cmd, m.executeSearch())
 			}
 			if cancelled {
 				// Input cancelled, search state preserved
@@ -240,12 +409,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Handle histogram focus mode
 	if m.histogram.IsFocused() {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			switch keyMsg.String() {
-			case "tab":
+			switch key := keyMsg.String(); {
+			case m.keys.Is(key, keys.HistogramBack):
 				// Switch focus back to list
 				m.histogram.SetFocused(false)
 				return m, nil
-			case "q", "ctrl+c":
+			case m.keys.Is(key, keys.HistogramQuit):
 				return m, tea.Quit
 			default:
 				var selectionChanged bool
@@ -276,7 +445,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.histogram.Recalculate(msg.Repo.Commits, m.width)
 			// Reapply filters if any active
 			var cmd tea.Cmd
-			if m.filters.BranchFilterActive() || m.filters.AuthorFilterActive() || m.filters.TagFilterActive() || m.filters.TimeFilterActive() {
+			if m.filters.Active() {
 				cmd = m.applyAllFilters()
 			} else {
 				m.list.SetRepo(msg.Repo)
@@ -286,24 +455,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cmd = tea.Batch(m.loadInsights(), spinnerTick())
 				}
 			}
+			// Marks may point to rewritten commits now
+			m.refreshMarks()
 			// Reapply highlight if active
 			if m.filters.AuthorHighlightActive() {
 				m.applyHighlight()
 			}
 			// Re-execute search if active
 			if m.search.IsActive() {
-				m.executeSearch()
+				cmd = tea.Batch(cmd, m.executeSearch())
 			}
 			return m, cmd
 		}
 		return m, nil
 
+	case SearchFilesLoadedMsg:
+		m.searchLoading = false
+		for hash, files := range msg.Files {
+			m.fileCache[hash] = files
+		}
+		if m.search.IsActive() {
+			return m, m.executeSearch()
+		}
+		return m, nil
+
 	case ExpandedFilesLoadedMsg:
 		if msg.Err == nil {
 			m.list.SetExpandedFiles(msg.Files)
 		} else {
 			m.list.SetExpandedFilesError()
 		}
+		return m, m.restoreJumpFile()
+
+	case CompareLoadedMsg:
+		m.handleCompareLoaded(msg)
+		return m, nil
+
+	case RangeDiffLoadedMsg:
+		m.handleRangeDiffLoaded(msg)
 		return m, nil
 
 	case DiffLoadedMsg:
@@ -321,166 +510,134 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case SpinnerTickMsg:
-		if m.insightsLoading {
+		if m.insightsLoading || m.searchLoading {
 			m.spinnerFrame = (m.spinnerFrame + 1) % len(spinnerFrames)
 			return m, spinnerTick()
 		}
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.pendingGoto.Hash != "" {
+			return m, m.confirmGoto(msg.String())
+		}
+
+		// A prefix waiting for its key wins over starting another one
+		if m.markPending != "" {
+			return m, m.handleMarkKey(msg.String())
+		}
+
+		// A diff search being typed or a key sequence takes every key
+		if m.showDiff && m.diffView.CapturesKeys() {
+			var cmd tea.Cmd
+			m.diffView, cmd = m.diffView.Update(msg)
+			return m, cmd
+		}
+
+		// y starts a yank in the list, expanded commit and diff view
+		if handled, cmd := m.handleYankKey(msg.String()); handled {
+			return m, cmd
+		}
+
+		// The jump list works in every view
+		switch key := msg.String(); {
+		case m.keys.Is(key, keys.JumpBack):
+			return m, m.jumpBack()
+		case m.keys.Is(key, keys.JumpForward):
+			return m, m.jumpForward()
+		}
+
 		// Handle diff view keys
 		if m.showDiff {
-			switch msg.String() {
-			case "q", "esc":
+			switch key := msg.String(); {
+			case m.keys.Is(key, keys.DiffClose):
+				if m.showCompare {
+					// Back to the file last shown
+					m.compareView.SetFileCursor(m.diffView.FileIndex())
+				}
 				m.diffView.Hide()
 				m.showDiff = false
 				return m, nil
-			case "h", "left":
+			case m.keys.Is(key, keys.DiffPrevFile):
 				// Previous file
 				if m.diffView.PrevFile() {
 					return m, m.loadFileDiff()
 				}
 				return m, nil
-			case "l", "right":
+			case m.keys.Is(key, keys.DiffNextFile):
 				// Next file
 				if m.diffView.NextFile() {
 					return m, m.loadFileDiff()
 				}
 				return m, nil
 			}
-			m.diffView, _ = m.diffView.Update(msg)
-			return m, nil
+			if handled, cmd := m.handleDiffOptionKey(msg.String()); handled {
+				return m, cmd
+			}
+			var cmd tea.Cmd
+			m.diffView, cmd = m.diffView.Update(msg)
+			return m, cmd
+		}
+
+		if m.showRangeDiff {
+			return m, m.handleRangeDiffKey(msg)
+		}
+
+		if m.showCompare {
+			return m, m.handleCompareKey(msg)
+		}
+
+		// Pick mode overrides enter and space
+		if m.pick != nil {
+			if handled, cmd := m.handlePickKey(msg); handled {
+				return m, cmd
+			}
 		}
 
 		// Handle expanded commit view keys
 		if m.list.IsExpanded() {
-			switch msg.String() {
-			case "esc":
+			switch key := msg.String(); {
+			case m.keys.Is(key, keys.ExpandedCollapse):
 				m.list.Collapse()
 				return m, nil
-			case "enter":
+			case m.keys.Is(key, keys.ExpandedOpenDiff):
 				// Open diff for selected file
 				if m.list.HasExpandedFiles() {
-					m.diffView.Show(m.list.ExpandedFiles(), m.list.FileCursor())
-					m.diffView.SetSize(m.width, m.height)
-					m.showDiff = true
-					return m, m.loadFileDiff()
+					m.recordJump()
+					return m, m.openDiff()
 				}
 				return m, nil
-			case "j", "down":
+			case m.keys.Is(key, keys.ExpandedDown):
 				// Navigate within file list
 				m.list.FileCursorDown()
 				return m, nil
-			case "k", "up":
+			case m.keys.Is(key, keys.ExpandedUp):
 				// Navigate within file list
 				m.list.FileCursorUp()
 				return m, nil
-			case "q", "ctrl+c":
+			case m.keys.Is(key, keys.ExpandedQuit):
 				return m, tea.Quit
 			}
+			if handled, cmd := m.handleCommandKey(msg.String()); handled {
+				return m, cmd
+			}
 			// Block all other keys (including commit navigation) when expanded
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
-			return m, tea.Quit
-
-		case "enter":
-			selected := m.list.SelectedCommit()
-			if selected != nil {
-				m.list.Expand()
-				return m, m.loadExpandedFiles()
-			}
-			return m, nil
-
-		case "b":
-			m.filters.BranchFilter().SetSize(m.width, m.height)
-			m.showBranchFilter = true
-			return m, nil
-
-		case "a":
-			m.filters.AuthorFilter().SetSize(m.width, m.height)
-			m.showAuthorFilter = true
-			return m, nil
-
-		case "A":
-			m.filters.AuthorHighlight().SetSize(m.width, m.height)
-			m.showAuthorHighlight = true
-			return m, nil
-
-		case "t":
-			m.filters.TagFilter().SetSize(m.width, m.height)
-			m.showTagFilter = true
-			return m, nil
-
-		case "h":
-			m.showHelp = true
-			return m, nil
-
-		case "/":
-			m.search.Activate()
-			return m, nil
-
-		case "n":
-			// Next search match
-			if m.search.IsActive() && m.search.MatchCount() > 0 {
-				m.search.NextMatch()
-				m.jumpToCurrentMatch()
-			}
-			return m, nil
-
-		case "N":
-			// Previous search match
-			if m.search.IsActive() && m.search.MatchCount() > 0 {
-				m.search.PrevMatch()
-				m.jumpToCurrentMatch()
-			}
-			return m, nil
-
-		case "r":
-			// Toggle range/histogram visibility
-			m.histogram.Toggle()
-			m.recalculateListHeight()
-			m.insights.SetSize(m.width, m.insightsContentHeight())
-			return m, nil
-
-		case "i":
-			// Toggle insights view
-			m.showInsights = !m.showInsights
-			if m.showInsights {
-				m.insightsLoading = true
-				return m, tea.Batch(m.loadInsights(), spinnerTick())
-			}
-			return m, nil
-
-		case "tab":
-			// Switch focus to histogram (if visible)
-			if m.histogram.IsVisible() {
-				m.histogram.SetFocused(true)
-			}
+		action := m.keys.Lookup(keys.ModeList, msg.String())
+		if handled, cmd := m.runAction(action); handled {
+			return m, cmd
+		}
+		if msg.String() == "esc" {
+			// No action when not in detail/filter view
 			return m, nil
+		}
 
-		case "c":
-			// Clear all filters, highlight, and search
-			m.filters.Reset()
-			m.histogram.Reset()
-			m.search.Clear()
-			m.list.SetHighlightedEmails(nil)
-			m.list.SetMatchIndices(nil)
-			m.list.SetRepo(m.repo)
-			// Recalculate histogram with all commits
-			m.histogram.Recalculate(m.repo.Commits, m.width)
-			// Reload insights if visible
-			if m.showInsights {
-				m.insightsLoading = true
-				return m, tea.Batch(m.loadInsights(), spinnerTick())
+		// Keys not used by gitree may run user commands
+		if action == "" {
+			if handled, cmd := m.handleCommandKey(msg.String()); handled {
+				return m, cmd
 			}
-			return m, nil
-
-		case "esc":
-			// No action when not in detail/filter view
-			return m, nil
 		}
 
 	case tea.WindowSizeMsg:
@@ -491,10 +648,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.histogram.Recalculate(m.repo.Commits, msg.Width)
 		m.recalculateListHeight()
 		m.diffView.SetSize(msg.Width, msg.Height)
+		m.compareView.SetSize(msg.Width, msg.Height)
+		m.rangeView.SetSize(msg.Width, msg.Height)
 		m.filters.BranchFilter().SetSize(msg.Width, msg.Height)
 		m.filters.AuthorFilter().SetSize(msg.Width, msg.Height)
 		m.filters.AuthorHighlight().SetSize(msg.Width, msg.Height)
 		m.filters.TagFilter().SetSize(msg.Width, msg.Height)
+		m.viewPicker.SetSize(msg.Width, msg.Height)
+		m.palette.SetSize(msg.Width, msg.Height)
+		m.commandPopup.SetSize(msg.Width, msg.Height)
 		m.insights.SetSize(msg.Width, m.insightsContentHeight())
 	}
 
@@ -504,6 +666,160 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// runAction performs an action of the commit list, for its key or the
+// command palette. Returns false for actions the list handles itself
+// (cursor movement).
+func (m *Model) runAction(action string) (bool, tea.Cmd) {
+	switch action {
+	case keys.Quit:
+		return true, tea.Quit
+
+	case keys.Top, keys.Bottom:
+		// The list moves the cursor
+		m.recordJump()
+		return false, nil
+
+	case keys.Expand:
+		selected := m.list.SelectedCommit()
+		if selected != nil {
+			m.list.Expand()
+			return true, m.loadExpandedFiles()
+		}
+		return true, nil
+
+	case keys.BranchFilter:
+		m.filters.BranchFilter().SetSize(m.width, m.height)
+		m.showBranchFilter = true
+		return true, nil
+
+	case keys.AuthorFilter:
+		m.filters.AuthorFilter().SetSize(m.width, m.height)
+		m.showAuthorFilter = true
+		return true, nil
+
+	case keys.AuthorHighlight:
+		m.filters.AuthorHighlight().SetSize(m.width, m.height)
+		m.showAuthorHighlight = true
+		return true, nil
+
+	case keys.TagFilter:
+		m.filters.TagFilter().SetSize(m.width, m.height)
+		m.showTagFilter = true
+		return true, nil
+
+	case keys.Help:
+		m.showHelp = true
+		m.helpScroll = 0
+		return true, nil
+
+	case keys.Search:
+		m.search.Activate()
+		return true, nil
+
+	case keys.Views:
+		m.openViews()
+		return true, nil
+
+	case keys.NextMatch:
+		// Next search match
+		if m.search.IsActive() && m.search.MatchCount() > 0 {
+			m.search.NextMatch()
+			m.jumpToCurrentMatch()
+		}
+		return true, nil
+
+	case keys.PrevMatch:
+		// Previous search match
+		if m.search.IsActive() && m.search.MatchCount() > 0 {
+			m.search.PrevMatch()
+			m.jumpToCurrentMatch()
+		}
+		return true, nil
+
+	case keys.Histogram:
+		// Toggle range/histogram visibility
+		m.histogram.Toggle()
+		m.recalculateListHeight()
+		m.insights.SetSize(m.width, m.insightsContentHeight())
+		return true, nil
+
+	case keys.Insights:
+		// Toggle insights view
+		m.showInsights = !m.showInsights
+		if m.showInsights {
+			m.insightsLoading = true
+			return true, tea.Batch(m.loadInsights(), spinnerTick())
+		}
+		return true, nil
+
+	case keys.FocusHistogram:
+		// Switch focus to histogram (if visible)
+		if m.histogram.IsVisible() {
+			m.histogram.SetFocused(true)
+		}
+		return true, nil
+
+	case keys.Clear:
+		return true, m.clearFilters()
+
+	case keys.Palette:
+		return true, m.openPalette()
+
+	case keys.Goto:
+		return true, m.openGoto()
+
+	case keys.Parent:
+		return true, m.gotoParent()
+
+	case keys.OtherParent:
+		return true, m.gotoOtherParent()
+
+	case keys.Child:
+		return true, m.gotoChild()
+
+	case keys.MergeInto:
+		return true, m.openMergeInto()
+
+	case keys.SetMark, keys.GotoMark:
+		m.markPending = action
+		return true, nil
+
+	case keys.Marks:
+		return true, m.openMarks()
+
+	case keys.Compare:
+		return true, m.compareWithBase()
+
+	case keys.RangeDiff:
+		return true, m.openRangeDiff()
+	}
+	return false, nil
+}
+
+// clearFilters clears all filters, the highlight and the search
+func (m *Model) clearFilters() tea.Cmd {
+	m.filters.Reset()
+	m.histogram.Reset()
+	m.search.Clear()
+	m.list.SetHighlightedEmails(nil)
+	m.list.SetMatchIndices(nil)
+	m.list.SetMatchHighlighter(nil)
+	// Commits piped in with --stdin stay
+	if result := m.filters.ApplyFilters(); result.IsFiltered {
+		m.list.SetFilteredCommits(result.Commits, m.repo)
+	} else {
+		m.list.SetRepo(m.repo)
+	}
+	// Recalculate histogram with all commits
+	m.histogram.Recalculate(m.repo.Commits, m.width)
+	// Reload insights if visible
+	if m.showInsights {
+		m.insightsLoading = true
+		return tea.Batch(m.loadInsights(), spinnerTick())
+	}
+	return nil
+}
+
 func (m *Model) applyFilter() tea.Cmd {
 	m.filters.UpdateFilterActive()
 	return m.applyAllFilters()
@@ -557,16 +873,17 @@ func (m *Model) applyAllFilters() tea.Cmd {
 	}
 
 	// Re-execute search if active
+	var searchCmd tea.Cmd
 	if m.search.IsActive() {
-		m.executeSearch()
+		searchCmd = m.executeSearch()
 	}
 
 	// Reload insights if visible
 	if m.showInsights {
 		m.insightsLoading = true
-		return tea.Batch(m.loadInsights(), spinnerTick())
+		return tea.Batch(m.loadInsights(), spinnerTick(), searchCmd)
 	}
-	return nil
+	return searchCmd
 }
 
 // loadInsights returns a command that loads insights data asynchronously
@@ -592,39 +909,7 @@ func (m Model) loadInsights() tea.Cmd {
 			fileChangeLimit = maxFileChangeCommits
 		}
 
-		// Load file changes in parallel for better performance
-		type result struct {
-			hash  string
-			files []domain.FileChange
-		}
-		resultChan := make(chan result, fileChangeLimit)
-
-		// Use worker pool to limit concurrency
-		const maxWorkers = 20
-		sem := make(chan struct{}, maxWorkers)
-
-		for i := 0; i < fileChangeLimit; i++ {
-			go func(commit domain.Commit) {
-				sem <- struct{}{}        // acquire
-				defer func() { <-sem }() // release
-
-				files, err := reader.LoadFileChanges(repoPath, commit.Hash)
-				if err == nil {
-					resultChan <- result{hash: commit.Hash, files: files}
-				} else {
-					resultChan <- result{hash: commit.Hash, files: nil}
-				}
-			}(commits[i])
-		}
-
-		// Collect results
-		fileChanges := make(map[string][]domain.FileChange)
-		for i := 0; i < fileChangeLimit; i++ {
-			r := <-resultChan
-			if r.files != nil {
-				fileChanges[r.hash] = r.files
-			}
-		}
+		fileChanges := git.LoadFileChangesParallel(reader, repoPath, commits[:fileChangeLimit])
 
 		return InsightsLoadedMsg{
 			Commits:     commitPtrs,
@@ -633,6 +918,22 @@ func (m Model) loadInsights() tea.Cmd {
 	}
 }
 
+// loadSearchFiles returns a command that loads file changes needed by file: search terms
+func (m Model) loadSearchFiles(commits []domain.Commit) tea.Cmd {
+	reader := m.reader
+	repoPath := m.repoPath
+	return func() tea.Msg {
+		files := git.LoadFileChangesParallel(reader, repoPath, commits)
+		// Commits that failed to load are cached without files, so the
+		// search does not ask for them again
+		for _, c := range commits {
+			if _, ok := files[c.Hash]; !ok {
+				files[c.Hash] = []domain.FileChange{}
+			}
+		}
+		return SearchFilesLoadedMsg{Files: files}
+	}
+}
 
 func (m Model) View() string {
 	if !m.ready {
@@ -650,6 +951,15 @@ func (m Model) View() string {
 	if m.showTagFilter {
 		return m.filters.TagFilter().View()
 	}
+	if m.showViews {
+		return m.viewPicker.View()
+	}
+	if m.showPalette {
+		return m.palette.View()
+	}
+	if m.showCommand {
+		return m.commandPopup.View()
+	}
 	if m.showHelp {
 		return m.renderHelp()
 	}
@@ -659,6 +969,12 @@ func (m Model) View() string {
 	if m.showDiff {
 		return m.renderWithDiff()
 	}
+	if m.showRangeDiff {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.rangeView.View())
+	}
+	if m.showCompare {
+		return m.renderWithCompare()
+	}
 	return m.renderLayout()
 }
 
@@ -671,6 +987,15 @@ func (m Model) renderWithDiff() string {
 	)
 }
 
+// renderWithCompare shows the compare view as a centered overlay
+func (m Model) renderWithCompare() string {
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		m.compareView.View(),
+	)
+}
+
 // Watching returns whether the watcher is active
 func (m Model) Watching() bool {
 	return m.watching
@@ -766,24 +1091,76 @@ func (m Model) SearchCurrentMatch() int {
 	return m.search.CurrentMatch() + 1
 }
 
+// SearchError returns the syntax error of the query being typed (nil if valid)
+func (m Model) SearchError() error {
+	return m.search.Err()
+}
+
+// SearchLoading returns whether file changes are loading for a file: search
+func (m Model) SearchLoading() bool {
+	return m.searchLoading
+}
+
+// SearchMode returns the current search mode
+func (m Model) SearchMode() search.Mode {
+	return m.search.Mode()
+}
+
 // SearchInputView returns the search input view
 func (m Model) SearchInputView() string {
 	return m.search.InputView()
 }
 
-// executeSearch runs the search and updates the view
-func (m *Model) executeSearch() {
+// executeSearch runs the search and updates the view.
+// Queries with file: terms first load missing file changes asynchronously.
+func (m *Model) executeSearch() tea.Cmd {
 	// Search on currently displayed commits (may be filtered)
 	commits := m.list.Commits()
-	m.search.Execute(commits)
+	if m.search.NeedsFiles() {
+		var missing []domain.Commit
+		for _, c := range commits {
+			if _, ok := m.fileCache[c.Hash]; !ok {
+				missing = append(missing, c)
+			}
+		}
+		if len(missing) > 0 {
+			cmd := m.loadSearchFiles(missing)
+			if !m.insightsLoading && !m.searchLoading {
+				// Animates the footer; a running spinner keeps its own ticks
+				cmd = tea.Batch(cmd, spinnerTick())
+			}
+			m.searchLoading = true
+			return cmd
+		}
+	}
+	m.search.Execute(commits, m.searchFileSource)
 	m.list.SetMatchIndices(m.search.Matches())
+	m.list.SetMatchHighlighter(m.search.Highlight)
 	m.jumpToCurrentMatch()
+	return nil
+}
+
+// searchFileSource provides changed paths from the file cache to file: search terms
+func (m *Model) searchFileSource(hash string) ([]string, bool) {
+	files, ok := m.fileCache[hash]
+	if !ok {
+		return nil, false
+	}
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		if f.OldPath != "" {
+			paths = append(paths, f.OldPath)
+		}
+	}
+	return paths, true
 }
 
 // jumpToCurrentMatch moves cursor to the current search match
 func (m *Model) jumpToCurrentMatch() {
 	idx := m.search.CurrentMatchCommitIndex()
-	if idx >= 0 {
+	if idx >= 0 && idx != m.list.Cursor() {
+		m.recordJump()
 		m.list.SetCursor(idx)
 	}
 }
@@ -828,25 +1205,40 @@ func (m Model) InsightsLoading() bool {
 	return m.insightsLoading
 }
 
-// ApplyInitialFilters applies filters from CLI arguments
-func (m *Model) ApplyInitialFilters(branch, author, tag string) {
+// ApplyInitialFilters applies filters from CLI arguments.
+// A zero since or until leaves that side of the time range open.
+// Malformed patterns are reported as errors.
+func (m *Model) ApplyInitialFilters(branch, author, tag string, since, until time.Time) error {
 	needsApply := false
 
 	// Apply branch filter
 	if branch != "" {
-		m.filters.SetBranchFilter(branch)
+		if _, err := m.filters.SetBranchFilter(branch); err != nil {
+			return err
+		}
 		needsApply = true
 	}
 
 	// Apply author filter
 	if author != "" {
-		m.filters.SetAuthorFilter(author)
+		if _, err := m.filters.SetAuthorFilter(author); err != nil {
+			return err
+		}
 		needsApply = true
 	}
 
 	// Apply tag filter
 	if tag != "" {
-		m.filters.SetTagFilter(tag)
+		if _, err := m.filters.SetTagFilter(tag); err != nil {
+			return err
+		}
+		needsApply = true
+	}
+
+	// Apply time filter and mirror it in the histogram
+	if !since.IsZero() || !until.IsZero() {
+		m.filters.SetTimeRange(since, until)
+		m.histogram.SelectRange(since, until)
 		needsApply = true
 	}
 
@@ -857,53 +1249,23 @@ func (m *Model) ApplyInitialFilters(branch, author, tag string) {
 			m.list.SetFilteredCommits(result.Commits, m.repo)
 		}
 	}
+	return nil
 }
 
-// renderHelp renders the help overlay
-func (m Model) renderHelp() string {
-	help := `Keyboard Shortcuts
-
- Navigation
-   j/↓  k/↑       Move cursor
-   Ctrl+d/u      Page down/up
-   g/G           Jump to first/last
-   Enter         Expand commit
-
- Filters
-   a             Author filter
-   b             Branch filter
-   t             Tag filter
-   A             Author highlight
-   r             Range (histogram)
-   c             Clear all filters
-
- Search
-   /             Start search
-   n/N           Next/prev match
-
- Histogram (when focused)
-   h/l ←/→       Move selection
-   Space         Toggle selection
-   [/]           Set start/end
-   +/-           Zoom in/out
-   Enter         Apply filter
-   Tab           Return to list
-
- General
-   i             Insights view
-   h             This help
-   q             Quit
-
-Press any key to close`
-
-	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
-		Padding(1, 2)
+// SetStdinCommits limits the history to the given commits (show) or keeps
+// all history for context and dims the commits not in the list
+func (m *Model) SetStdinCommits(hashes []string, show bool) {
+	m.stdinCommits = len(hashes)
+	if show {
+		m.filters.SetCommitSet(hashes)
+		result := m.filters.ApplyFilters()
+		m.list.SetFilteredCommits(result.Commits, m.repo)
+		return
+	}
+	m.list.SetHighlightedHashes(hashes)
+}
 
-	return lipgloss.Place(
-		m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		style.Render(help),
-	)
+// StdinCommitCount returns the number of commits read with --stdin
+func (m Model) StdinCommitCount() int {
+	return m.stdinCommits
 }
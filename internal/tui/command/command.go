@@ -0,0 +1,73 @@
+// Package command runs user-defined shell commands from the config file
+// and shows their confirmation prompt and output.
+package command
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/placeholder"
+)
+
+// Values returns the placeholder values for a command run on commit,
+// with path being the selected file ("" outside the expanded view)
+func Values(repo *domain.Repository, root string, commit domain.Commit, path string) map[string]string {
+	return map[string]string{
+		"hash":    commit.Hash,
+		"short":   commit.ShortHash,
+		"subject": commit.Message,
+		"path":    path,
+		"branch":  branchFor(repo, commit),
+		"root":    root,
+	}
+}
+
+// branchFor returns the checked-out branch if it points at commit, else the
+// first branch pointing at commit, else the checked-out branch
+func branchFor(repo *domain.Repository, commit domain.Commit) string {
+	for _, ref := range commit.BranchRefs {
+		if ref == repo.HEAD {
+			return ref
+		}
+	}
+	if len(commit.BranchRefs) > 0 {
+		return commit.BranchRefs[0]
+	}
+	for _, b := range repo.Branches {
+		if b.Name == repo.HEAD {
+			return b.Name
+		}
+	}
+	return ""
+}
+
+// Expand fills tmpl with shell-quoted values
+func Expand(tmpl string, values map[string]string) string {
+	quoted := make(map[string]string, len(values))
+	for name, v := range values {
+		quoted[name] = Quote(v)
+	}
+	return placeholder.Expand(tmpl, quoted)
+}
+
+// Quote quotes s as a single shell word
+func Quote(s string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Shell returns the command running line through the user's shell in dir
+func Shell(line, dir string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", line)
+	} else {
+		cmd = exec.Command("sh", "-c", line)
+	}
+	cmd.Dir = dir
+	return cmd
+}
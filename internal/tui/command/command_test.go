@@ -0,0 +1,51 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+func TestExpandQuotesValues(t *testing.T) {
+	repo := &domain.Repository{HEAD: "main", Branches: []domain.Branch{{Name: "main"}}}
+	commit := domain.Commit{Hash: "abc1234def", ShortHash: "abc1234", Message: "fix: don't $(rm) it"}
+
+	got := Expand("git show {hash} -- {path} # {subject} {unknown}", Values(repo, "/repo", commit, "a b.go"))
+	want := `git show 'abc1234def' -- 'a b.go' # 'fix: don'\''t $(rm) it' {unknown}`
+	if got != want {
+		t.Errorf("Expand =\n%s\nwant\n%s", got, want)
+	}
+
+	out, err := Shell("printf '%s|' "+Expand("{subject} {path}", Values(repo, "/repo", commit, "")), t.TempDir()).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "fix: don't $(rm) it||" {
+		t.Errorf("shell saw %q", out)
+	}
+}
+
+func TestBranchFor(t *testing.T) {
+	repo := &domain.Repository{HEAD: "main", Branches: []domain.Branch{{Name: "main"}, {Name: "feature"}}}
+	tests := []struct {
+		name string
+		refs []string
+		want string
+	}{
+		{"checked-out branch wins", []string{"feature", "main"}, "main"},
+		{"first branch at commit", []string{"feature"}, "feature"},
+		{"falls back to checked-out branch", nil, "main"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := branchFor(repo, domain.Commit{BranchRefs: tc.refs}); got != tc.want {
+				t.Errorf("branchFor = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	detached := &domain.Repository{HEAD: "abc1234", Branches: repo.Branches}
+	if got := branchFor(detached, domain.Commit{}); got != "" {
+		t.Errorf("detached HEAD branch = %q, want empty", got)
+	}
+}
@@ -0,0 +1,172 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// Popup asks before running a command and shows the output of
+// background commands
+type Popup struct {
+	command    config.Command
+	line       string // expanded command line
+	confirming bool
+	lines      []string // output of the finished command
+	err        error
+	offset     int
+	keys       keys.Map
+	width      int
+	height     int
+}
+
+// NewPopup creates an empty popup
+func NewPopup() Popup {
+	return Popup{keys: keys.Default()}
+}
+
+// SetKeyMap sets the key bindings
+func (p *Popup) SetKeyMap(km keys.Map) {
+	p.keys = km
+}
+
+// SetSize sets the overlay area
+func (p *Popup) SetSize(w, h int) {
+	p.width = w
+	p.height = h
+}
+
+// Confirm asks whether to run line for c
+func (p *Popup) Confirm(c config.Command, line string) {
+	*p = Popup{command: c, line: line, confirming: true, keys: p.keys, width: p.width, height: p.height}
+}
+
+// ShowResult shows the output and exit status of c
+func (p *Popup) ShowResult(c config.Command, line, output string, err error) {
+	*p = Popup{command: c, line: line, err: err, keys: p.keys, width: p.width, height: p.height}
+	output = strings.TrimRight(output, "\n")
+	if output != "" {
+		p.lines = strings.Split(output, "\n")
+	}
+}
+
+// Command returns the command and expanded line the popup is about
+func (p Popup) Command() (config.Command, string) {
+	return p.command, p.line
+}
+
+// maxVisibleLines calculates how many output lines fit in the popup
+func (p Popup) maxVisibleLines() int {
+	maxLines := p.height - 12
+	if maxLines < 3 {
+		maxLines = 3
+	}
+	return maxLines
+}
+
+// Update handles input and returns (updated popup, cmd, confirmed, closed)
+func (p Popup) Update(msg tea.Msg) (Popup, tea.Cmd, bool, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil, false, false
+	}
+
+	key := keyMsg.String()
+	if p.confirming {
+		switch {
+		case p.keys.Is(key, keys.ConfirmYes):
+			return p, nil, true, true
+		case p.keys.Is(key, keys.ConfirmNo):
+			return p, nil, false, true
+		}
+		return p, nil, false, false
+	}
+
+	maxOffset := len(p.lines) - p.maxVisibleLines()
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	switch {
+	case p.keys.Is(key, keys.CommandDown):
+		if p.offset < maxOffset {
+			p.offset++
+		}
+	case p.keys.Is(key, keys.CommandUp):
+		if p.offset > 0 {
+			p.offset--
+		}
+	case p.keys.Is(key, keys.CommandTop):
+		p.offset = 0
+	case p.keys.Is(key, keys.CommandBottom):
+		p.offset = maxOffset
+	case p.keys.Is(key, keys.CommandClose):
+		return p, nil, false, true
+	}
+	return p, nil, false, false
+}
+
+func (p Popup) View() string {
+	var lines []string
+	lines = append(lines, TitleStyle.Render(p.command.Title()))
+	lines = append(lines, HintStyle.Render("$ "+p.line))
+	lines = append(lines, "")
+
+	if p.confirming {
+		lines = append(lines, WarnStyle.Render(fmt.Sprintf("Run this command? [%s/%s]",
+			p.keys.Label(keys.ConfirmYes), p.keys.Label(keys.ConfirmNo))))
+		return p.place(lines)
+	}
+
+	if len(p.lines) == 0 {
+		lines = append(lines, HintStyle.Render("(no output)"))
+	}
+	maxVisible := p.maxVisibleLines()
+	end := p.offset + maxVisible
+	if end > len(p.lines) {
+		end = len(p.lines)
+	}
+	if p.offset > 0 {
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("↑ %d more", p.offset)))
+	}
+	lines = append(lines, p.lines[p.offset:end]...)
+	if end < len(p.lines) {
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("↓ %d more", len(p.lines)-end)))
+	}
+
+	lines = append(lines, "")
+	if p.err != nil {
+		lines = append(lines, ErrorStyle.Render(exitStatus(p.err)))
+	}
+	lines = append(lines, HintStyle.Render(fmt.Sprintf("[%s/%s] Scroll  [%s] Close",
+		p.keys.Label(keys.CommandDown), p.keys.Label(keys.CommandUp), p.keys.Label(keys.CommandClose))))
+	return p.place(lines)
+}
+
+// place centers the popup content in the overlay area
+func (p Popup) place(lines []string) string {
+	innerWidth := p.width - 6
+	if innerWidth < 30 {
+		innerWidth = 30
+	}
+	content := strings.Join(lines, "\n")
+	return lipgloss.Place(
+		p.width, p.height,
+		lipgloss.Center, lipgloss.Center,
+		PopupStyle.Width(innerWidth).Render(content),
+	)
+}
+
+// exitStatus describes why a command failed
+func exitStatus(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Sprintf("exit status %d", exitErr.ExitCode())
+	}
+	return err.Error()
+}
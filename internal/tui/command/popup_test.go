@@ -0,0 +1,34 @@
+package command
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+func TestPopupKeyMap(t *testing.T) {
+	km := keys.Default()
+	if err := km.Apply(map[string]map[string][]string{"confirm": {"yes": {"o"}}}); err != nil {
+		t.Fatal(err)
+	}
+	p := NewPopup()
+	p.SetKeyMap(km)
+
+	tests := []struct {
+		key               tea.KeyMsg
+		confirmed, closed bool
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, false, false}, // unbound now
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")}, true, true},
+		{tea.KeyMsg{Type: tea.KeyEsc}, false, true},
+	}
+	for _, tc := range tests {
+		p.Confirm(config.Command{Run: "true"}, "true")
+		_, _, confirmed, closed := p.Update(tc.key)
+		if confirmed != tc.confirmed || closed != tc.closed {
+			t.Errorf("%s: confirmed, closed = %v, %v; want %v, %v", tc.key, confirmed, closed, tc.confirmed, tc.closed)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package command
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
+
+var (
+	PopupStyle lipgloss.Style
+	TitleStyle lipgloss.Style
+	HintStyle  lipgloss.Style
+	WarnStyle  lipgloss.Style
+	ErrorStyle lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	PopupStyle = t.Overlay()
+
+	TitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Accent)
+
+	HintStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	WarnStyle = lipgloss.NewStyle().
+		Foreground(t.Warning)
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(t.Error)
+}
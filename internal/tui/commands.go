@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/tui/command"
+)
+
+// handleCommandKey runs the user command bound to key, asking first if the
+// command wants confirmation. Returns false if no command is bound to key.
+func (m *Model) handleCommandKey(key string) (bool, tea.Cmd) {
+	for _, c := range m.commands {
+		if c.Key != key {
+			continue
+		}
+		commit := m.list.SelectedCommit()
+		if commit == nil {
+			return true, nil
+		}
+		path := ""
+		if m.list.IsExpanded() {
+			if f := m.list.SelectedFile(); f != nil {
+				path = f.Path
+			}
+		}
+		line := command.Expand(c.Run, command.Values(m.repo, m.repoPath, *commit, path))
+		if c.Confirm {
+			m.commandPopup.Confirm(c, line)
+			m.showCommand = true
+			return true, nil
+		}
+		return true, m.runCommand(c, line)
+	}
+	return false, nil
+}
+
+// runCommand runs line in the repository root, either handing over the
+// terminal or in the background
+func (m Model) runCommand(c config.Command, line string) tea.Cmd {
+	cmd := command.Shell(line, m.repoPath)
+	if c.Background() {
+		return func() tea.Msg {
+			out, err := cmd.CombinedOutput()
+			return CommandFinishedMsg{Command: c, Line: line, Output: string(out), Err: err}
+		}
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return CommandFinishedMsg{Command: c, Line: line, Err: err}
+	})
+}
+
+// commandHelp lists the user commands for the help overlay
+func (m Model) commandHelp() []string {
+	if len(m.commands) == 0 {
+		return nil
+	}
+	lines := []string{" Commands (config)"}
+	for _, c := range m.commands {
+		lines = append(lines, fmt.Sprintf("   %-13s %s", c.Key, c.Title()))
+	}
+	return lines
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/graph"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// compareWithBase makes the selected commit the compare base, or compares
+// it with the base set before. Pressed on the base itself, it clears it.
+func (m *Model) compareWithBase() tea.Cmd {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return nil
+	}
+	switch m.compareBase {
+	case "":
+		m.compareBase = commit.Hash
+		return m.setFlash("compare base "+commit.ShortHash+": press "+m.keys.Label(keys.Compare)+" on another commit", false)
+	case commit.Hash:
+		m.compareBase = ""
+		return m.setFlash("compare base cleared", false)
+	}
+
+	i := slices.IndexFunc(m.repo.Commits, func(c domain.Commit) bool { return c.Hash == m.compareBase })
+	if i < 0 {
+		m.compareBase = ""
+		return m.setFlash("compare base is no longer in the history", true)
+	}
+	return m.openCompare(m.repo.Commits[i], *commit)
+}
+
+// openCompare shows the files differing between two commits
+func (m *Model) openCompare(from, to domain.Commit) tea.Cmd {
+	m.compareView.Show(from, to, graph.Range(m.repo.Commits, from.Hash, to.Hash))
+	m.compareView.SetSize(m.width, m.height)
+	m.showCompare = true
+	return m.loadCompareFiles()
+}
+
+// closeCompare hides the comparison; the base stays for the next one
+func (m *Model) closeCompare() {
+	m.compareView.Hide()
+	m.showCompare = false
+}
+
+// loadCompareFiles returns a command loading the tree diff of the comparison
+func (m Model) loadCompareFiles() tea.Cmd {
+	reader := m.reader
+	repoPath := m.repoPath
+	from := m.compareView.From().Hash
+	to := m.compareView.To().Hash
+	return func() tea.Msg {
+		files, err := reader.LoadTreeDiff(repoPath, from, to)
+		return CompareLoadedMsg{From: from, To: to, Files: files, Err: err}
+	}
+}
+
+// handleCompareLoaded shows the files of a comparison still open
+func (m *Model) handleCompareLoaded(msg CompareLoadedMsg) {
+	if !m.showCompare || m.compareView.From().Hash != msg.From || m.compareView.To().Hash != msg.To {
+		return
+	}
+	m.compareView.SetFiles(msg.Files, msg.Err)
+}
+
+// handleCompareKey handles a key in the compare view
+func (m *Model) handleCompareKey(msg tea.KeyMsg) tea.Cmd {
+	switch key := msg.String(); {
+	case m.keys.Is(key, keys.CompareClose):
+		m.closeCompare()
+		return nil
+	case m.keys.Is(key, keys.CompareOpenDiff):
+		files := m.compareView.Files()
+		if m.compareView.IsLoading() || len(files) == 0 {
+			return nil
+		}
+		m.diffView.Show(files, m.compareView.FileCursor())
+		m.diffView.SetSize(m.width, m.height)
+		m.showDiff = true
+		return m.loadFileDiff()
+	case m.keys.Is(key, keys.CompareSwap):
+		return m.openCompare(m.compareView.To(), m.compareView.From())
+	}
+	m.compareView, _ = m.compareView.Update(msg)
+	return nil
+}
+
+// CompareBase returns the short hash of the compare base ("" if none)
+func (m Model) CompareBase() string {
+	return shortHash(m.compareBase)
+}
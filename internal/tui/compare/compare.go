@@ -0,0 +1,341 @@
+// Package compare shows the tree diff between two arbitrary commits: the
+// changed files with their stats and, optionally, the commits in between.
+package compare
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+// minCommitsWidth is the terminal width from which the commits in the
+// range are shown beside the files instead of below them
+const minCommitsWidth = 110
+
+// View compares the trees of two commits
+type View struct {
+	visible     bool
+	loading     bool
+	err         string
+	from        domain.Commit
+	to          domain.Commit
+	files       []domain.FileChange
+	commits     []domain.Commit // from..to, newest first
+	cursor      int
+	showCommits bool
+	width       int
+	height      int
+	status      string // transient message shown instead of the key hints
+	keys        keys.Map
+}
+
+// New creates a hidden compare view
+func New() View {
+	return View{keys: keys.Default(), showCommits: true}
+}
+
+// SetKeyMap sets the key bindings
+func (v *View) SetKeyMap(km keys.Map) {
+	v.keys = km
+}
+
+// SetSize sets the view dimensions
+func (v *View) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+}
+
+// Show opens the comparison of from with to while its files load.
+// commits are the commits in from..to.
+func (v *View) Show(from, to domain.Commit, commits []domain.Commit) {
+	v.visible = true
+	v.loading = true
+	v.err = ""
+	v.from = from
+	v.to = to
+	v.commits = commits
+	v.files = nil
+	v.cursor = 0
+}
+
+// SetFiles sets the loaded files, or the error loading them
+func (v *View) SetFiles(files []domain.FileChange, err error) {
+	v.loading = false
+	v.files = files
+	if err != nil {
+		v.err = err.Error()
+	}
+	v.cursor = min(v.cursor, max(0, len(files)-1))
+}
+
+// Hide closes the view
+func (v *View) Hide() {
+	v.visible = false
+	v.files = nil
+	v.commits = nil
+}
+
+// IsVisible returns whether the view is shown
+func (v View) IsVisible() bool {
+	return v.visible
+}
+
+// IsLoading returns whether the files are being loaded
+func (v View) IsLoading() bool {
+	return v.loading
+}
+
+// From returns the base of the comparison
+func (v View) From() domain.Commit {
+	return v.from
+}
+
+// To returns the commit compared with the base
+func (v View) To() domain.Commit {
+	return v.to
+}
+
+// Files returns the files differing between the two commits
+func (v View) Files() []domain.FileChange {
+	return v.files
+}
+
+// FileCursor returns the index of the selected file
+func (v View) FileCursor() int {
+	return v.cursor
+}
+
+// SetFileCursor selects the file at index i, e.g. the one last shown in
+// the diff view
+func (v *View) SetFileCursor(i int) {
+	if i >= 0 && i < len(v.files) {
+		v.cursor = i
+	}
+}
+
+// SetStatus shows a message in place of the key hints ("" restores them)
+func (v *View) SetStatus(status string) {
+	v.status = status
+}
+
+// Update handles the keys moving the cursor and toggling the commits
+func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !v.visible || !ok {
+		return v, nil
+	}
+
+	switch key := keyMsg.String(); {
+	case v.keys.Is(key, keys.CompareDown):
+		if v.cursor < len(v.files)-1 {
+			v.cursor++
+		}
+	case v.keys.Is(key, keys.CompareUp):
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case v.keys.Is(key, keys.CompareTop):
+		v.cursor = 0
+	case v.keys.Is(key, keys.CompareBottom):
+		v.cursor = max(0, len(v.files)-1)
+	case v.keys.Is(key, keys.CompareCommits):
+		v.showCommits = !v.showCommits
+	}
+	return v, nil
+}
+
+func (v View) contentWidth() int {
+	// Account for border and padding
+	return max(20, v.width-8)
+}
+
+func (v View) contentHeight() int {
+	// Account for border, header (2), separators and footer
+	return max(5, v.height-9)
+}
+
+// View renders the comparison
+func (v View) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	width := v.contentWidth()
+	height := v.contentHeight()
+
+	var body []string
+	switch {
+	case v.loading:
+		body = []string{InfoStyle.Render("Loading files...")}
+	case v.err != "":
+		body = []string{ErrorStyle.Render(v.err)}
+	case !v.showCommits:
+		body = v.renderFiles(width, height)
+	case v.width >= minCommitsWidth:
+		commitsWidth := width / 3
+		filesWidth := width - commitsWidth - 3
+		files := v.renderFiles(filesWidth, height)
+		commits := v.renderCommits(commitsWidth, height)
+		for i := 0; i < max(len(files), len(commits)); i++ {
+			var left, right string
+			if i < len(files) {
+				left = files[i]
+			}
+			if i < len(commits) {
+				right = commits[i]
+			}
+			body = append(body, text.PadRightAnsi(left, filesWidth)+SeparatorStyle.Render(" │ ")+right)
+		}
+	default:
+		// Narrow: the commits take up to a third of the height below
+		commitsHeight := min(len(v.commits)+1, height/3)
+		body = v.renderFiles(width, height-commitsHeight-1)
+		body = append(body, "")
+		body = append(body, v.renderCommits(width, commitsHeight)...)
+	}
+	for len(body) < height {
+		body = append(body, "")
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		v.renderHeader(width),
+		v.renderSides(width),
+		strings.Repeat("─", width),
+		strings.Join(body[:height], "\n"),
+		strings.Repeat("─", width),
+		v.renderFooter(),
+	)
+
+	// The width of the container includes its padding
+	return ContainerStyle.
+		Width(width + 2).
+		Height(v.height - 4).
+		Render(content)
+}
+
+// renderHeader renders "Compare A..B" left and the aggregate stats right
+func (v View) renderHeader(width int) string {
+	left := TitleStyle.Render(fmt.Sprintf("Compare %s..%s", v.from.ShortHash, v.to.ShortHash))
+
+	var right string
+	if !v.loading && v.err == "" {
+		stats := text.FileStats{}
+		for _, f := range v.files {
+			stats.Additions += f.Additions
+			stats.Deletions += f.Deletions
+		}
+		noun := "files"
+		if len(v.files) == 1 {
+			noun = "file"
+		}
+		right = fmt.Sprintf("%s  %s", LabelStyle.Render(fmt.Sprintf("%d %s", len(v.files), noun)), stats.Render())
+	}
+
+	spacing := max(1, width-lipgloss.Width(left)-lipgloss.Width(right))
+	return left + strings.Repeat(" ", spacing) + right
+}
+
+// renderSides renders the subjects of both commits
+func (v View) renderSides(width int) string {
+	half := (width - 4) / 2
+	side := func(label string, c domain.Commit) string {
+		line := LabelStyle.Render(label) + " " + HashStyle.Render(c.ShortHash) + " " + c.Message
+		return text.PadRightAnsi(text.TruncateAnsi(line, half), half)
+	}
+	return side("from", v.from) + LabelStyle.Render("  →  ") + side("to", v.to)
+}
+
+// renderFiles renders the file list with the cursor kept in view
+func (v View) renderFiles(width, height int) []string {
+	if len(v.files) == 0 {
+		return []string{InfoStyle.Render("No differences")}
+	}
+
+	visible := max(1, height)
+	if len(v.files) > visible && visible > 1 {
+		// Last line shows the position
+		visible--
+	}
+	offset := max(0, v.cursor-visible+1)
+	end := min(offset+visible, len(v.files))
+
+	var lines []string
+	for i := offset; i < end; i++ {
+		lines = append(lines, v.renderFile(v.files[i], i == v.cursor, width))
+	}
+	if len(v.files) > visible {
+		lines = append(lines, LabelStyle.Render(fmt.Sprintf("  (%d/%d)", v.cursor+1, len(v.files))))
+	}
+	return lines
+}
+
+// renderFile renders one file: cursor, status, path and stats
+func (v View) renderFile(f domain.FileChange, selected bool, width int) string {
+	var status string
+	switch f.Status {
+	case domain.FileAdded:
+		status = FileAddedStyle.Render("A")
+	case domain.FileModified:
+		status = FileModifiedStyle.Render("M")
+	case domain.FileDeleted:
+		status = FileDeletedStyle.Render("D")
+	case domain.FileRenamed:
+		status = FileRenamedStyle.Render("R")
+	default:
+		status = " "
+	}
+
+	cursor := "  "
+	if selected {
+		cursor = "> "
+	}
+
+	path := f.Path
+	if f.Status == domain.FileRenamed && f.OldPath != "" {
+		path = f.OldPath + " → " + f.Path
+	}
+	stats := text.FileStats{Additions: f.Additions, Deletions: f.Deletions}.Render()
+	pathWidth := max(10, width-4-lipgloss.Width(stats)-1)
+	line := cursor + status + " " + text.Fit(path, pathWidth) + " " + stats
+	if selected {
+		line = SelectedStyle.Render(text.Strip(line))
+	}
+	return text.TruncateAnsi(line, width)
+}
+
+// renderCommits renders the commits in from..to
+func (v View) renderCommits(width, height int) []string {
+	if height <= 0 {
+		return nil
+	}
+	title := fmt.Sprintf("Commits (%d)", len(v.commits))
+	if len(v.commits) == 0 {
+		title = "No commits in " + v.from.ShortHash + ".." + v.to.ShortHash
+	}
+	lines := []string{LabelStyle.Render(text.Truncate(title, width))}
+	for i, c := range v.commits {
+		if len(lines) == height-1 && i < len(v.commits)-1 {
+			lines = append(lines, LabelStyle.Render(fmt.Sprintf("  … %d more", len(v.commits)-i)))
+			break
+		}
+		line := HashStyle.Render(c.ShortHash) + " " + c.Message
+		lines = append(lines, text.TruncateAnsi(line, width))
+	}
+	return lines
+}
+
+func (v View) renderFooter() string {
+	if v.status != "" {
+		return StatusStyle.Render(v.status)
+	}
+	k := v.keys.Label
+	return FooterStyle.Render(fmt.Sprintf("[%s/%s] file  [%s] diff  [%s] commits  [%s] swap  [%s] back",
+		k(keys.CompareDown), k(keys.CompareUp), k(keys.CompareOpenDiff), k(keys.CompareCommits),
+		k(keys.CompareSwap), k(keys.CompareClose)))
+}
@@ -0,0 +1,78 @@
+package compare
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
+
+var (
+	ContainerStyle lipgloss.Style
+	TitleStyle     lipgloss.Style
+	LabelStyle     lipgloss.Style
+	HashStyle      lipgloss.Style
+	SelectedStyle  lipgloss.Style
+	SeparatorStyle lipgloss.Style
+
+	// File status indicators
+	FileAddedStyle    lipgloss.Style
+	FileModifiedStyle lipgloss.Style
+	FileDeletedStyle  lipgloss.Style
+	FileRenamedStyle  lipgloss.Style
+
+	FooterStyle lipgloss.Style
+	StatusStyle lipgloss.Style // transient message in the footer
+	InfoStyle   lipgloss.Style
+	ErrorStyle  lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	ContainerStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+
+	TitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Accent)
+
+	LabelStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	HashStyle = lipgloss.NewStyle().
+		Foreground(t.Hash)
+
+	SelectedStyle = t.Selected()
+
+	SeparatorStyle = lipgloss.NewStyle().
+		Foreground(t.Separator)
+
+	FileAddedStyle = lipgloss.NewStyle().
+		Foreground(t.Added)
+
+	FileModifiedStyle = lipgloss.NewStyle().
+		Foreground(t.Modified)
+
+	FileDeletedStyle = lipgloss.NewStyle().
+		Foreground(t.Deleted)
+
+	FileRenamedStyle = lipgloss.NewStyle().
+		Foreground(t.Renamed)
+
+	FooterStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	StatusStyle = lipgloss.NewStyle().
+		Foreground(t.Success)
+
+	InfoStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Italic(true)
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(t.Error)
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/tui/diff"
+	"github.com/nogo/gitree/internal/tui/insights"
+	"github.com/nogo/gitree/internal/tui/keys"
+	"github.com/nogo/gitree/internal/tui/search"
+)
+
+// ApplyConfig sets up key bindings, user commands and default toggles from
+// the config file. The theme is applied with SetTheme before the model is
+// created, since renderers pick their colors when they are built.
+func (m *Model) ApplyConfig(cfg config.Config) error {
+	km := keys.Default()
+	overrides := make(map[string]map[string][]string, len(cfg.Keys))
+	for mode, actions := range cfg.Keys {
+		overrides[mode] = make(map[string][]string, len(actions))
+		for action, list := range actions {
+			overrides[mode][action] = list
+		}
+	}
+	if err := km.Apply(overrides); err != nil {
+		return err
+	}
+	if err := km.Check(); err != nil {
+		return err
+	}
+	m.setKeyMap(km)
+	m.commands = cfg.Commands
+	m.keyWarnings = append(km.Shadowed(), m.shadowedCommands()...)
+	if len(m.keyWarnings) > 0 {
+		m.initCmd = tea.Batch(m.initCmd, m.setFlash(fmt.Sprintf("%d key binding conflicts, listed on stderr", len(m.keyWarnings)), true))
+	}
+
+	d := cfg.Defaults
+	if d.Histogram != nil {
+		m.histogram.SetVisible(*d.Histogram)
+	}
+	if d.HistogramHeight != nil {
+		m.histogram.SetBarHeight(*d.HistogramHeight)
+	}
+	if d.SearchMode != "" {
+		mode, ok := search.ParseMode(d.SearchMode)
+		if !ok {
+			return fmt.Errorf("defaults.search_mode: invalid mode %q", d.SearchMode)
+		}
+		m.search.SetMode(mode)
+	}
+	if d.DiffLayout != "" {
+		layout, ok := diff.ParseLayout(d.DiffLayout)
+		if !ok {
+			return fmt.Errorf("defaults.diff_layout: invalid layout %q", d.DiffLayout)
+		}
+		m.diffView.SetLayout(layout)
+	}
+	if d.WordDiff != "" {
+		mode, ok := diff.ParseWordDiff(d.WordDiff)
+		if !ok {
+			return fmt.Errorf("defaults.word_diff: invalid mode %q", d.WordDiff)
+		}
+		m.diffView.SetWordDiff(mode)
+	}
+	if d.Syntax != nil {
+		m.diffView.SetSyntax(*d.Syntax)
+	}
+	if d.WeekStart == "sunday" {
+		m.insights.SetWeekStart(insights.WeekStartSunday)
+	}
+	if d.Insights != nil && *d.Insights {
+		// Loaded in Init, once filters from the command line are applied
+		m.showInsights = true
+		m.insightsLoading = true
+	}
+	return nil
+}
+
+// setKeyMap hands the bindings to every part of the UI with its own keys
+func (m *Model) setKeyMap(km keys.Map) {
+	m.keys = km
+	m.list.SetKeyMap(km)
+	m.diffView.SetKeyMap(km)
+	m.compareView.SetKeyMap(km)
+	m.rangeView.SetKeyMap(km)
+	m.histogram.SetKeyMap(km)
+	m.filters.SetKeyMap(km)
+	m.viewPicker.SetKeyMap(km)
+	m.palette.SetKeyMap(km)
+	m.commandPopup.SetKeyMap(km)
+}
+
+// shadowedCommands reports user commands bound to keys gitree uses, in
+// the modes where commands run
+func (m Model) shadowedCommands() []string {
+	var warnings []string
+	for _, c := range m.commands {
+		for _, mode := range []keys.Mode{keys.ModeList, keys.ModeExpanded} {
+			if action := m.keys.Lookup(mode, c.Key); action != "" {
+				warnings = append(warnings, fmt.Sprintf("command %q on %q is shadowed by %s", c.Title(), c.Key, action))
+			}
+		}
+	}
+	return warnings
+}
+
+// KeyWarnings returns the bindings that can never fire, for reporting on
+// startup
+func (m Model) KeyWarnings() []string {
+	return m.keyWarnings
+}
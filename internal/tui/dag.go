@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/tui/graph"
+	"github.com/nogo/gitree/internal/tui/palette"
+)
+
+// commitItem prefixes palette items that stand for a commit
+const commitItem = "commit:"
+
+// parentCycle remembers the merge P went to a parent of, so the next P
+// goes to its next parent
+type parentCycle struct {
+	merge string
+	index int
+}
+
+// visitCommit moves the cursor to a shown commit as a jump
+func (m *Model) visitCommit(hash string) tea.Cmd {
+	i := m.indexOf(hash)
+	if i < 0 {
+		return m.setFlash("commit "+shortHash(hash)+" is hidden by filters", true)
+	}
+	m.recordJump()
+	m.list.SetCursor(i)
+	return nil
+}
+
+// gotoParent moves to the first parent of the selected commit
+func (m *Model) gotoParent() tea.Cmd {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return nil
+	}
+	m.parentCycle = parentCycle{}
+	if len(commit.Parents) == 0 {
+		return m.setFlash("root commit has no parent", true)
+	}
+	return m.visitCommit(commit.Parents[0])
+}
+
+// gotoOtherParent moves to the second parent of a merge, and on each
+// further press to the next parent of the same merge
+func (m *Model) gotoOtherParent() tea.Cmd {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return nil
+	}
+	cyc := m.parentCycle
+	if merge := m.list.Node(cyc.merge); merge != nil && merge.Parents[cyc.index] == commit.Hash {
+		cyc.index = (cyc.index + 1) % len(merge.Parents)
+	} else if len(commit.Parents) > 1 {
+		cyc = parentCycle{merge: commit.Hash, index: 1}
+	} else {
+		return m.setFlash("not a merge commit", true)
+	}
+	merge := m.list.Node(cyc.merge)
+	m.parentCycle = cyc
+	return m.visitCommit(merge.Parents[cyc.index])
+}
+
+// gotoChild moves to the child of the selected commit, or lets the user
+// pick one if there are several
+func (m *Model) gotoChild() tea.Cmd {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return nil
+	}
+	node := m.list.Node(commit.Hash)
+	switch {
+	case node == nil || len(node.Children) == 0:
+		return m.setFlash("no child shown", true)
+	case len(node.Children) == 1:
+		return m.visitCommit(node.Children[0])
+	}
+
+	commits := m.list.Commits()
+	items := make([]palette.Item, 0, len(node.Children))
+	for _, hash := range node.Children {
+		if i := m.indexOf(hash); i >= 0 {
+			items = append(items, palette.Item{ID: commitItem + hash, Title: commits[i].Message, Keys: commits[i].ShortHash})
+		}
+	}
+	m.palette.SetSize(m.width, m.height)
+	m.showPalette = true
+	return m.palette.OpenPicker(fmt.Sprintf("Children of %s", commit.ShortHash), items)
+}
+
+// openMergeInto opens the palette with the merge command and the checked
+// out branch typed
+func (m *Model) openMergeInto() tea.Cmd {
+	cmd := m.openPalette()
+	branch := ""
+	if m.branchTip(m.repo.HEAD) != "" {
+		branch = m.repo.HEAD
+	}
+	m.palette.SetInput(cmdMerge + " " + branch)
+	return cmd
+}
+
+// gotoMergeInto moves to the merge that brought the selected commit into
+// a branch
+func (m *Model) gotoMergeInto(branch string) (tea.Cmd, error) {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return nil, nil
+	}
+	tip := m.branchTip(branch)
+	if tip == "" {
+		return nil, fmt.Errorf("unknown branch %q", branch)
+	}
+	merge, direct, ok := graph.MergeInto(m.repo.Commits, commit.Hash, tip)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%s is not on %s", commit.ShortHash, branch)
+	case direct:
+		return m.setFlash(fmt.Sprintf("%s was committed on %s, not merged", commit.ShortHash, branch), false), nil
+	}
+	return m.visitCommit(merge), nil
+}
+
+// branchTip returns the commit a branch points to ("" if unknown)
+func (m Model) branchTip(name string) string {
+	for _, b := range m.repo.Branches {
+		if b.Name == name {
+			return b.HeadHash
+		}
+	}
+	return ""
+}
+
+// pickedCommit returns the commit of a palette item from a commit picker
+func pickedCommit(id string) (string, bool) {
+	return strings.CutPrefix(id, commitItem)
+}
@@ -1,51 +1,73 @@
 package detail
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
 
 var (
-	DetailStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(1, 2)
+	DetailStyle       lipgloss.Style
+	HeaderStyle       lipgloss.Style
+	LabelStyle        lipgloss.Style
+	MessageStyle      lipgloss.Style
+	ParentStyle       lipgloss.Style
+	FileSectionStyle  lipgloss.Style
+	FileAddedStyle    lipgloss.Style
+	FileModifiedStyle lipgloss.Style
+	FileDeletedStyle  lipgloss.Style
+	FileRenamedStyle  lipgloss.Style
+	AdditionsStyle    lipgloss.Style
+	DeletionsStyle    lipgloss.Style
+	FilePathStyle     lipgloss.Style
+	SelectedFileStyle lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	DetailStyle = t.Overlay()
 
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205"))
+		Bold(true).
+		Foreground(t.Accent)
 
 	LabelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+		Foreground(t.Muted)
 
 	MessageStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
+		Foreground(t.Text)
 
 	ParentStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214"))
+		Foreground(t.Hash)
 
 	FileSectionStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("252")).
-				Bold(true)
+		Foreground(t.Text).
+		Bold(true)
 
 	FileAddedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("34")) // Green
+		Foreground(t.Added)
 
 	FileModifiedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("214")) // Yellow
+		Foreground(t.Modified)
 
 	FileDeletedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("196")) // Red
+		Foreground(t.Deleted)
 
 	FileRenamedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("51")) // Cyan
+		Foreground(t.Renamed)
 
 	AdditionsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("34")) // Green
+		Foreground(t.Added)
 
 	DeletionsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")) // Red
+		Foreground(t.Deleted)
 
 	FilePathStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
+		Foreground(t.Text)
 
 	SelectedFileStyle = lipgloss.NewStyle().
-				Bold(true)
-)
+		Bold(true)
+}
@@ -2,12 +2,16 @@ package diff
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
+	"github.com/nogo/gitree/internal/tui/text"
 )
 
 // DiffView displays the diff for a file
@@ -25,11 +29,76 @@ type DiffView struct {
 	width      int
 	height     int
 	isBinary   bool
+	patch      bool   // a patch given whole, not a file of the commit
+	layout     Layout // as configured, or as toggled last
+	wordDiff   WordDiff
+	options    domain.DiffOptions // what diffs are requested with
+	keepLine   int                // new file line to show at the top once reloaded, 0 if none
+	noSyntax   bool               // syntax highlighting turned off
+	lines      []string           // the diff by line
+	syntax     *highlighter       // nil when the file type is not known
+	split      bool               // the viewport holds the split layout
+	rows       []splitRow         // the diff paired for the split layout
+	rowOf      []int              // split row of each diff line
+	geometry   splitGeometry      // columns of the split layout
+	rowStarts  []int              // first viewport line of each shown split row
+	cache      *renderCache       // lines rendered for the current layout and width
+	hunks      []section          // hunks of the patch, found when it loads
+	patchFiles []section          // files of the patch, found when it loads
+	folded     map[int]int        // end of each folded hunk or file, by header line
+	shown      []int              // patch lines not hidden by a fold
+	shownRows  []int              // split rows not hidden by a fold
+	search     diffSearch         // the query and its matches
+	pending    string             // first key of a key sequence
+	status     string             // transient message shown instead of the key hints
+	keys       keys.Map
 }
 
 // New creates a new DiffView
 func New() DiffView {
-	return DiffView{}
+	return DiffView{keys: keys.Default(), options: domain.DefaultDiffOptions(), search: newDiffSearch()}
+}
+
+// SetKeyMap sets the key bindings
+func (d *DiffView) SetKeyMap(km keys.Map) {
+	d.keys = km
+}
+
+// SetLayout sets how diffs are laid out
+func (d *DiffView) SetLayout(l Layout) {
+	d.layout = l
+	d.refresh()
+}
+
+// SetWordDiff sets how paired lines are compared to emphasize their
+// changed words
+func (d *DiffView) SetWordDiff(mode WordDiff) {
+	d.wordDiff = mode
+	d.refresh()
+}
+
+// SetSyntax turns syntax highlighting of the code in diffs on or off
+func (d *DiffView) SetSyntax(on bool) {
+	d.noSyntax = !on
+	if !d.loading && d.diff != "" {
+		d.syntax = d.newHighlighter()
+		d.refresh()
+	}
+}
+
+// Split returns whether the diff is shown side by side
+func (d DiffView) Split() bool {
+	return d.layout == LayoutSplit || d.layout == LayoutAuto && d.width >= autoSplitWidth
+}
+
+// ToggleLayout switches between the unified and split layout, keeping the
+// line at the top of the view
+func (d *DiffView) ToggleLayout() {
+	if d.Split() {
+		d.SetLayout(LayoutUnified)
+	} else {
+		d.SetLayout(LayoutSplit)
+	}
 }
 
 // Show displays the diff view for a file
@@ -46,6 +115,24 @@ func (d *DiffView) Show(files []domain.FileChange, fileIndex int) {
 	}
 	d.diff = ""
 	d.isBinary = false
+	d.patch = false
+	d.keepLine = 0
+}
+
+// ShowPatch displays a diff that is not a file of the commit, e.g. the
+// interdiff of a range-diff, under title
+func (d *DiffView) ShowPatch(title, patch string) {
+	d.Show([]domain.FileChange{{Path: title}}, 0)
+	d.patch = true
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			d.additions++
+		case strings.HasPrefix(line, "-"):
+			d.deletions++
+		}
+	}
+	d.SetDiff(patch, false)
 }
 
 // SetDiff sets the loaded diff content
@@ -56,7 +143,63 @@ func (d *DiffView) SetDiff(diff string, isBinary bool) {
 
 	// Initialize viewport with rendered diff
 	d.viewport = viewport.New(d.contentWidth(), d.contentHeight())
-	d.viewport.SetContent(renderDiff(diff))
+	d.lines = strings.Split(diff, "\n")
+	d.rows, d.rowOf = splitRows(diff)
+	d.hunks, d.patchFiles = indexSections(d.lines)
+	d.folded = make(map[int]int)
+	d.syntax = d.newHighlighter()
+	d.findMatches()
+	d.render()
+	if d.keepLine > 0 {
+		d.scrollToNewLine(d.keepLine)
+		d.keepLine = 0
+	}
+}
+
+// newHighlighter returns the highlighter of the diff, if it is on and knows
+// the file type
+func (d DiffView) newHighlighter() *highlighter {
+	if d.noSyntax || d.patch {
+		return nil
+	}
+	return newHighlighter(d.filePath, d.lines)
+}
+
+// render lays out the diff in the current layout and folds. The viewport
+// only scrolls: it holds as many empty lines as the layout has, and View
+// renders the lines in view.
+func (d *DiffView) render() {
+	d.split = d.Split() && d.diff != ""
+	d.cache = newRenderCache()
+	d.layoutFolds()
+	total := len(d.shown)
+	if d.split {
+		d.geometry = newSplitGeometry(d.rows, d.viewport.Width)
+		d.rowStarts, total = d.geometry.rowStarts(d.rows, d.shownRows)
+	} else {
+		d.rowStarts = nil
+	}
+	d.viewport.SetContent(strings.Repeat("\n", max(0, total-1)))
+}
+
+// refresh renders the diff again after a change of layout or width,
+// keeping the diff line at the top of the view
+func (d *DiffView) refresh() {
+	if d.loading || d.diff == "" {
+		return
+	}
+	d.relayout(d.topLine())
+}
+
+// topLine returns the diff line at the top of the view; in the split
+// layout, the first line of the row there
+func (d DiffView) topLine() int {
+	offset := d.viewport.YOffset
+	if !d.split {
+		return d.shown[min(offset, len(d.shown)-1)]
+	}
+	k, _ := slices.BinarySearch(d.rowStarts, offset+1)
+	return d.rows[d.shownRows[max(0, k-1)]].line
 }
 
 // Hide hides the diff view
@@ -65,6 +208,8 @@ func (d *DiffView) Hide() {
 	d.loading = false
 	d.diff = ""
 	d.files = nil
+	d.pending = ""
+	d.clearSearch()
 }
 
 // IsVisible returns whether the diff view is visible
@@ -81,13 +226,18 @@ func (d DiffView) IsLoading() bool {
 func (d *DiffView) SetSize(w, h int) {
 	d.width = w
 	d.height = h
+	resized := d.viewport.Width != d.contentWidth()
 	d.viewport.Width = d.contentWidth()
 	d.viewport.Height = d.contentHeight()
+	if resized {
+		// Split lines wrap at the new width, and LayoutAuto may switch
+		d.refresh()
+	}
 }
 
 func (d DiffView) contentWidth() int {
 	// Account for border and padding
-	w := d.width - 6
+	w := d.width - 8
 	if w < 20 {
 		w = 20
 	}
@@ -108,6 +258,16 @@ func (d DiffView) CurrentFile() string {
 	return d.filePath
 }
 
+// Diff returns the raw diff of the current file ("" while loading)
+func (d DiffView) Diff() string {
+	return d.diff
+}
+
+// SetStatus shows a message in place of the key hints ("" restores them)
+func (d *DiffView) SetStatus(status string) {
+	d.status = status
+}
+
 // FileIndex returns the current file index
 func (d DiffView) FileIndex() int {
 	return d.fileIndex
@@ -141,6 +301,7 @@ func (d *DiffView) updateCurrentFile() {
 		d.loading = true
 		d.diff = ""
 		d.isBinary = false
+		d.keepLine = 0
 	}
 }
 
@@ -152,20 +313,51 @@ func (d DiffView) Update(msg tea.Msg) (DiffView, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "j", "down":
+		if d.search.typing {
+			return d, d.updateSearch(msg)
+		}
+		key := msg.String()
+		if d.pending != "" {
+			// Second key of a sequence like "z o"
+			key, d.pending = d.pending+" "+key, ""
+		} else if d.keys.IsPrefix(keys.ModeDiff, key) {
+			d.pending = key
+			return d, nil
+		}
+
+		// Scrolling keys come from the keymap, not the viewport's own
+		switch {
+		case d.keys.Is(key, keys.DiffDown):
 			d.viewport.LineDown(1)
-		case "k", "up":
+		case d.keys.Is(key, keys.DiffUp):
 			d.viewport.LineUp(1)
-		case "ctrl+d":
+		case d.keys.Is(key, keys.DiffPageDown):
 			d.viewport.HalfViewDown()
-		case "ctrl+u":
+		case d.keys.Is(key, keys.DiffPageUp):
 			d.viewport.HalfViewUp()
-		case "g":
+		case d.keys.Is(key, keys.DiffTop):
 			d.viewport.GotoTop()
-		case "G":
+		case d.keys.Is(key, keys.DiffBottom):
 			d.viewport.GotoBottom()
+		case d.keys.Is(key, keys.DiffSplit):
+			d.ToggleLayout()
+		case d.keys.Is(key, keys.DiffNextHunk):
+			d.NextHunk()
+		case d.keys.Is(key, keys.DiffPrevHunk):
+			d.PrevHunk()
+		case d.keys.Is(key, keys.DiffFoldOpen):
+			d.OpenFold()
+		case d.keys.Is(key, keys.DiffFoldClose):
+			d.CloseFold()
+		case d.keys.Is(key, keys.DiffSearch):
+			d.StartSearch()
+			return d, textinput.Blink
+		case d.keys.Is(key, keys.DiffNextMatch):
+			d.NextMatch()
+		case d.keys.Is(key, keys.DiffPrevMatch):
+			d.PrevMatch()
 		}
+		return d, nil
 	}
 
 	var cmd tea.Cmd
@@ -191,7 +383,7 @@ func (d DiffView) View() string {
 	} else if d.diff == "" {
 		content = InfoStyle.Render("No changes")
 	} else {
-		content = d.viewport.View()
+		content = d.visibleLines()
 	}
 
 	// Footer with keybindings
@@ -207,8 +399,9 @@ func (d DiffView) View() string {
 		footer,
 	)
 
+	// The width of the container includes its padding
 	return ContainerStyle.
-		Width(d.contentWidth()).
+		Width(d.contentWidth() + 2).
 		Height(d.height - 4).
 		Render(body)
 }
@@ -223,12 +416,15 @@ func (d DiffView) renderHeader() string {
 		DeletionsStyle.Render(fmt.Sprintf("-%d", d.deletions)),
 	)
 
-	// File indicator
-	indicator := FileIndicatorStyle.Render(fmt.Sprintf("File %d/%d", d.fileIndex+1, d.totalFiles))
-
-	// Combine: path on left, stats and indicator on right
+	// Combine: path on left, options, stats and file indicator on right
 	left := path
-	right := fmt.Sprintf("%s  %s", stats, indicator)
+	right := stats
+	if !d.patch {
+		if flags := optionFlags(d.options); flags != "" {
+			right = FileIndicatorStyle.Render(flags) + "  " + right
+		}
+		right += "  " + FileIndicatorStyle.Render(fmt.Sprintf("File %d/%d", d.fileIndex+1, d.totalFiles))
+	}
 
 	// Calculate spacing
 	spacing := d.contentWidth() - lipgloss.Width(left) - lipgloss.Width(right)
@@ -240,5 +436,26 @@ func (d DiffView) renderHeader() string {
 }
 
 func (d DiffView) renderFooter() string {
-	return FooterStyle.Render("[↑/↓] scroll  [h/l] prev/next file  [Ctrl+d/u] page  [g/G] top/bottom  [Esc] back")
+	if d.search.typing {
+		footer := d.search.input.View()
+		if d.search.query != "" {
+			footer += "  " + FooterStyle.Render(d.matchCounter())
+		}
+		return footer
+	}
+	if d.status != "" {
+		return StatusStyle.Render(d.status)
+	}
+	k := d.keys.Label
+	layout := "split"
+	if d.Split() {
+		layout = "unified"
+	}
+	hints := fmt.Sprintf("[%s/%s] scroll  [%s/%s] prev/next file  [%s/%s] page  [%s/%s] top/bottom  [%s] %s  [%s] search  [%s] yank  [%s] back",
+		k(keys.DiffDown), k(keys.DiffUp), k(keys.DiffPrevFile), k(keys.DiffNextFile), k(keys.DiffPageDown), k(keys.DiffPageUp),
+		k(keys.DiffTop), k(keys.DiffBottom), k(keys.DiffSplit), layout, k(keys.DiffSearch), k(keys.DiffYank), k(keys.DiffClose))
+	if d.search.query != "" {
+		hints = "/" + d.search.query + " " + d.matchCounter() + "  " + hints
+	}
+	return FooterStyle.Render(text.Truncate(hints, d.contentWidth()))
 }
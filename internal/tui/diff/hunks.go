@@ -0,0 +1,171 @@
+package diff
+
+import (
+	"slices"
+	"strings"
+)
+
+// section is a range of patch lines that folds under its first line: a
+// hunk under its @@ header, or a file under its diff header
+type section struct {
+	start, end int // the header line, and the line after the last
+}
+
+// indexSections finds the hunks and files of a patch
+func indexSections(lines []string) (hunks, files []section) {
+	closeLast := func(sections []section, end int) {
+		if n := len(sections); n > 0 && sections[n-1].end < 0 {
+			sections[n-1].end = end
+		}
+	}
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			closeLast(hunks, i)
+			closeLast(files, i)
+			files = append(files, section{start: i, end: -1})
+		case strings.HasPrefix(line, "@@"):
+			closeLast(hunks, i)
+			hunks = append(hunks, section{start: i, end: -1})
+		}
+	}
+	// The patch ends in a newline, so its last line is empty
+	end := len(lines)
+	if end > 0 && lines[end-1] == "" {
+		end--
+	}
+	closeLast(hunks, end)
+	closeLast(files, end)
+	return hunks, files
+}
+
+// sectionAt returns the index of the section that line i is in, -1 if none
+func sectionAt(sections []section, i int) int {
+	k, _ := slices.BinarySearchFunc(sections, i, func(s section, i int) int { return s.start - i })
+	if k < len(sections) && sections[k].start == i {
+		return k
+	}
+	if k > 0 && i < sections[k-1].end {
+		return k - 1
+	}
+	return -1
+}
+
+// layoutFolds lists the patch lines and split rows left shown by the folds
+func (d *DiffView) layoutFolds() {
+	d.shown = make([]int, 0, len(d.lines))
+	for i := 0; i < len(d.lines); i++ {
+		d.shown = append(d.shown, i)
+		if end, ok := d.folded[i]; ok {
+			i = end - 1
+		}
+	}
+	d.shownRows = make([]int, 0, len(d.rows))
+	for row, r := range d.rows {
+		if _, found := slices.BinarySearch(d.shown, r.line); found {
+			d.shownRows = append(d.shownRows, row)
+		}
+	}
+}
+
+// offsetOf returns the first screen line of patch line i, or of the fold
+// that hides it
+func (d DiffView) offsetOf(i int) int {
+	if !d.split {
+		k, found := slices.BinarySearch(d.shown, i)
+		if !found {
+			k--
+		}
+		return max(0, k)
+	}
+	k, found := slices.BinarySearch(d.shownRows, d.rowOf[i])
+	if !found {
+		k--
+	}
+	return d.rowStarts[max(0, k)]
+}
+
+// NextHunk scrolls the next hunk header to the top of the view
+func (d *DiffView) NextHunk() {
+	top := d.topLine()
+	for _, h := range d.hunks {
+		if h.start > top && d.isShown(h.start) {
+			d.viewport.SetYOffset(d.offsetOf(h.start))
+			return
+		}
+	}
+}
+
+// PrevHunk scrolls the previous hunk header to the top of the view
+func (d *DiffView) PrevHunk() {
+	top := d.topLine()
+	for k := len(d.hunks) - 1; k >= 0; k-- {
+		if h := d.hunks[k]; h.start < top && d.isShown(h.start) {
+			d.viewport.SetYOffset(d.offsetOf(h.start))
+			return
+		}
+	}
+}
+
+func (d DiffView) isShown(i int) bool {
+	_, found := slices.BinarySearch(d.shown, i)
+	return found
+}
+
+// CloseFold folds the hunk at the top of the view, or its file when the
+// hunk is folded already or the top is a file header
+func (d *DiffView) CloseFold() {
+	top := d.topLine()
+	if k := sectionAt(d.hunks, top); k >= 0 {
+		if h := d.hunks[k]; !d.isFolded(h.start) {
+			d.fold(h)
+			return
+		}
+	}
+	if k := sectionAt(d.patchFiles, top); k >= 0 {
+		d.fold(d.patchFiles[k])
+	}
+}
+
+// OpenFold unfolds the fold at the top of the view
+func (d *DiffView) OpenFold() {
+	top := d.topLine()
+	if d.isFolded(top) {
+		delete(d.folded, top)
+		d.relayout(top)
+	}
+}
+
+// reveal opens the folds that hide patch line i
+func (d *DiffView) reveal(i int) {
+	if d.isShown(i) {
+		return
+	}
+	for _, sections := range [][]section{d.patchFiles, d.hunks} {
+		if k := sectionAt(sections, i); k >= 0 {
+			delete(d.folded, sections[k].start)
+		}
+	}
+	d.relayout(d.topLine())
+}
+
+func (d DiffView) isFolded(i int) bool {
+	_, ok := d.folded[i]
+	return ok
+}
+
+func (d *DiffView) fold(s section) {
+	if s.end-s.start < 2 {
+		// Nothing under the header
+		return
+	}
+	d.folded[s.start] = s.end
+	d.relayout(s.start)
+}
+
+// relayout lays out the diff again after a change of folds, with patch
+// line top at the top of the view
+func (d *DiffView) relayout(top int) {
+	d.render()
+	d.viewport.SetYOffset(d.offsetOf(top))
+}
@@ -0,0 +1,85 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// twoFiles is a patch of two files, the first with two hunks
+const twoFiles = "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n" +
+	"@@ -1 +1 @@\n-x1\n+x2\n" +
+	"@@ -10 +10,2 @@\n x3\n+y\n" +
+	"diff --git a/b.go b/b.go\n--- a/b.go\n+++ b/b.go\n" +
+	"@@ -1,8 +1,8 @@\n-x4\n+x5\n z\n z\n z\n z\n z\n z\n z\n"
+
+func TestIndexSections(t *testing.T) {
+	hunks, files := indexSections(strings.Split(twoFiles, "\n"))
+	if want := []section{{3, 6}, {6, 9}, {12, 22}}; fmt.Sprint(hunks) != fmt.Sprint(want) {
+		t.Errorf("hunks = %v, want %v", hunks, want)
+	}
+	if want := []section{{0, 9}, {9, 22}}; fmt.Sprint(files) != fmt.Sprint(want) {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+}
+
+func TestHunkJumps(t *testing.T) {
+	for _, layout := range []Layout{LayoutUnified, LayoutSplit} {
+		d := New()
+		d.SetLayout(layout)
+		d.SetSize(120, 13)
+		d.Show(nil, 0)
+		d.SetDiff(twoFiles, false)
+
+		for _, want := range []int{3, 6, 12, 12} {
+			d.NextHunk()
+			if got := d.topLine(); got != want {
+				t.Errorf("layout %d: next hunk at line %d, want %d", layout, got, want)
+			}
+		}
+		d.PrevHunk()
+		if got := d.topLine(); got != 6 {
+			t.Errorf("layout %d: previous hunk at line %d, want 6", layout, got)
+		}
+	}
+}
+
+func TestFolds(t *testing.T) {
+	for _, layout := range []Layout{LayoutUnified, LayoutSplit} {
+		d := New()
+		d.SetLayout(layout)
+		d.SetSize(120, 13)
+		d.Show(nil, 0)
+		d.SetDiff(twoFiles, false)
+		d.NextHunk()
+
+		// The first zc folds the hunk, the second its file
+		d.CloseFold()
+		if got := d.topLine(); got != 3 || !d.isFolded(3) || d.isShown(4) {
+			t.Fatalf("layout %d: hunk fold at line %d, want the hunk at 3 folded", layout, got)
+		}
+		if line := d.screenLine(d.viewport.YOffset); !strings.Contains(line, "⋯ 2 lines") {
+			t.Errorf("layout %d: folded hunk header = %q, want the hidden line count", layout, line)
+		}
+		d.CloseFold()
+		if got := d.topLine(); got != 0 || d.isShown(6) {
+			t.Fatalf("layout %d: file fold at line %d, want the file at 0 folded", layout, got)
+		}
+		d.NextHunk()
+		if got := d.topLine(); got != 12 {
+			t.Errorf("layout %d: next hunk at line %d past the fold, want 12", layout, got)
+		}
+
+		d.viewport.GotoTop()
+		d.OpenFold()
+		if !d.isShown(6) || d.isShown(4) {
+			t.Errorf("layout %d: opening the file should leave the hunk folded", layout)
+		}
+
+		// Folds are kept across a change of layout
+		d.ToggleLayout()
+		if d.isShown(4) {
+			t.Errorf("layout %d: the hunk unfolded when the layout changed", layout)
+		}
+	}
+}
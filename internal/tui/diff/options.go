@@ -0,0 +1,76 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+// Options returns the options diffs are requested with
+func (d DiffView) Options() domain.DiffOptions {
+	return d.options
+}
+
+// SetOptions sets the options diffs are requested with and returns whether
+// the diff shown must be loaded again. The reloaded diff opens at the line
+// of the new file that is at the top of the view.
+func (d *DiffView) SetOptions(opts domain.DiffOptions) bool {
+	opts.Context = max(0, opts.Context)
+	if opts == d.options {
+		return false
+	}
+	d.options = opts
+	if !d.visible || d.patch {
+		return false
+	}
+	d.keepLine = d.topNewLine()
+	return true
+}
+
+// topNewLine returns the first line of the new file at or below the top of
+// the view, 0 if none
+func (d DiffView) topNewLine() int {
+	if d.loading || d.diff == "" {
+		return 0
+	}
+	for _, r := range d.rows[d.rowOf[d.topLine()]:] {
+		if r.newNum > 0 {
+			return r.newNum
+		}
+	}
+	return 0
+}
+
+// scrollToNewLine puts the row of line n of the new file at the top, or
+// the first row below it
+func (d *DiffView) scrollToNewLine(n int) {
+	for _, r := range d.rows {
+		if r.newNum >= n {
+			d.viewport.SetYOffset(d.offsetOf(r.line))
+			return
+		}
+	}
+}
+
+// optionFlags returns the git diff flags of the options that differ from
+// the defaults, e.g. "-w -U1"
+func optionFlags(opts domain.DiffOptions) string {
+	var flags []string
+	if opts.IgnoreAllSpace {
+		flags = append(flags, "-w")
+	}
+	if opts.IgnoreSpaceChange {
+		flags = append(flags, "-b")
+	}
+	if opts.IgnoreBlankLines {
+		flags = append(flags, "--ignore-blank-lines")
+	}
+	if opts.Context != domain.DefaultContext {
+		flags = append(flags, "-U"+strconv.Itoa(opts.Context))
+	}
+	if opts.FunctionContext {
+		flags = append(flags, "-W")
+	}
+	return strings.Join(flags, " ")
+}
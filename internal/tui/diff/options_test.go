@@ -0,0 +1,55 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+func TestOptionFlags(t *testing.T) {
+	if got := optionFlags(domain.DefaultDiffOptions()); got != "" {
+		t.Errorf("default options = %q, want no flags", got)
+	}
+	opts := domain.DiffOptions{IgnoreAllSpace: true, IgnoreBlankLines: true, Context: 1, FunctionContext: true}
+	if got, want := optionFlags(opts), "-w --ignore-blank-lines -U1 -W"; got != want {
+		t.Errorf("optionFlags = %q, want %q", got, want)
+	}
+}
+
+func TestSetOptions_KeepsNewLine(t *testing.T) {
+	numbered := func(context int) string {
+		var b strings.Builder
+		fmt.Fprintf(&b, "@@ -1,%d +1,%d @@\n", 40+context, 40+context)
+		for i := 1; i <= 40+context; i++ {
+			fmt.Fprintf(&b, " line %d\n", i)
+		}
+		return b.String()
+	}
+
+	d := New()
+	d.SetSize(120, 20)
+	d.Show([]domain.FileChange{{Path: "a.txt"}}, 0)
+	d.SetDiff(numbered(0), false)
+	d.viewport.SetYOffset(21) // line 21 of the new file
+
+	opts := d.Options()
+	opts.Context++
+	if !d.SetOptions(opts) {
+		t.Fatal("a new option should reload the diff")
+	}
+	if d.SetOptions(opts) {
+		t.Error("the same options should not reload the diff")
+	}
+	d.SetDiff(numbered(1), false)
+	if got := d.viewport.YOffset; got != 21 {
+		t.Errorf("offset after reload = %d, want 21", got)
+	}
+
+	d.ShowPatch("interdiff", numbered(0))
+	opts.IgnoreAllSpace = true
+	if d.SetOptions(opts) {
+		t.Error("a patch given whole cannot be reloaded")
+	}
+}
@@ -1,39 +1,269 @@
 package diff
 
 import (
+	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+// lineKind is the side of a change a line of code is on
+type lineKind int
+
+const (
+	lineContext lineKind = iota
+	lineRemoved
+	lineAdded
 )
 
-// renderDiff applies syntax coloring to diff output
-func renderDiff(diff string) string {
-	if diff == "" {
-		return InfoStyle.Render("No changes")
+// renderCache holds the lines rendered so far. Only the lines in view are
+// rendered, so diffs of any length open at once.
+type renderCache struct {
+	lines map[int]string         // unified layout, by patch line
+	rows  map[int][]string       // split layout, by row
+	words map[int][2][]text.Span // changed words of paired lines, by row
+}
+
+func newRenderCache() *renderCache {
+	return &renderCache{
+		lines: make(map[int]string),
+		rows:  make(map[int][]string),
+		words: make(map[int][2][]text.Span),
 	}
+}
 
-	lines := strings.Split(diff, "\n")
-	var rendered []string
+// visibleLines renders the screen lines in view, padded to its height
+func (d DiffView) visibleLines() string {
+	start := d.viewport.YOffset
+	end := min(start+d.viewport.Height, d.viewport.TotalLineCount())
+	lines := make([]string, 0, d.viewport.Height)
+	for v := start; v < end; v++ {
+		lines = append(lines, d.screenLine(v))
+	}
+	for len(lines) < d.viewport.Height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// screenLine renders screen line v of the current layout
+func (d DiffView) screenLine(v int) string {
+	if !d.split {
+		return d.unifiedLine(d.shown[v])
+	}
+	k, _ := slices.BinarySearch(d.rowStarts, v+1)
+	k = max(0, k-1)
+	row := d.shownRows[k]
+	lines, ok := d.cache.rows[row]
+	if !ok {
+		lines = d.splitRow(row)
+		d.cache.rows[row] = lines
+	}
+	if n := v - d.rowStarts[k]; n < len(lines) {
+		return lines[n]
+	}
+	return ""
+}
 
-	for _, line := range lines {
-		if len(line) == 0 {
-			rendered = append(rendered, "")
-			continue
+// unifiedLine renders patch line i as git prints it, cut to the width
+func (d DiffView) unifiedLine(i int) string {
+	if line, ok := d.cache.lines[i]; ok {
+		return line
+	}
+
+	line := d.lines[i]
+	width := d.viewport.Width
+	var rendered string
+	switch {
+	case line == "":
+	case strings.HasPrefix(line, "@@"):
+		rendered = d.headerLine(i, width)
+	case strings.HasPrefix(line, "+"), strings.HasPrefix(line, "-"), strings.HasPrefix(line, " "):
+		kind := lineContext
+		switch line[0] {
+		case '+':
+			kind = lineAdded
+		case '-':
+			kind = lineRemoved
+		}
+		code, highlighted := d.code(i, kind)
+		rendered = markerStyle(kind, highlighted).Render(line[:1]) + code
+		if text.Width(rendered) > width {
+			rendered = text.TruncateAnsi(rendered, width)
+		}
+		if highlighted {
+			rendered = padCode(rendered, kind, width)
 		}
+	default:
+		// File headers
+		rendered = d.headerLine(i, width)
+	}
+
+	d.cache.lines[i] = rendered
+	return rendered
+}
 
-		switch {
-		case strings.HasPrefix(line, "@@"):
-			// Hunk header
-			rendered = append(rendered, DiffHunkStyle.Render(line))
-		case strings.HasPrefix(line, "+"):
-			// Added line
-			rendered = append(rendered, DiffAddedStyle.Render(line))
-		case strings.HasPrefix(line, "-"):
-			// Deleted line
-			rendered = append(rendered, DiffDeletedStyle.Render(line))
-		default:
-			// Context line
-			rendered = append(rendered, DiffContextStyle.Render(line))
+// splitRow renders a row of the split layout
+func (d DiffView) splitRow(row int) []string {
+	r := d.rows[row]
+	var left, right string
+	var highlighted bool
+	switch r.kind {
+	case rowHunk, rowMeta:
+		left = d.headerLine(r.line, d.geometry.width)
+	case rowContext:
+		left, highlighted = d.code(r.leftLine, lineContext)
+		right = left
+	case rowChange:
+		if r.hasLeft {
+			left, highlighted = d.code(r.leftLine, lineRemoved)
+		}
+		if r.hasRight {
+			right, highlighted = d.code(r.rightLine, lineAdded)
 		}
 	}
+	return d.geometry.renderRow(r, left, right, highlighted)
+}
 
-	return strings.Join(rendered, "\n")
+// code renders the code of patch line i, a line on the kind side, and
+// returns whether it is syntax highlighted
+func (d DiffView) code(i int, kind lineKind) (string, bool) {
+	var tokens []token
+	if d.syntax != nil {
+		tokens = d.syntax.lineTokens(i)
+	}
+	return renderCode(d.lines[i][1:], kind, tokens, d.changedWords(i, kind), d.codeMatches(i)), tokens != nil
+}
+
+// headerLine renders patch line i, a line outside the code of hunks, cut to
+// width. A folded header tells how many lines it hides.
+func (d DiffView) headerLine(i, width int) string {
+	style := DiffContextStyle
+	if strings.HasPrefix(d.lines[i], "@@") {
+		style = DiffHunkStyle
+	}
+	line := renderEmphasis(d.lines[i], d.search.spans[i], style, DiffMatchStyle)
+	if end, ok := d.folded[i]; ok {
+		line += DiffFoldStyle.Render(fmt.Sprintf(" ⋯ %d lines", end-i-1))
+	}
+	if text.Width(line) > width {
+		line = text.TruncateAnsi(line, width)
+	}
+	return line
+}
+
+// changedWords returns the spans of patch line i that differ from the line
+// it is paired with
+func (d DiffView) changedWords(i int, kind lineKind) []text.Span {
+	if kind == lineContext || d.wordDiff == WordDiffNone {
+		return nil
+	}
+	row := d.rowOf[i]
+	words, ok := d.cache.words[row]
+	if !ok {
+		words[0], words[1] = rowSpans(d.lines, d.rows[row], d.wordDiff)
+		d.cache.words[row] = words
+	}
+	if kind == lineRemoved {
+		return words[0]
+	}
+	return words[1]
+}
+
+// renderCode renders a line of code without its +/-/space prefix, with
+// its changed words emphasized, its search matches marked and tabs
+// expanded. Highlighted code has the colors of its tokens over the
+// background of its kind; without tokens the line takes the color of its
+// kind.
+func renderCode(s string, kind lineKind, tokens []token, spans, matches []text.Span) string {
+	highlighted := tokens != nil
+	if !highlighted {
+		tokens = []token{{text: s}}
+	}
+
+	var b strings.Builder
+	pos := 0 // rune offset in s
+	nextSpan, nextMatch := 0, 0
+	for _, tok := range tokens {
+		runes := []rune(tok.text)
+		for len(runes) > 0 {
+			// Cut the token where emphasis or a match starts or ends
+			n, emphasized := spanRun(spans, &nextSpan, pos, len(runes))
+			n, matched := spanRun(matches, &nextMatch, pos, n)
+			var style lipgloss.Style
+			switch {
+			case matched:
+				style = DiffMatchStyle
+			case highlighted && emphasized:
+				style = codeEmphStyles[kind]
+			case highlighted:
+				style = codeStyles[kind][tok.class]
+			default:
+				style = plainStyle(kind, emphasized)
+			}
+			b.WriteString(style.Render(expandTabs(string(runes[:n]))))
+			runes = runes[n:]
+			pos += n
+		}
+	}
+	return b.String()
+}
+
+// spanRun returns how many of the n runes from pos on are alike in being
+// in spans or not, and whether they are. next is the first span that can
+// still hold pos.
+func spanRun(spans []text.Span, next *int, pos, n int) (int, bool) {
+	for *next < len(spans) && spans[*next].End <= pos {
+		*next++
+	}
+	if *next == len(spans) {
+		return n, false
+	}
+	if sp := spans[*next]; pos < sp.Start {
+		return min(n, sp.Start-pos), false
+	}
+	return min(n, spans[*next].End-pos), true
+}
+
+// plainStyle returns the style of code that is not highlighted
+func plainStyle(kind lineKind, emphasized bool) lipgloss.Style {
+	switch {
+	case kind == lineAdded && emphasized:
+		return DiffAddedEmphStyle
+	case kind == lineAdded:
+		return DiffAddedStyle
+	case kind == lineRemoved && emphasized:
+		return DiffDeletedEmphStyle
+	case kind == lineRemoved:
+		return DiffDeletedStyle
+	}
+	return DiffContextStyle
+}
+
+// padCode extends the background of a highlighted added or removed line
+// to width
+func padCode(line string, kind lineKind, width int) string {
+	if kind == lineContext {
+		return line
+	}
+	if pad := width - text.Width(line); pad > 0 {
+		return line + codeStyles[kind][classPlain].Render(strings.Repeat(" ", pad))
+	}
+	return line
+}
+
+// markerStyle returns the style of the +/- prefix of a line
+func markerStyle(kind lineKind, highlighted bool) lipgloss.Style {
+	if highlighted {
+		return codeMarkerStyles[kind]
+	}
+	switch kind {
+	case lineAdded:
+		return DiffAddedStyle
+	case lineRemoved:
+		return DiffDeletedStyle
+	}
+	return DiffContextStyle
 }
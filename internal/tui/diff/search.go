@@ -0,0 +1,230 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+// match is a search match in the diff
+type match struct {
+	line int       // patch line
+	span text.Span // rune offsets in the whole line
+}
+
+// diffSearch is a search in the diff shown. It searches as the query is
+// typed and keeps its query for the next diffs shown.
+type diffSearch struct {
+	input   textinput.Model
+	typing  bool
+	query   string
+	matches []match             // in patch order
+	spans   map[int][]text.Span // matches by patch line
+	current int                 // index into matches, -1 before the first jump
+	from    int                 // patch line at the top when typing started
+	prev    string              // query when typing started, restored on esc
+}
+
+func newDiffSearch() diffSearch {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.CharLimit = 100
+	ti.Width = 40
+	return diffSearch{input: ti, current: -1}
+}
+
+// findMatches returns the matches of query in lines, with smart case:
+// case-insensitive unless the query has an uppercase letter. Code is
+// searched without its +/-/space prefix.
+func findMatches(lines []string, rows []splitRow, rowOf []int, query string) []match {
+	if query == "" {
+		return nil
+	}
+	fold := strings.ToLower(query) == query
+	sub := []rune(query)
+	var matches []match
+	for i, line := range lines {
+		runes := []rune(line)
+		if fold {
+			for k, r := range runes {
+				runes[k] = unicode.ToLower(r)
+			}
+		}
+		start := 0
+		if kind := rows[rowOf[i]].kind; kind == rowContext || kind == rowChange {
+			start = 1
+		}
+		for k := start; k+len(sub) <= len(runes); {
+			if slices.Equal(runes[k:k+len(sub)], sub) {
+				matches = append(matches, match{line: i, span: text.Span{Start: k, End: k + len(sub)}})
+				k += len(sub)
+				continue
+			}
+			k++
+		}
+	}
+	return matches
+}
+
+// StartSearch opens the search input
+func (d *DiffView) StartSearch() {
+	if d.loading || d.diff == "" {
+		return
+	}
+	d.search.typing = true
+	d.search.prev = d.search.query
+	d.search.from = d.topLine()
+	d.search.input.SetValue("")
+	d.search.input.Focus()
+}
+
+// CapturesKeys returns whether the diff view takes every key: while a
+// search is typed or a key sequence is started
+func (d DiffView) CapturesKeys() bool {
+	return d.search.typing || d.pending != ""
+}
+
+// updateSearch handles a key while the search is typed. The view jumps to
+// the first match from where the search started.
+func (d *DiffView) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		d.search.typing = false
+		d.search.input.Blur()
+		return nil
+	case tea.KeyEsc:
+		d.search.typing = false
+		d.search.input.Blur()
+		d.setQuery(d.search.prev)
+		d.viewport.SetYOffset(d.offsetOf(d.search.from))
+		return nil
+	}
+
+	var cmd tea.Cmd
+	d.search.input, cmd = d.search.input.Update(msg)
+	if query := d.search.input.Value(); query != d.search.query {
+		d.setQuery(query)
+		for k, m := range d.search.matches {
+			if m.line >= d.search.from {
+				d.jumpTo(k)
+				break
+			}
+		}
+		if d.search.current < 0 {
+			d.viewport.SetYOffset(d.offsetOf(d.search.from))
+		}
+	}
+	return cmd
+}
+
+// setQuery searches the diff for query ("" clears the search)
+func (d *DiffView) setQuery(query string) {
+	d.search.query = query
+	d.findMatches()
+}
+
+// findMatches searches the diff shown for the query
+func (d *DiffView) findMatches() {
+	d.search.matches = nil
+	d.search.current = -1
+	if !d.loading {
+		d.search.matches = findMatches(d.lines, d.rows, d.rowOf, d.search.query)
+	}
+	d.search.spans = make(map[int][]text.Span)
+	for _, m := range d.search.matches {
+		d.search.spans[m.line] = append(d.search.spans[m.line], m.span)
+	}
+	if d.cache != nil {
+		d.cache = newRenderCache()
+	}
+}
+
+// clearSearch forgets the query, e.g. when the diff view closes
+func (d *DiffView) clearSearch() {
+	d.search.typing = false
+	d.search.input.Blur()
+	d.search.query = ""
+	d.search.matches = nil
+	d.search.spans = nil
+	d.search.current = -1
+}
+
+// NextMatch jumps to the next match, from the top after the last. Before
+// the first jump in a diff, the next match is the first below the top of
+// the view.
+func (d *DiffView) NextMatch() {
+	n := len(d.search.matches)
+	if n == 0 {
+		return
+	}
+	next := (d.search.current + 1) % n
+	if d.search.current < 0 {
+		top := d.topLine()
+		next = 0
+		for next < n && d.search.matches[next].line <= top {
+			next++
+		}
+		next %= n
+	}
+	d.jumpTo(next)
+}
+
+// PrevMatch jumps to the previous match, from the bottom before the first
+func (d *DiffView) PrevMatch() {
+	n := len(d.search.matches)
+	if n == 0 {
+		return
+	}
+	prev := (d.search.current + n - 1) % n
+	if d.search.current < 0 {
+		top := d.topLine()
+		prev = n - 1
+		for prev >= 0 && d.search.matches[prev].line >= top {
+			prev--
+		}
+		prev = (prev + n) % n
+	}
+	d.jumpTo(prev)
+}
+
+// jumpTo makes match k the current one and scrolls it to a third of the
+// view, opening the folds that hide it
+func (d *DiffView) jumpTo(k int) {
+	d.search.current = k
+	line := d.search.matches[k].line
+	d.reveal(line)
+	d.viewport.SetYOffset(max(0, d.offsetOf(line)-d.viewport.Height/3))
+}
+
+// codeMatches returns the matches in the code of patch line i, in runes of
+// the code without its prefix
+func (d DiffView) codeMatches(i int) []text.Span {
+	spans := d.search.spans[i]
+	if len(spans) == 0 {
+		return nil
+	}
+	code := make([]text.Span, 0, len(spans))
+	for _, sp := range spans {
+		if sp.End > 1 {
+			code = append(code, text.Span{Start: max(0, sp.Start-1), End: sp.End - 1})
+		}
+	}
+	return code
+}
+
+// matchCounter returns the current match and the number of matches, e.g.
+// "2/7", for the footer
+func (d DiffView) matchCounter() string {
+	switch {
+	case len(d.search.matches) == 0:
+		return "no matches"
+	case d.search.current < 0:
+		return fmt.Sprintf("%d matches", len(d.search.matches))
+	}
+	return fmt.Sprintf("%d/%d", d.search.current+1, len(d.search.matches))
+}
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+func TestFindMatches_SmartCase(t *testing.T) {
+	patch := "@@ -1,2 +1,2 @@ Foo\n-foo := 1\n+Foo := foo\n"
+	rows, rowOf := splitRows(patch)
+	lines := strings.Split(patch, "\n")
+
+	if got := findMatches(lines, rows, rowOf, "foo"); len(got) != 4 {
+		t.Errorf("lower case query found %d matches, want 4 in any case", len(got))
+	}
+	got := findMatches(lines, rows, rowOf, "Foo")
+	if len(got) != 2 || got[1] != (match{line: 2, span: text.Span{Start: 1, End: 4}}) {
+		t.Errorf("upper case query found %v, want its case only", got)
+	}
+	if got := findMatches(lines, rows, rowOf, "-f"); len(got) != 0 {
+		t.Errorf("query of a prefix found %v, want none", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	for _, layout := range []Layout{LayoutUnified, LayoutSplit} {
+		d := New()
+		d.SetLayout(layout)
+		d.SetSize(120, 13)
+		d.Show(nil, 0)
+		d.SetDiff(twoFiles, false)
+		d.NextHunk()
+		d.CloseFold()
+		d.CloseFold()
+
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+		if !d.CapturesKeys() {
+			t.Fatalf("layout %d: / should open the search", layout)
+		}
+		for _, r := range "x5" {
+			d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		if got := d.search.matches; len(got) != 1 || got[0].line != 14 {
+			t.Fatalf("layout %d: matches of %q = %v, want line 14", layout, d.search.query, got)
+		}
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if d.CapturesKeys() {
+			t.Errorf("layout %d: enter should close the search input", layout)
+		}
+
+		// n opens the folds that hide a match
+		d.setQuery("x")
+		d.viewport.GotoTop()
+		d.CloseFold()
+		d.NextMatch()
+		if got := d.search.current; got != 0 || !d.isShown(4) {
+			t.Errorf("layout %d: first match %d, want 0 with its folds opened", layout, got)
+		}
+		for range 5 {
+			d.NextMatch()
+		}
+		if got := d.matchCounter(); got != "1/5" {
+			t.Errorf("layout %d: counter after wrapping = %q, want 1/5", layout, got)
+		}
+		d.PrevMatch()
+		if got := d.matchCounter(); got != "5/5" {
+			t.Errorf("layout %d: counter before the first = %q, want 5/5", layout, got)
+		}
+
+		// esc restores the query the search started with
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if d.search.query != "x" {
+			t.Errorf("layout %d: query after esc = %q, want x", layout, d.search.query)
+		}
+	}
+}
+
+func TestKeySequence(t *testing.T) {
+	d := New()
+	d.SetSize(120, 13)
+	d.Show(nil, 0)
+	d.SetDiff(twoFiles, false)
+	for _, r := range "]c]c" {
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got := d.topLine(); got != 6 {
+		t.Errorf("] c twice at line %d, want the second hunk at 6", got)
+	}
+}
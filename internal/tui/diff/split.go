@@ -0,0 +1,272 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+// Layout is how the diff view lays out a patch
+type Layout int
+
+const (
+	LayoutUnified Layout = iota // the patch as git prints it
+	LayoutSplit                 // old lines left, new lines right
+	LayoutAuto                  // split on terminals at least autoSplitWidth wide
+)
+
+// autoSplitWidth is the terminal width from which LayoutAuto splits; each
+// side then has room for about 75 columns of code
+const autoSplitWidth = 160
+
+// ParseLayout parses a layout name, e.g. from the config file
+func ParseLayout(name string) (Layout, bool) {
+	switch strings.ToLower(name) {
+	case "", "unified":
+		return LayoutUnified, true
+	case "split":
+		return LayoutSplit, true
+	case "auto":
+		return LayoutAuto, true
+	}
+	return LayoutUnified, false
+}
+
+// tabWidth matches the width lipgloss renders tabs with in the unified layout
+const tabWidth = 4
+
+// rowKind is what a row of the split layout shows
+type rowKind int
+
+const (
+	rowMeta    rowKind = iota // file header lines, shown across both sides
+	rowHunk                   // @@ header, across both sides
+	rowContext                // the same line on both sides
+	rowChange                 // a removed line left, an added line right, or one of them
+)
+
+// splitRow is a line of the split layout
+type splitRow struct {
+	kind      rowKind
+	left      string // text without the +/-/space prefix
+	right     string
+	hasLeft   bool
+	hasRight  bool
+	oldNum    int // 0 when unknown or the side is empty
+	newNum    int
+	line      int // index of its first line in the patch
+	leftLine  int // patch lines of the sides
+	rightLine int
+}
+
+// splitRows pairs the lines of a patch for the split layout. Removed and
+// added lines of a change are paired in order; the longer side gets rows
+// of its own. It also returns the row each patch line is shown in.
+func splitRows(diff string) ([]splitRow, []int) {
+	lines := strings.Split(diff, "\n")
+	rows := make([]splitRow, 0, len(lines))
+	rowOf := make([]int, len(lines))
+
+	var removed, added []int // patch lines of the pending change
+	inHunk := false
+	oldNum, newNum := 0, 0
+
+	flush := func() {
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			row := splitRow{kind: rowChange, line: -1}
+			if k < len(removed) {
+				i := removed[k]
+				row.left, row.hasLeft, row.line, row.leftLine = lines[i][1:], true, i, i
+				if oldNum > 0 {
+					row.oldNum = oldNum
+					oldNum++
+				}
+				rowOf[i] = len(rows)
+			}
+			if k < len(added) {
+				i := added[k]
+				row.right, row.hasRight, row.rightLine = lines[i][1:], true, i
+				if row.line < 0 {
+					row.line = i
+				}
+				if newNum > 0 {
+					row.newNum = newNum
+					newNum++
+				}
+				rowOf[i] = len(rows)
+			}
+			rows = append(rows, row)
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			inHunk = true
+			oldNum, newNum = hunkStart(line)
+			rowOf[i] = len(rows)
+			rows = append(rows, splitRow{kind: rowHunk, left: line, line: i})
+		case strings.HasPrefix(line, "diff "):
+			flush()
+			inHunk = false
+			rowOf[i] = len(rows)
+			rows = append(rows, splitRow{kind: rowMeta, left: line, line: i})
+		case inHunk && strings.HasPrefix(line, "-"):
+			if len(added) > 0 {
+				// A removal after additions starts a new change
+				flush()
+			}
+			removed = append(removed, i)
+		case inHunk && strings.HasPrefix(line, "+"):
+			added = append(added, i)
+		case inHunk && strings.HasPrefix(line, " "):
+			flush()
+			rowOf[i] = len(rows)
+			rows = append(rows, splitRow{kind: rowContext, left: line[1:], right: line[1:], hasLeft: true, hasRight: true,
+				oldNum: oldNum, newNum: newNum, line: i, leftLine: i, rightLine: i})
+			if oldNum > 0 {
+				oldNum++
+				newNum++
+			}
+		default:
+			// Headers, "\ No newline at end of file" and blank lines
+			flush()
+			rowOf[i] = len(rows)
+			rows = append(rows, splitRow{kind: rowMeta, left: line, line: i})
+		}
+	}
+	flush()
+	return rows, rowOf
+}
+
+// hunkStart returns the first old and new line numbers of a hunk header
+// "@@ -12,5 +12,7 @@", or zeros when it has none
+func hunkStart(header string) (int, int) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0
+	}
+	start := func(s string) int {
+		s, _, _ = strings.Cut(s[1:], ",")
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0
+		}
+		// A side without lines ("-0,0") starts before its first line
+		return max(n, 1)
+	}
+	return start(fields[1]), start(fields[2])
+}
+
+// splitGeometry is the column layout of the split view for a patch
+type splitGeometry struct {
+	width     int
+	sideWidth int // number, marker and text
+	numWidth  int
+	textWidth int
+}
+
+// newSplitGeometry fits the rows into width columns, with line numbers as
+// wide as the largest
+func newSplitGeometry(rows []splitRow, width int) splitGeometry {
+	maxNum := 0
+	for _, r := range rows {
+		maxNum = max(maxNum, r.oldNum, r.newNum)
+	}
+	g := splitGeometry{width: width, numWidth: len(strconv.Itoa(maxNum))}
+	g.sideWidth = (width - 3) / 2 // three columns for the separator
+	g.textWidth = max(1, g.sideWidth-g.numWidth-2)
+	return g
+}
+
+// rowStarts returns the first screen line of each shown row, and the
+// number of screen lines, from the widths of the plain text: long lines
+// wrap within their side, headers are cut
+func (g splitGeometry) rowStarts(rows []splitRow, shown []int) ([]int, int) {
+	starts := make([]int, len(shown))
+	total := 0
+	for k, row := range shown {
+		starts[k] = total
+		total += g.rowHeight(rows[row])
+	}
+	return starts, total
+}
+
+func (g splitGeometry) rowHeight(r splitRow) int {
+	if r.kind == rowHunk || r.kind == rowMeta {
+		return 1
+	}
+	lines := func(s string) int {
+		return max(1, (text.Width(expandTabs(s))+g.textWidth-1)/g.textWidth)
+	}
+	return max(lines(r.left), lines(r.right))
+}
+
+// renderRow renders a row from the rendered code of its sides (see
+// renderCode), in as many screen lines as rowHeight. The background of
+// highlighted code fills its side. Headers come rendered whole in left.
+func (g splitGeometry) renderRow(r splitRow, left, right string, highlighted bool) []string {
+	if r.kind == rowHunk || r.kind == rowMeta {
+		return []string{left}
+	}
+
+	leftLines := g.renderSide(left, r.hasLeft, r.oldNum, "-", r.kind, highlighted)
+	rightLines := g.renderSide(right, r.hasRight, r.newNum, "+", r.kind, highlighted)
+	for len(leftLines) < len(rightLines) {
+		leftLines = append(leftLines, "")
+	}
+	for len(rightLines) < len(leftLines) {
+		rightLines = append(rightLines, "")
+	}
+	separator := " " + DiffLineNumberStyle.Render("│") + " "
+	lines := make([]string, len(leftLines))
+	for k := range leftLines {
+		lines[k] = text.FitAnsi(leftLines[k], g.sideWidth) + separator + rightLines[k]
+	}
+	return lines
+}
+
+// renderSide renders one side of a row: the line number, the +/- marker
+// and the code, wrapped to the text width
+func (g splitGeometry) renderSide(code string, present bool, num int, marker string, kind rowKind, highlighted bool) []string {
+	if !present {
+		return nil
+	}
+	line := lineAdded
+	switch {
+	case kind == rowContext:
+		marker, line = " ", lineContext
+	case marker == "-":
+		line = lineRemoved
+	}
+
+	number := strings.Repeat(" ", g.numWidth)
+	if num > 0 {
+		number = fmt.Sprintf("%*d", g.numWidth, num)
+	}
+	gutter := DiffLineNumberStyle.Render(number) + " " + markerStyle(line, highlighted).Render(marker)
+	blank := strings.Repeat(" ", g.numWidth+2)
+
+	chunks := text.WrapAnsi(code, g.textWidth)
+	lines := make([]string, len(chunks))
+	for k, chunk := range chunks {
+		if highlighted {
+			chunk = padCode(chunk, line, g.textWidth)
+		}
+		if k == 0 {
+			lines[k] = gutter + chunk
+		} else {
+			lines[k] = blank + chunk
+		}
+	}
+	return lines
+}
+
+// expandTabs replaces tabs with spaces, so that widths can be counted
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", strings.Repeat(" ", tabWidth))
+}
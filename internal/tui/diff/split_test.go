@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+const testPatch = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -10,5 +10,6 @@ func main() {
+ 	a := 1
+-	b := 2
+-	c := 3
++	b := 20
++	d := 4
++	e := 5
+ 	return
+-	x()
++	y()`
+
+func TestSplitRows(t *testing.T) {
+	rows, rowOf := splitRows(testPatch)
+
+	// 3 header lines, the hunk, a context line, 3 change rows, a context
+	// line and 1 change row
+	if len(rows) != 10 {
+		t.Fatalf("expected 10 rows, got %d", len(rows))
+	}
+	if rows[3].kind != rowHunk {
+		t.Errorf("row 3 should be the hunk header, got %+v", rows[3])
+	}
+
+	changes := []struct {
+		left, right    string
+		oldNum, newNum int
+	}{
+		{"\tb := 2", "\tb := 20", 11, 11},
+		{"\tc := 3", "\td := 4", 12, 12},
+		{"", "\te := 5", 0, 13},
+	}
+	for k, want := range changes {
+		r := rows[5+k]
+		if r.kind != rowChange || r.left != want.left || r.right != want.right || r.oldNum != want.oldNum || r.newNum != want.newNum {
+			t.Errorf("change row %d = %+v, want %+v", k, r, want)
+		}
+	}
+	if rows[7].hasLeft {
+		t.Error("the third added line should have no old side")
+	}
+	if ctx := rows[8]; ctx.kind != rowContext || ctx.oldNum != 13 || ctx.newNum != 14 {
+		t.Errorf("context after the change should be old 13 / new 14, got %+v", ctx)
+	}
+
+	// Both lines of a pair map to its row
+	if rowOf[5] != 5 || rowOf[7] != 5 || rowOf[9] != 7 {
+		t.Errorf("rowOf = %v", rowOf)
+	}
+}
+
+func TestHunkStart(t *testing.T) {
+	tests := []struct {
+		header   string
+		old, new int
+	}{
+		{"@@ -10,5 +12,6 @@ func main() {", 10, 12},
+		{"@@ -1 +1 @@", 1, 1},
+		{"@@ -0,0 +1,3 @@", 1, 1},
+		{"@@", 0, 0},
+	}
+	for _, tt := range tests {
+		if o, n := hunkStart(tt.header); o != tt.old || n != tt.new {
+			t.Errorf("hunkStart(%q) = %d, %d; want %d, %d", tt.header, o, n, tt.old, tt.new)
+		}
+	}
+}
+
+func TestRenderSplit_Wraps(t *testing.T) {
+	long := strings.Repeat("x", 50)
+	rows, _ := splitRows("@@ -1,1 +1,1 @@\n-short\n+" + long)
+	g := newSplitGeometry(rows, 43)
+	starts, total := g.rowStarts(rows, []int{0, 1})
+
+	// 20 columns a side: 1 digit, a space and the marker leave 17 for text,
+	// so the 50 x wrap to 3 lines
+	if total != 4 {
+		t.Fatalf("expected 4 lines, got %d", total)
+	}
+	if starts[0] != 0 || starts[1] != 1 {
+		t.Errorf("row starts = %v, want [0 1]", starts)
+	}
+	lines := g.renderRow(rows[1], renderCode("short", lineRemoved, nil, nil, nil), renderCode(long, lineAdded, nil, nil, nil), false)
+	if len(lines) != 3 {
+		t.Fatalf("expected the row in 3 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if w := text.Width(line); w > 43 {
+			t.Errorf("line %d is %d wide, more than 43", i+1, w)
+		}
+		if !strings.Contains(text.Strip(line), "│") {
+			t.Errorf("line %d has no separator: %q", i+1, text.Strip(line))
+		}
+	}
+}
+
+func TestToggleLayout_KeepsPosition(t *testing.T) {
+	var patch strings.Builder
+	patch.WriteString("@@ -1,40 +1,40 @@\n")
+	for i := 0; i < 40; i++ {
+		patch.WriteString("-old line\n+new line\n")
+	}
+
+	d := New()
+	d.SetSize(120, 20)
+	d.Show(nil, 0)
+	d.SetDiff(patch.String(), false)
+
+	// Patch line 21 is the removal of the 11th pair
+	d.viewport.SetYOffset(21)
+	d.ToggleLayout()
+	if !d.Split() {
+		t.Fatal("expected the split layout")
+	}
+	if got := d.viewport.YOffset; got != 11 {
+		t.Errorf("split offset = %d, want 11 (hunk row + 10 pairs)", got)
+	}
+
+	d.ToggleLayout()
+	if got := d.viewport.YOffset; got != 21 {
+		t.Errorf("unified offset = %d, want 21", got)
+	}
+}
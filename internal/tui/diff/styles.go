@@ -1,52 +1,163 @@
 package diff
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
 
 var (
 	// Container style with border
-	ContainerStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(0, 1)
+	ContainerStyle lipgloss.Style
 
 	// Header style
-	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205"))
+	HeaderStyle lipgloss.Style
 
 	// File path in header
-	FilePathStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
+	FilePathStyle lipgloss.Style
 
 	// Stats in header
+	AdditionsStyle lipgloss.Style
+	DeletionsStyle lipgloss.Style
+
+	// Diff line styles
+	DiffAddedStyle   lipgloss.Style
+	DiffDeletedStyle lipgloss.Style
+	DiffHunkStyle    lipgloss.Style
+	DiffContextStyle lipgloss.Style
+
+	// Changed words within paired removed and added lines
+	DiffAddedEmphStyle   lipgloss.Style
+	DiffDeletedEmphStyle lipgloss.Style
+
+	// Line numbers and the column separator of the split layout
+	DiffLineNumberStyle lipgloss.Style
+
+	// Search matches, and the lines hidden by a fold
+	DiffMatchStyle lipgloss.Style
+	DiffFoldStyle  lipgloss.Style
+
+	// Footer style
+	FooterStyle lipgloss.Style
+
+	// Transient message in the footer (e.g. after yanking)
+	StatusStyle lipgloss.Style
+
+	// File indicator style
+	FileIndicatorStyle lipgloss.Style
+
+	// Loading/error style
+	InfoStyle lipgloss.Style
+)
+
+// Styles of syntax highlighted code, by lineKind: the token colors over the
+// background of added and removed lines
+var (
+	codeStyles       [3][numClasses]lipgloss.Style
+	codeEmphStyles   [3]lipgloss.Style
+	codeMarkerStyles [3]lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	ContainerStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+
+	HeaderStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Accent)
+
+	FilePathStyle = lipgloss.NewStyle().
+		Foreground(t.Text)
+
 	AdditionsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#50FA7B"))
+		Foreground(t.DiffAdded)
 
 	DeletionsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5555"))
+		Foreground(t.DiffDeleted)
 
-	// Diff line styles
 	DiffAddedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#50FA7B"))
+		Foreground(t.DiffAdded)
 
 	DiffDeletedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FF5555"))
+		Foreground(t.DiffDeleted)
 
 	DiffHunkStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6272A4"))
+		Foreground(t.DiffHunk)
 
 	DiffContextStyle = lipgloss.NewStyle()
 
-	// Footer style
+	DiffAddedEmphStyle = DiffAddedStyle.
+		Reverse(true)
+
+	DiffDeletedEmphStyle = DiffDeletedStyle.
+		Reverse(true)
+
+	DiffLineNumberStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	DiffMatchStyle = lipgloss.NewStyle().
+		Background(t.Match).
+		Foreground(t.BadgeText)
+	if t.NoColor {
+		DiffMatchStyle = lipgloss.NewStyle().Reverse(true)
+	}
+
+	DiffFoldStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Italic(true)
+
 	FooterStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+		Foreground(t.Muted)
+
+	StatusStyle = lipgloss.NewStyle().
+		Foreground(t.Success)
 
-	// File indicator style
 	FileIndicatorStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("241"))
+		Foreground(t.Muted)
 
-	// Loading/error style
 	InfoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
-			Italic(true)
-)
+		Foreground(t.Muted).
+		Italic(true)
+
+	syntax := [numClasses]lipgloss.Style{
+		classPlain:    lipgloss.NewStyle(),
+		classKeyword:  lipgloss.NewStyle().Foreground(t.Keyword),
+		classString:   lipgloss.NewStyle().Foreground(t.String),
+		classComment:  lipgloss.NewStyle().Foreground(t.Comment),
+		classNumber:   lipgloss.NewStyle().Foreground(t.Number),
+		classFunction: lipgloss.NewStyle().Foreground(t.Function),
+		classType:     lipgloss.NewStyle().Foreground(t.Type),
+	}
+	backgrounds := [3]lipgloss.Style{
+		lineContext: lipgloss.NewStyle(),
+		lineRemoved: lipgloss.NewStyle().Background(t.DiffDeletedBg),
+		lineAdded:   lipgloss.NewStyle().Background(t.DiffAddedBg),
+	}
+	for kind, bg := range backgrounds {
+		for class, fg := range syntax {
+			codeStyles[kind][class] = bg.Inherit(fg)
+		}
+	}
+	codeMarkerStyles = [3]lipgloss.Style{
+		lineContext: DiffContextStyle,
+		lineRemoved: backgrounds[lineRemoved].Foreground(t.DiffDeleted),
+		lineAdded:   backgrounds[lineAdded].Foreground(t.DiffAdded),
+	}
+	// Changed words take the full color of their line, or reverse video
+	// without colors
+	codeEmphStyles = [3]lipgloss.Style{
+		lineContext: DiffContextStyle,
+		lineRemoved: lipgloss.NewStyle().Background(t.DiffDeleted).Foreground(t.BadgeText),
+		lineAdded:   lipgloss.NewStyle().Background(t.DiffAdded).Foreground(t.BadgeText),
+	}
+	if t.NoColor {
+		codeEmphStyles[lineRemoved] = lipgloss.NewStyle().Reverse(true)
+		codeEmphStyles[lineAdded] = lipgloss.NewStyle().Reverse(true)
+	}
+}
@@ -0,0 +1,181 @@
+package diff
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// syntaxChunk is the most lines lexed at once. Hunks are lexed from their
+// start, so strings and comments opened earlier in a hunk are known, but
+// a hunk of a large new file is lexed a chunk at a time.
+const syntaxChunk = 1000
+
+// tokenClass picks the theme color of a token
+type tokenClass int
+
+const (
+	classPlain tokenClass = iota
+	classKeyword
+	classString
+	classComment
+	classNumber
+	classFunction
+	classType
+	numClasses
+)
+
+// token is a piece of a line of code with its class
+type token struct {
+	text  string
+	class tokenClass
+}
+
+// highlighter lexes the code in a patch with the lexer for its file. Each
+// side of a hunk is lexed on its own: the old side from its context and
+// removed lines, the new side from its context and added lines.
+type highlighter struct {
+	lexer     chroma.Lexer
+	lines     []string
+	hunkStart []int           // the @@ line each patch line is under, -1 if none
+	tokens    map[int][]token // by patch line
+	lexed     map[int]bool    // chunks lexed, by first patch line
+}
+
+// newHighlighter returns a highlighter for a patch of the file at path, or
+// nil when no lexer matches its name
+func newHighlighter(path string, lines []string) *highlighter {
+	lexer := lexers.Match(filepath.Base(path))
+	if lexer == nil {
+		return nil
+	}
+	h := &highlighter{
+		lexer:     chroma.Coalesce(lexer),
+		lines:     lines,
+		hunkStart: make([]int, len(lines)),
+		tokens:    make(map[int][]token),
+		lexed:     make(map[int]bool),
+	}
+	start := -1
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			start = i
+		case strings.HasPrefix(line, "diff "):
+			start = -1
+		}
+		h.hunkStart[i] = start
+	}
+	return h
+}
+
+// lineTokens returns the tokens of a line of code without its +/-/space
+// prefix, or nil for lines outside hunks. Lines the lexer got wrong are a
+// single plain token.
+func (h *highlighter) lineTokens(i int) []token {
+	start := h.hunkStart[i]
+	if start < 0 || i == start || h.lines[i] == "" {
+		return nil
+	}
+	first := start + 1 + (i-start-1)/syntaxChunk*syntaxChunk
+	if !h.lexed[first] {
+		h.lexed[first] = true
+		h.lexChunk(first)
+	}
+	if tokens := h.tokens[i]; tokens != nil {
+		return tokens
+	}
+	return []token{{text: h.lines[i][1:]}}
+}
+
+// lexChunk lexes both sides of the lines from first to the end of the hunk
+// or chunk
+func (h *highlighter) lexChunk(first int) {
+	var old, new []int // patch lines of each side
+	for i := first; i < len(h.lines) && i < first+syntaxChunk; i++ {
+		line := h.lines[i]
+		if strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "diff ") {
+			break
+		}
+		switch {
+		case strings.HasPrefix(line, " "):
+			old = append(old, i)
+			new = append(new, i)
+		case strings.HasPrefix(line, "-"):
+			old = append(old, i)
+		case strings.HasPrefix(line, "+"):
+			new = append(new, i)
+		}
+	}
+	// Context lines get the tokens of the new side
+	h.lexSide(old)
+	h.lexSide(new)
+}
+
+// lexSide lexes lines as one text and splits the tokens back into lines
+func (h *highlighter) lexSide(lines []int) {
+	if len(lines) == 0 {
+		return
+	}
+	code := make([]string, len(lines))
+	for k, i := range lines {
+		code[k] = h.lines[i][1:]
+	}
+	it, err := h.lexer.Tokenise(nil, strings.Join(code, "\n"))
+	if err != nil {
+		return
+	}
+
+	k := 0
+	var current []token
+	for _, t := range it.Tokens() {
+		parts := strings.Split(t.Value, "\n")
+		for p, part := range parts {
+			if p > 0 {
+				h.setTokens(lines, k, code, current)
+				current = nil
+				k++
+			}
+			if part != "" {
+				current = append(current, token{text: part, class: classify(t.Type)})
+			}
+		}
+	}
+	h.setTokens(lines, k, code, current)
+}
+
+// setTokens stores the tokens of the k-th line of a side, if they spell
+// out the line; lexers that rewrite text are not trusted
+func (h *highlighter) setTokens(lines []int, k int, code []string, tokens []token) {
+	if k >= len(lines) {
+		return
+	}
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteString(t.text)
+	}
+	if b.String() == code[k] {
+		h.tokens[lines[k]] = tokens
+	}
+}
+
+// classify maps a chroma token type to the class that colors it
+func classify(t chroma.TokenType) tokenClass {
+	switch {
+	case t == chroma.KeywordType || t == chroma.NameClass || t == chroma.NameBuiltin:
+		return classType
+	case t.InCategory(chroma.Keyword):
+		return classKeyword
+	case t.InCategory(chroma.Comment):
+		return classComment
+	case t.InSubCategory(chroma.LiteralString):
+		return classString
+	case t.InSubCategory(chroma.LiteralNumber):
+		return classNumber
+	case t == chroma.NameFunction || t == chroma.NameFunctionMagic:
+		return classFunction
+	}
+	return classPlain
+}
@@ -0,0 +1,83 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHighlighter_Sides(t *testing.T) {
+	patch := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ func main() {
+-	/* old
++	s := "new"
+ 	x := 1 // done
+`
+	lines := strings.Split(patch, "\n")
+	h := newHighlighter("internal/main.go", lines)
+	if h == nil {
+		t.Fatal("expected a Go highlighter")
+	}
+	if tokens := h.lineTokens(1); tokens != nil {
+		t.Errorf("header line should have no tokens, got %v", tokens)
+	}
+
+	classes := func(i int) map[tokenClass]bool {
+		var b strings.Builder
+		found := make(map[tokenClass]bool)
+		for _, tok := range h.lineTokens(i) {
+			b.WriteString(tok.text)
+			found[tok.class] = true
+		}
+		if b.String() != lines[i][1:] {
+			t.Errorf("tokens of line %d spell %q, want %q", i, b.String(), lines[i][1:])
+		}
+		return found
+	}
+	if !classes(4)[classKeyword] {
+		t.Error("func should be a keyword")
+	}
+	if !classes(6)[classString] {
+		t.Error("\"new\" should be a string")
+	}
+	// The comment opened on the old side does not run into the new side
+	if got := classes(7); !got[classNumber] || !got[classComment] {
+		t.Errorf("context line should have a number and a comment, got %v", got)
+	}
+}
+
+func TestHighlighter_UnknownType(t *testing.T) {
+	if h := newHighlighter("notes.unknownext", []string{"@@ -1 +1 @@", "+x"}); h != nil {
+		t.Error("expected no highlighter for an unknown file type")
+	}
+}
+
+func TestView_RendersVisibleLines(t *testing.T) {
+	var patch strings.Builder
+	patch.WriteString("diff --git a/big.go b/big.go\n--- a/big.go\n+++ b/big.go\n@@ -1,10000 +1,10000 @@\n")
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&patch, "-\tx := %d\n+\tx := %d\n", i, i+1)
+	}
+
+	for _, layout := range []Layout{LayoutUnified, LayoutSplit} {
+		d := New()
+		d.SetLayout(layout)
+		d.SetSize(120, 30)
+		d.Show(nil, 0)
+		d.filePath = "big.go"
+		d.SetDiff(patch.String(), false)
+		d.viewport.SetYOffset(15000)
+		d.View()
+
+		rendered := len(d.cache.lines) + len(d.cache.rows)
+		if rendered == 0 || rendered > d.viewport.Height {
+			t.Errorf("layout %d rendered %d lines for a view of %d", layout, rendered, d.viewport.Height)
+		}
+		if lexed := len(d.syntax.lexed); lexed > 2 {
+			t.Errorf("layout %d lexed %d chunks, want the ones in view", layout, lexed)
+		}
+	}
+}
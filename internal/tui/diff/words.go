@@ -0,0 +1,198 @@
+package diff
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/text"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// WordDiff is how a removed and an added line are compared to emphasize
+// what changed between them
+type WordDiff int
+
+const (
+	WordDiffWord       WordDiff = iota // identifiers, numbers and whitespace runs; punctuation by character
+	WordDiffChar                       // each character
+	WordDiffWhitespace                 // words, with changes in whitespace ignored
+	WordDiffNone                       // no emphasis
+)
+
+// ParseWordDiff parses a word diff mode name, e.g. from the config file
+func ParseWordDiff(name string) (WordDiff, bool) {
+	switch strings.ToLower(name) {
+	case "", "word":
+		return WordDiffWord, true
+	case "char":
+		return WordDiffChar, true
+	case "whitespace":
+		return WordDiffWhitespace, true
+	case "none":
+		return WordDiffNone, true
+	}
+	return WordDiffWord, false
+}
+
+// maxWordDiffLine bounds the runes of lines compared word by word;
+// longer lines, e.g. minified code, are shown without emphasis
+const maxWordDiffLine = 1000
+
+// minWordDiffCommon is the share of the longer line a pair must have in
+// common to emphasize its changes; lines rewritten more than that would
+// be emphasized all over
+const minWordDiffCommon = 0.5
+
+// rowSpans returns the changed parts of the removed and the added line of
+// a change row, when it pairs them. Spans are rune offsets in the line
+// without its +/- prefix.
+func rowSpans(lines []string, r splitRow, mode WordDiff) ([]text.Span, []text.Span) {
+	if mode == WordDiffNone || r.kind != rowChange || !r.hasLeft || !r.hasRight {
+		return nil, nil
+	}
+	return changedSpans(lines[r.leftLine][1:], lines[r.rightLine][1:], mode)
+}
+
+// changedSpans compares two lines token by token and returns the parts of
+// each that are not in the other, or none when the lines have too little
+// in common
+func changedSpans(old, new string, mode WordDiff) ([]text.Span, []text.Span) {
+	if len(old) > maxWordDiffLine || len(new) > maxWordDiffLine {
+		return nil, nil
+	}
+	oldTokens, newTokens := tokenize(old, mode), tokenize(new, mode)
+
+	// Tokens are compared as single runes, like diffmatchpatch compares lines
+	ids := make(map[string]rune)
+	encode := func(tokens []string) string {
+		var b strings.Builder
+		for _, tok := range tokens {
+			key := tok
+			if mode == WordDiffWhitespace && isSpace(tok) {
+				key = " "
+			}
+			id, ok := ids[key]
+			if !ok {
+				// Private use area, so ids never collide with surrogates
+				id = rune(0xE000 + len(ids))
+				ids[key] = id
+			}
+			b.WriteRune(id)
+		}
+		return b.String()
+	}
+
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffCleanupSemantic(dmp.DiffMain(encode(oldTokens), encode(newTokens), false))
+
+	var oldSpans, newSpans []text.Span
+	oldPos, newPos, common := 0, 0, 0
+	for _, d := range diffs {
+		n := len([]rune(d.Text))
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			for _, tok := range oldTokens[:n] {
+				oldPos += len([]rune(tok))
+			}
+			for _, tok := range newTokens[:n] {
+				w := len([]rune(tok))
+				newPos += w
+				common += w
+			}
+		case diffmatchpatch.DiffDelete:
+			oldSpans, oldPos = addSpan(oldSpans, oldTokens[:n], oldPos, mode)
+		case diffmatchpatch.DiffInsert:
+			newSpans, newPos = addSpan(newSpans, newTokens[:n], newPos, mode)
+		}
+		if d.Type != diffmatchpatch.DiffInsert {
+			oldTokens = oldTokens[n:]
+		}
+		if d.Type != diffmatchpatch.DiffDelete {
+			newTokens = newTokens[n:]
+		}
+	}
+
+	longer := max(len([]rune(old)), len([]rune(new)))
+	if longer == 0 || float64(common) < minWordDiffCommon*float64(longer) {
+		return nil, nil
+	}
+	return oldSpans, newSpans
+}
+
+// addSpan adds the span of tokens starting at pos, unless it is only
+// whitespace changes that mode ignores, and returns the position after it
+func addSpan(spans []text.Span, tokens []string, pos int, mode WordDiff) ([]text.Span, int) {
+	start := pos
+	onlySpace := true
+	for _, tok := range tokens {
+		pos += len([]rune(tok))
+		onlySpace = onlySpace && isSpace(tok)
+	}
+	if start == pos || mode == WordDiffWhitespace && onlySpace {
+		return spans, pos
+	}
+	if n := len(spans); n > 0 && spans[n-1].End == start {
+		spans[n-1].End = pos
+		return spans, pos
+	}
+	return append(spans, text.Span{Start: start, End: pos}), pos
+}
+
+// tokenize splits a line into the units mode compares
+func tokenize(s string, mode WordDiff) []string {
+	if mode == WordDiffChar {
+		return strings.Split(s, "")
+	}
+	var tokens []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isSpace(tok string) bool {
+	return strings.TrimSpace(tok) == ""
+}
+
+// renderEmphasis renders s in base with the runes covered by spans in
+// emph, and its tabs expanded. Spans must be sorted and non-overlapping.
+func renderEmphasis(s string, spans []text.Span, base, emph lipgloss.Style) string {
+	if len(spans) == 0 {
+		return base.Render(expandTabs(s))
+	}
+	runes := []rune(s)
+	var b strings.Builder
+	pos := 0
+	for _, sp := range spans {
+		start, end := min(sp.Start, len(runes)), min(sp.End, len(runes))
+		if start > pos {
+			b.WriteString(base.Render(expandTabs(string(runes[pos:start]))))
+		}
+		if end > start {
+			b.WriteString(emph.Render(expandTabs(string(runes[start:end]))))
+		}
+		pos = max(pos, end)
+	}
+	if pos < len(runes) {
+		b.WriteString(base.Render(expandTabs(string(runes[pos:]))))
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+func TestChangedSpans(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		mode     WordDiff
+		wantOld  []text.Span
+		wantNew  []text.Span
+	}{
+		{
+			name: "identifier",
+			old:  "\tresult := parseLine(input)",
+			new:  "\tresult := parseLines(input)",
+			mode: WordDiffWord,
+			// The whole identifier, not just the added s
+			wantOld: []text.Span{{Start: 11, End: 20}},
+			wantNew: []text.Span{{Start: 11, End: 21}},
+		},
+		{
+			name:    "char",
+			old:     "\tresult := parseLine(input)",
+			new:     "\tresult := parseLines(input)",
+			mode:    WordDiffChar,
+			wantOld: nil,
+			wantNew: []text.Span{{Start: 20, End: 21}},
+		},
+		{
+			name: "whitespace counts",
+			old:  "a := b+c",
+			new:  "a := b + c",
+			mode: WordDiffWord,
+			// The operator goes with the spaces around it
+			wantOld: []text.Span{{Start: 6, End: 7}},
+			wantNew: []text.Span{{Start: 6, End: 9}},
+		},
+		{
+			name:    "whitespace ignored",
+			old:     "a  :=  b + c",
+			new:     "a := b + d",
+			mode:    WordDiffWhitespace,
+			wantOld: []text.Span{{Start: 11, End: 12}},
+			wantNew: []text.Span{{Start: 9, End: 10}},
+		},
+		{
+			name: "rewritten",
+			old:  "return nil",
+			new:  "log.Fatal(err)",
+			mode: WordDiffWord,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOld, gotNew := changedSpans(tt.old, tt.new, tt.mode)
+			if !reflect.DeepEqual(gotOld, tt.wantOld) || !reflect.DeepEqual(gotNew, tt.wantNew) {
+				t.Errorf("changedSpans = %v, %v; want %v, %v", gotOld, gotNew, tt.wantOld, tt.wantNew)
+			}
+		})
+	}
+}
+
+func TestRowSpans_PairsInOrder(t *testing.T) {
+	lines := strings.Split(testPatch, "\n")
+	rows, _ := splitRows(testPatch)
+	spans := make(map[int][]text.Span)
+	for _, r := range rows {
+		old, new := rowSpans(lines, r, WordDiffWord)
+		if old != nil {
+			spans[r.leftLine] = old
+		}
+		if new != nil {
+			spans[r.rightLine] = new
+		}
+		if old, new := rowSpans(lines, r, WordDiffNone); old != nil || new != nil {
+			t.Errorf("WordDiffNone should emphasize nothing, got %v, %v", old, new)
+		}
+	}
+
+	// Removed lines pair with the added lines in order; e := 5 has no pair
+	want := map[int][]text.Span{
+		5:  {{Start: 6, End: 7}},
+		6:  {{Start: 1, End: 2}, {Start: 6, End: 7}},
+		7:  {{Start: 6, End: 8}},
+		8:  {{Start: 1, End: 2}, {Start: 6, End: 7}},
+		11: {{Start: 1, End: 2}},
+		12: {{Start: 1, End: 2}},
+	}
+	if !reflect.DeepEqual(spans, want) {
+		t.Errorf("rowSpans = %v, want %v", spans, want)
+	}
+}
+
+func TestRenderEmphasis(t *testing.T) {
+	got := renderEmphasis("\ta := 1", []text.Span{{Start: 1, End: 2}}, DiffContextStyle, DiffContextStyle)
+	if text.Strip(got) != "    a := 1" {
+		t.Errorf("renderEmphasis = %q, want the text with its tab expanded", text.Strip(got))
+	}
+}
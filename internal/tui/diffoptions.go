@@ -0,0 +1,32 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// handleDiffOptionKey changes a diff option and reloads the diff with it.
+// Returns false if the key is not a diff option.
+func (m *Model) handleDiffOptionKey(key string) (bool, tea.Cmd) {
+	opts := m.diffView.Options()
+	switch m.keys.Lookup(keys.ModeDiff, key) {
+	case keys.DiffIgnoreAllSpace:
+		opts.IgnoreAllSpace = !opts.IgnoreAllSpace
+	case keys.DiffIgnoreSpaceChange:
+		opts.IgnoreSpaceChange = !opts.IgnoreSpaceChange
+	case keys.DiffIgnoreBlankLines:
+		opts.IgnoreBlankLines = !opts.IgnoreBlankLines
+	case keys.DiffMoreContext:
+		opts.Context++
+	case keys.DiffLessContext:
+		opts.Context--
+	case keys.DiffFunctionContext:
+		opts.FunctionContext = !opts.FunctionContext
+	default:
+		return false, nil
+	}
+	if m.diffView.SetOptions(opts) {
+		return true, m.loadFileDiff()
+	}
+	return true, nil
+}
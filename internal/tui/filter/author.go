@@ -8,6 +8,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/glob"
+	"github.com/nogo/gitree/internal/tui/keys"
 )
 
 type AuthorEntry struct {
@@ -19,10 +21,12 @@ type AuthorEntry struct {
 type AuthorFilter struct {
 	authors      []AuthorEntry
 	selected     map[string]bool // normalized name → selected
+	excluded     map[string]bool // normalized name → commits hidden
 	cursor       int
 	scrollOffset int
 	width        int
 	height       int
+	keys         keys.Map
 }
 
 func NewAuthorFilter(commits []domain.Commit) AuthorFilter {
@@ -67,9 +71,16 @@ func NewAuthorFilter(commits []domain.Commit) AuthorFilter {
 	return AuthorFilter{
 		authors:  authors,
 		selected: selected,
+		excluded: make(map[string]bool),
+		keys:     keys.Default(),
 	}
 }
 
+// SetKeyMap sets the key bindings
+func (f *AuthorFilter) SetKeyMap(km keys.Map) {
+	f.keys = km
+}
+
 // normalizeName normalizes author name for grouping
 func normalizeName(name string) string {
 	return strings.ToLower(strings.TrimSpace(name))
@@ -112,36 +123,49 @@ func (f *AuthorFilter) adjustScroll() {
 func (f AuthorFilter) Update(msg tea.Msg) (AuthorFilter, tea.Cmd, bool, bool) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "j", "down":
+		switch key := msg.String(); {
+		case f.keys.Is(key, keys.FilterDown):
 			if f.cursor < len(f.authors)-1 {
 				f.cursor++
 				f.adjustScroll()
 			}
-		case "k", "up":
+		case f.keys.Is(key, keys.FilterUp):
 			if f.cursor > 0 {
 				f.cursor--
 				f.adjustScroll()
 			}
-		case " ", "space":
-			// Toggle current author
+		case f.keys.Is(key, keys.FilterToggle):
+			// Toggle current author (an excluded author becomes selected)
+			if f.cursor < len(f.authors) {
+				name := f.authors[f.cursor].Name
+				if f.excluded[name] {
+					delete(f.excluded, name)
+					f.selected[name] = true
+				} else {
+					f.selected[name] = !f.selected[name]
+				}
+			}
+		case f.keys.Is(key, keys.FilterExclude):
+			// Toggle exclusion of current author
 			if f.cursor < len(f.authors) {
 				name := f.authors[f.cursor].Name
-				f.selected[name] = !f.selected[name]
+				f.SetExcluded(name, !f.excluded[name])
 			}
-		case "a":
+		case f.keys.Is(key, keys.FilterAll):
 			// Select all
 			for name := range f.selected {
 				f.selected[name] = true
 			}
-		case "n":
+			clear(f.excluded)
+		case f.keys.Is(key, keys.FilterNone):
 			// Select none
 			for name := range f.selected {
 				f.selected[name] = false
 			}
-		case "enter":
+			clear(f.excluded)
+		case f.keys.Is(key, keys.FilterApply):
 			return f, nil, true, false // Done, apply filter
-		case "esc":
+		case f.keys.Is(key, keys.FilterCancel):
 			return f, nil, false, true // Cancelled
 		}
 	}
@@ -151,7 +175,7 @@ func (f AuthorFilter) Update(msg tea.Msg) (AuthorFilter, tea.Cmd, bool, bool) {
 func (f AuthorFilter) View() string {
 	var lines []string
 	lines = append(lines, TitleStyle.Render("Filter authors"))
-	lines = append(lines, HintStyle.Render("space=toggle  a=all  n=none"))
+	lines = append(lines, HintStyle.Render(checklistHint(f.keys)))
 	lines = append(lines, "")
 
 	maxVisible := f.maxVisibleItems()
@@ -171,7 +195,9 @@ func (f AuthorFilter) View() string {
 	for i := f.scrollOffset; i < endIdx; i++ {
 		a := f.authors[i]
 		checkbox := UncheckedStyle.Render("[ ]")
-		if f.selected[a.Name] {
+		if f.excluded[a.Name] {
+			checkbox = ExcludedStyle.Render("[!]")
+		} else if f.selected[a.Name] {
 			checkbox = CheckedStyle.Render("[x]")
 		}
 
@@ -234,6 +260,7 @@ func (f *AuthorFilter) Reset() {
 	for name := range f.selected {
 		f.selected[name] = true
 	}
+	clear(f.excluded)
 }
 
 // UpdateAuthors updates the author list (e.g., after repo refresh)
@@ -284,6 +311,11 @@ func (f *AuthorFilter) UpdateAuthors(commits []domain.Commit) {
 	for _, a := range authors {
 		authorSet[a.Name] = true
 	}
+	for name := range f.excluded {
+		if !authorSet[name] {
+			delete(f.excluded, name)
+		}
+	}
 	for name := range f.selected {
 		if !authorSet[name] {
 			delete(f.selected, name)
@@ -322,23 +354,114 @@ func (f *AuthorFilter) SelectNone() {
 	}
 }
 
-// SelectMatching selects authors whose name contains the given string (case-insensitive)
-func (f *AuthorFilter) SelectMatching(name string) {
-	name = strings.ToLower(name)
+// SelectMatching selects authors whose name or email matches the pattern
+// (substring or glob, case-insensitive)
+func (f *AuthorFilter) SelectMatching(pattern glob.Pattern) {
 	for _, a := range f.authors {
-		if strings.Contains(a.Name, name) {
+		if a.matches(pattern) {
 			f.selected[a.Name] = true
 		}
 	}
 }
 
-// HasMatching returns true if any author matches the given name
-func (f AuthorFilter) HasMatching(name string) bool {
-	name = strings.ToLower(name)
+// HasMatching returns true if any author name or email matches the pattern
+func (f AuthorFilter) HasMatching(pattern glob.Pattern) bool {
+	for _, a := range f.authors {
+		if a.matches(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExcludeMatching excludes authors whose name or email matches the pattern.
+// Returns the number of authors excluded.
+func (f *AuthorFilter) ExcludeMatching(pattern glob.Pattern) int {
+	count := 0
 	for _, a := range f.authors {
-		if strings.Contains(a.Name, name) {
+		if a.matches(pattern) {
+			f.SetExcluded(a.Name, true)
+			count++
+		}
+	}
+	return count
+}
+
+// matches reports whether the author's name or any email matches the pattern
+func (a AuthorEntry) matches(pattern glob.Pattern) bool {
+	if pattern.Match(a.Name) {
+		return true
+	}
+	for _, email := range a.Emails {
+		if pattern.Match(email) {
 			return true
 		}
 	}
 	return false
 }
+
+// SelectedNames returns the normalized names of selected authors
+func (f AuthorFilter) SelectedNames() []string {
+	var result []string
+	for _, a := range f.authors {
+		if f.selected[a.Name] {
+			result = append(result, a.Name)
+		}
+	}
+	return result
+}
+
+// SetSelected sets the selection state for an author by name (case-insensitive).
+// Returns false if no such author exists.
+func (f *AuthorFilter) SetSelected(name string, selected bool) bool {
+	name = normalizeName(name)
+	if _, exists := f.selected[name]; !exists {
+		return false
+	}
+	f.selected[name] = selected
+	return true
+}
+
+// SetExcluded marks an author as excluded or clears the mark (case-insensitive).
+// Excluded authors are never selected. Returns false if no such author exists.
+func (f *AuthorFilter) SetExcluded(name string, excluded bool) bool {
+	name = normalizeName(name)
+	if _, exists := f.selected[name]; !exists {
+		return false
+	}
+	if excluded {
+		f.excluded[name] = true
+		f.selected[name] = false
+	} else {
+		delete(f.excluded, name)
+		f.selected[name] = true
+	}
+	return true
+}
+
+// ExcludedNames returns the normalized names of excluded authors
+func (f AuthorFilter) ExcludedNames() []string {
+	var result []string
+	for _, a := range f.authors {
+		if f.excluded[a.Name] {
+			result = append(result, a.Name)
+		}
+	}
+	return result
+}
+
+// ExcludedEmails returns all emails of excluded authors
+func (f AuthorFilter) ExcludedEmails() []string {
+	var result []string
+	for _, a := range f.authors {
+		if f.excluded[a.Name] {
+			result = append(result, a.Emails...)
+		}
+	}
+	return result
+}
+
+// HasExclusions returns true if any author is excluded
+func (f AuthorFilter) HasExclusions() bool {
+	return len(f.excluded) > 0
+}
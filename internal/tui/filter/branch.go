@@ -7,15 +7,18 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
 )
 
 type BranchFilter struct {
 	branches     []domain.Branch
 	selected     map[string]bool // branch name → visible
+	excluded     map[string]bool // branch name → commits reachable from it are hidden
 	cursor       int
 	scrollOffset int
 	width        int
 	height       int
+	keys         keys.Map
 }
 
 func NewBranchFilter(branches []domain.Branch) BranchFilter {
@@ -26,9 +29,16 @@ func NewBranchFilter(branches []domain.Branch) BranchFilter {
 	return BranchFilter{
 		branches: branches,
 		selected: selected,
+		excluded: make(map[string]bool),
+		keys:     keys.Default(),
 	}
 }
 
+// SetKeyMap sets the key bindings
+func (f *BranchFilter) SetKeyMap(km keys.Map) {
+	f.keys = km
+}
+
 func (f *BranchFilter) SetSize(w, h int) {
 	f.width = w
 	f.height = h
@@ -66,36 +76,49 @@ func (f *BranchFilter) adjustScroll() {
 func (f BranchFilter) Update(msg tea.Msg) (BranchFilter, tea.Cmd, bool, bool) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "j", "down":
+		switch key := msg.String(); {
+		case f.keys.Is(key, keys.FilterDown):
 			if f.cursor < len(f.branches)-1 {
 				f.cursor++
 				f.adjustScroll()
 			}
-		case "k", "up":
+		case f.keys.Is(key, keys.FilterUp):
 			if f.cursor > 0 {
 				f.cursor--
 				f.adjustScroll()
 			}
-		case " ", "space":
-			// Toggle current branch
+		case f.keys.Is(key, keys.FilterToggle):
+			// Toggle current branch (an excluded branch becomes selected)
+			if f.cursor < len(f.branches) {
+				name := f.branches[f.cursor].Name
+				if f.excluded[name] {
+					delete(f.excluded, name)
+					f.selected[name] = true
+				} else {
+					f.selected[name] = !f.selected[name]
+				}
+			}
+		case f.keys.Is(key, keys.FilterExclude):
+			// Toggle exclusion of current branch
 			if f.cursor < len(f.branches) {
 				name := f.branches[f.cursor].Name
-				f.selected[name] = !f.selected[name]
+				f.SetExcluded(name, !f.excluded[name])
 			}
-		case "a":
+		case f.keys.Is(key, keys.FilterAll):
 			// Select all
 			for name := range f.selected {
 				f.selected[name] = true
 			}
-		case "n":
+			clear(f.excluded)
+		case f.keys.Is(key, keys.FilterNone):
 			// Select none
 			for name := range f.selected {
 				f.selected[name] = false
 			}
-		case "enter":
+			clear(f.excluded)
+		case f.keys.Is(key, keys.FilterApply):
 			return f, nil, true, false // Done, apply filter
-		case "esc":
+		case f.keys.Is(key, keys.FilterCancel):
 			return f, nil, false, true // Cancelled
 		}
 	}
@@ -105,7 +128,7 @@ func (f BranchFilter) Update(msg tea.Msg) (BranchFilter, tea.Cmd, bool, bool) {
 func (f BranchFilter) View() string {
 	var lines []string
 	lines = append(lines, TitleStyle.Render("Filter branches"))
-	lines = append(lines, HintStyle.Render("space=toggle  a=all  n=none"))
+	lines = append(lines, HintStyle.Render(checklistHint(f.keys)))
 	lines = append(lines, "")
 
 	maxVisible := f.maxVisibleItems()
@@ -125,7 +148,9 @@ func (f BranchFilter) View() string {
 	for i := f.scrollOffset; i < endIdx; i++ {
 		b := f.branches[i]
 		checkbox := UncheckedStyle.Render("[ ]")
-		if f.selected[b.Name] {
+		if f.excluded[b.Name] {
+			checkbox = ExcludedStyle.Render("[!]")
+		} else if f.selected[b.Name] {
 			checkbox = CheckedStyle.Render("[x]")
 		}
 
@@ -189,6 +214,7 @@ func (f *BranchFilter) Reset() {
 	for name := range f.selected {
 		f.selected[name] = true
 	}
+	clear(f.excluded)
 }
 
 // SetSelected sets the selection state for a specific branch
@@ -210,6 +236,11 @@ func (f *BranchFilter) UpdateBranches(branches []domain.Branch) {
 	for _, b := range branches {
 		branchSet[b.Name] = true
 	}
+	for name := range f.excluded {
+		if !branchSet[name] {
+			delete(f.excluded, name)
+		}
+	}
 	for name := range f.selected {
 		if !branchSet[name] {
 			delete(f.selected, name)
@@ -223,3 +254,29 @@ func (f *BranchFilter) UpdateBranches(branches []domain.Branch) {
 		f.cursor = 0
 	}
 }
+
+// SetExcluded marks a branch as excluded (hiding every commit reachable from
+// it) or clears the mark. Excluded branches are never selected.
+func (f *BranchFilter) SetExcluded(name string, excluded bool) {
+	if excluded {
+		f.excluded[name] = true
+		f.selected[name] = false
+		return
+	}
+	delete(f.excluded, name)
+	f.selected[name] = true
+}
+
+// ExcludedBranches returns the names of excluded branches
+func (f BranchFilter) ExcludedBranches() []string {
+	var result []string
+	for name := range f.excluded {
+		result = append(result, name)
+	}
+	return result
+}
+
+// HasExclusions returns true if any branch is excluded
+func (f BranchFilter) HasExclusions() bool {
+	return len(f.excluded) > 0
+}
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
 )
 
 // AuthorHighlight provides single-select author highlighting
@@ -17,6 +18,7 @@ type AuthorHighlight struct {
 	selectedName string // empty = no highlight (None option)
 	width        int
 	height       int
+	keys         keys.Map
 }
 
 // NewAuthorHighlight creates a highlight selector from commits
@@ -61,9 +63,15 @@ func NewAuthorHighlight(commits []domain.Commit) AuthorHighlight {
 		authors:      authors,
 		cursor:       0, // Start on "None"
 		selectedName: "",
+		keys:         keys.Default(),
 	}
 }
 
+// SetKeyMap sets the key bindings
+func (h *AuthorHighlight) SetKeyMap(km keys.Map) {
+	h.keys = km
+}
+
 func (h *AuthorHighlight) SetSize(w, height int) {
 	h.width = w
 	h.height = height
@@ -107,19 +115,19 @@ func (h *AuthorHighlight) adjustScroll() {
 func (h AuthorHighlight) Update(msg tea.Msg) (AuthorHighlight, tea.Cmd, bool, bool) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "j", "down":
+		switch key := msg.String(); {
+		case h.keys.Is(key, keys.FilterDown):
 			// cursor 0 = None, 1..N = authors
 			if h.cursor < len(h.authors) {
 				h.cursor++
 				h.adjustScroll()
 			}
-		case "k", "up":
+		case h.keys.Is(key, keys.FilterUp):
 			if h.cursor > 0 {
 				h.cursor--
 				h.adjustScroll()
 			}
-		case "enter", " ", "space":
+		case h.keys.Is(key, keys.FilterApply), h.keys.Is(key, keys.FilterToggle):
 			// Select current option
 			if h.cursor == 0 {
 				h.selectedName = "" // None
@@ -127,7 +135,7 @@ func (h AuthorHighlight) Update(msg tea.Msg) (AuthorHighlight, tea.Cmd, bool, bo
 				h.selectedName = h.authors[h.cursor-1].Name
 			}
 			return h, nil, true, false // Done, apply
-		case "esc":
+		case h.keys.Is(key, keys.FilterCancel):
 			return h, nil, false, true // Cancelled
 		}
 	}
@@ -137,7 +145,7 @@ func (h AuthorHighlight) Update(msg tea.Msg) (AuthorHighlight, tea.Cmd, bool, bo
 func (h AuthorHighlight) View() string {
 	var lines []string
 	lines = append(lines, TitleStyle.Render("Highlight Author"))
-	lines = append(lines, HintStyle.Render("j/k=move  Enter=select"))
+	lines = append(lines, HintStyle.Render(fmt.Sprintf("%s/%s=move  %s=select", h.keys.Label(keys.FilterDown), h.keys.Label(keys.FilterUp), h.keys.Label(keys.FilterApply))))
 	lines = append(lines, "")
 
 	maxVisible := h.maxVisibleItems()
@@ -236,6 +244,25 @@ func (h AuthorHighlight) IsActive() bool {
 	return h.selectedName != ""
 }
 
+// SelectedName returns the normalized name of the highlighted author (empty if None)
+func (h AuthorHighlight) SelectedName() string {
+	return h.selectedName
+}
+
+// SetHighlighted highlights the author with the given name (case-insensitive).
+// Returns false if no such author exists.
+func (h *AuthorHighlight) SetHighlighted(name string) bool {
+	name = normalizeName(name)
+	for i, a := range h.authors {
+		if a.Name == name {
+			h.selectedName = name
+			h.cursor = i + 1 // +1 for "None" option
+			return true
+		}
+	}
+	return false
+}
+
 // Reset clears the highlight
 func (h *AuthorHighlight) Reset() {
 	h.selectedName = ""
@@ -304,9 +331,6 @@ func (h *AuthorHighlight) UpdateAuthors(commits []domain.Commit) {
 
 // Radio button styles
 var (
-	RadioOnStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("156"))
-
-	RadioOffStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+	RadioOnStyle  lipgloss.Style
+	RadioOffStyle lipgloss.Style
 )
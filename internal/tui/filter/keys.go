@@ -0,0 +1,13 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// checklistHint describes the keys of the branch, author and tag overlays
+func checklistHint(km keys.Map) string {
+	return fmt.Sprintf("%s=toggle  %s=exclude  %s=all  %s=none",
+		km.Label(keys.FilterToggle), km.Label(keys.FilterExclude), km.Label(keys.FilterAll), km.Label(keys.FilterNone))
+}
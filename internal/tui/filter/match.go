@@ -0,0 +1,30 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/nogo/gitree/internal/glob"
+)
+
+// ParsePatterns splits a comma-separated filter value into include patterns
+// and exclude patterns (prefixed with '!'), compiled for matching. Empty
+// entries are dropped. Returns an error for a malformed glob.
+func ParsePatterns(value string) (include, exclude []glob.Pattern, err error) {
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(p)
+		list := &include
+		if strings.HasPrefix(p, "!") {
+			p = strings.TrimSpace(p[1:])
+			list = &exclude
+		}
+		if p == "" {
+			continue
+		}
+		compiled, err := glob.Compile(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		*list = append(*list, compiled)
+	}
+	return include, exclude, nil
+}
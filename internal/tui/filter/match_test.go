@@ -0,0 +1,23 @@
+package filter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParsePatterns(t *testing.T) {
+	include, exclude, err := ParsePatterns("alice, !bot@*,,! renovate ,bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(include); got != "[alice bob]" {
+		t.Errorf("include = %s, want [alice bob]", got)
+	}
+	if got := fmt.Sprint(exclude); got != "[bot@* renovate]" {
+		t.Errorf("exclude = %s, want [bot@* renovate]", got)
+	}
+
+	if _, _, err := ParsePatterns("alice,!v[1"); err == nil {
+		t.Error("ParsePatterns with an unclosed '[' = nil error, want one")
+	}
+}
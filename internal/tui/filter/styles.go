@@ -1,27 +1,49 @@
 package filter
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
 
 var (
-	FilterStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(1, 2)
+	FilterStyle    lipgloss.Style
+	TitleStyle     lipgloss.Style
+	SelectedStyle  lipgloss.Style
+	CheckedStyle   lipgloss.Style
+	UncheckedStyle lipgloss.Style
+	ExcludedStyle  lipgloss.Style
+	HintStyle      lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles of the filter and highlight overlays from t
+func SetTheme(t theme.Theme) {
+	FilterStyle = t.Overlay()
 
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205"))
+		Bold(true).
+		Foreground(t.Accent)
 
-	SelectedStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("237")).
-			Foreground(lipgloss.Color("255"))
+	SelectedStyle = t.Selected()
 
 	CheckedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("156"))
+		Foreground(t.Checked)
 
 	UncheckedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+		Foreground(t.Muted)
+
+	ExcludedStyle = lipgloss.NewStyle().
+		Foreground(t.Deleted)
 
 	HintStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
-)
+		Foreground(t.Muted)
+
+	RadioOnStyle = lipgloss.NewStyle().
+		Foreground(t.Checked)
+
+	RadioOffStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+}
@@ -8,15 +8,19 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/glob"
+	"github.com/nogo/gitree/internal/tui/keys"
 )
 
 type TagFilter struct {
 	tags         []string
 	selected     map[string]bool // tag name → selected
+	excluded     map[string]bool // tag name → commits reachable from it are hidden
 	cursor       int
 	scrollOffset int
 	width        int
 	height       int
+	keys         keys.Map
 }
 
 func NewTagFilter(commits []domain.Commit) TagFilter {
@@ -45,9 +49,16 @@ func NewTagFilter(commits []domain.Commit) TagFilter {
 	return TagFilter{
 		tags:     tags,
 		selected: selected,
+		excluded: make(map[string]bool),
+		keys:     keys.Default(),
 	}
 }
 
+// SetKeyMap sets the key bindings
+func (f *TagFilter) SetKeyMap(km keys.Map) {
+	f.keys = km
+}
+
 func (f *TagFilter) SetSize(w, h int) {
 	f.width = w
 	f.height = h
@@ -81,36 +92,49 @@ func (f *TagFilter) adjustScroll() {
 func (f TagFilter) Update(msg tea.Msg) (TagFilter, tea.Cmd, bool, bool) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "j", "down":
+		switch key := msg.String(); {
+		case f.keys.Is(key, keys.FilterDown):
 			if f.cursor < len(f.tags)-1 {
 				f.cursor++
 				f.adjustScroll()
 			}
-		case "k", "up":
+		case f.keys.Is(key, keys.FilterUp):
 			if f.cursor > 0 {
 				f.cursor--
 				f.adjustScroll()
 			}
-		case " ", "space":
-			// Toggle current tag
+		case f.keys.Is(key, keys.FilterToggle):
+			// Toggle current tag (an excluded tag becomes selected)
+			if f.cursor < len(f.tags) {
+				name := f.tags[f.cursor]
+				if f.excluded[name] {
+					delete(f.excluded, name)
+					f.selected[name] = true
+				} else {
+					f.selected[name] = !f.selected[name]
+				}
+			}
+		case f.keys.Is(key, keys.FilterExclude):
+			// Toggle exclusion of current tag
 			if f.cursor < len(f.tags) {
 				name := f.tags[f.cursor]
-				f.selected[name] = !f.selected[name]
+				f.SetExcluded(name, !f.excluded[name])
 			}
-		case "a":
+		case f.keys.Is(key, keys.FilterAll):
 			// Select all
 			for name := range f.selected {
 				f.selected[name] = true
 			}
-		case "n":
+			clear(f.excluded)
+		case f.keys.Is(key, keys.FilterNone):
 			// Select none
 			for name := range f.selected {
 				f.selected[name] = false
 			}
-		case "enter":
+			clear(f.excluded)
+		case f.keys.Is(key, keys.FilterApply):
 			return f, nil, true, false // Done, apply filter
-		case "esc":
+		case f.keys.Is(key, keys.FilterCancel):
 			return f, nil, false, true // Cancelled
 		}
 	}
@@ -120,7 +144,7 @@ func (f TagFilter) Update(msg tea.Msg) (TagFilter, tea.Cmd, bool, bool) {
 func (f TagFilter) View() string {
 	var lines []string
 	lines = append(lines, TitleStyle.Render("Filter tags"))
-	lines = append(lines, HintStyle.Render("space=toggle  a=all  n=none"))
+	lines = append(lines, HintStyle.Render(checklistHint(f.keys)))
 	lines = append(lines, "")
 
 	if len(f.tags) == 0 {
@@ -157,7 +181,9 @@ func (f TagFilter) View() string {
 	for i := f.scrollOffset; i < endIdx; i++ {
 		tag := f.tags[i]
 		checkbox := UncheckedStyle.Render("[ ]")
-		if f.selected[tag] {
+		if f.excluded[tag] {
+			checkbox = ExcludedStyle.Render("[!]")
+		} else if f.selected[tag] {
 			checkbox = CheckedStyle.Render("[x]")
 		}
 
@@ -218,6 +244,7 @@ func (f *TagFilter) Reset() {
 	for tag := range f.selected {
 		f.selected[tag] = false
 	}
+	clear(f.excluded)
 }
 
 // UpdateTags updates the tag list (e.g., after repo refresh)
@@ -240,6 +267,11 @@ func (f *TagFilter) UpdateTags(commits []domain.Commit) {
 	f.tags = tags
 
 	// Remove tags that no longer exist
+	for tag := range f.excluded {
+		if !tagSet[tag] {
+			delete(f.excluded, tag)
+		}
+	}
 	for tag := range f.selected {
 		if !tagSet[tag] {
 			delete(f.selected, tag)
@@ -278,23 +310,80 @@ func (f TagFilter) TotalCount() int {
 	return len(f.tags)
 }
 
-// SelectMatching selects tags whose name contains the given string (case-insensitive)
-func (f *TagFilter) SelectMatching(name string) {
-	name = strings.ToLower(name)
+// SelectNone deselects all tags
+func (f *TagFilter) SelectNone() {
+	for tag := range f.selected {
+		f.selected[tag] = false
+	}
+}
+
+// SelectMatching selects tags matching the pattern (substring or glob, case-insensitive)
+func (f *TagFilter) SelectMatching(pattern glob.Pattern) {
 	for _, tag := range f.tags {
-		if strings.Contains(strings.ToLower(tag), name) {
+		if pattern.Match(tag) {
 			f.selected[tag] = true
 		}
 	}
 }
 
-// HasMatching returns true if any tag matches the given name
-func (f TagFilter) HasMatching(name string) bool {
-	name = strings.ToLower(name)
+// HasMatching returns true if any tag matches the pattern
+func (f TagFilter) HasMatching(pattern glob.Pattern) bool {
 	for _, tag := range f.tags {
-		if strings.Contains(strings.ToLower(tag), name) {
+		if pattern.Match(tag) {
 			return true
 		}
 	}
 	return false
 }
+
+// ExcludeMatching excludes tags matching the pattern.
+// Returns the number of tags excluded.
+func (f *TagFilter) ExcludeMatching(pattern glob.Pattern) int {
+	count := 0
+	for _, tag := range f.tags {
+		if pattern.Match(tag) {
+			f.SetExcluded(tag, true)
+			count++
+		}
+	}
+	return count
+}
+
+// SetSelected sets the selection state for a specific tag.
+// Returns false if no such tag exists.
+func (f *TagFilter) SetSelected(tag string, selected bool) bool {
+	if _, exists := f.selected[tag]; !exists {
+		return false
+	}
+	f.selected[tag] = selected
+	return true
+}
+
+// SetExcluded marks a tag as excluded (hiding the tagged commit and its
+// ancestors) or clears the mark. Returns false if no such tag exists.
+func (f *TagFilter) SetExcluded(tag string, excluded bool) bool {
+	if _, exists := f.selected[tag]; !exists {
+		return false
+	}
+	if excluded {
+		f.excluded[tag] = true
+		f.selected[tag] = false
+	} else {
+		delete(f.excluded, tag)
+	}
+	return true
+}
+
+// ExcludedTags returns the names of excluded tags
+func (f TagFilter) ExcludedTags() []string {
+	var result []string
+	for tag := range f.excluded {
+		result = append(result, tag)
+	}
+	return result
+}
+
+// HasExclusions returns true if any tag is excluded
+func (f TagFilter) HasExclusions() bool {
+	return len(f.excluded) > 0
+}
@@ -0,0 +1,98 @@
+package filtering
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+// ParseCommitList reads commit hashes from r, one per line, as printed by
+// git rev-list, git log --oneline, git cherry or a bisect log: the first
+// token of at least 7 hex digits on each line is used. Abbreviated hashes
+// are resolved against commits. Returns the full hashes in input order
+// (without duplicates) and the tokens that matched no commit or several.
+func ParseCommitList(r io.Reader, commits []domain.Commit) (hashes, unknown []string, err error) {
+	// Index by 7-char prefix; longer or full hashes are checked in the bucket
+	byPrefix := make(map[string][]string)
+	for _, c := range commits {
+		if len(c.Hash) >= 7 {
+			byPrefix[c.Hash[:7]] = append(byPrefix[c.Hash[:7]], c.Hash)
+		}
+	}
+
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		token := hashToken(scanner.Text())
+		if token == "" {
+			continue
+		}
+		var matches []string
+		for _, h := range byPrefix[token[:7]] {
+			if strings.HasPrefix(h, token) {
+				matches = append(matches, h)
+			}
+		}
+		if len(matches) != 1 {
+			unknown = append(unknown, token)
+			continue
+		}
+		if !seen[matches[0]] {
+			seen[matches[0]] = true
+			hashes = append(hashes, matches[0])
+		}
+	}
+	return hashes, unknown, scanner.Err()
+}
+
+// hashToken returns the first hash-like field of a line ("" if none)
+func hashToken(line string) string {
+	for _, field := range strings.Fields(line) {
+		field = strings.ToLower(strings.Trim(field, "[]^-+:"))
+		if len(field) >= 7 && len(field) <= 40 && isHex(field) {
+			return field
+		}
+	}
+	return ""
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// SetCommitSet limits the history to the given commits (nil = no limit).
+// Unlike the other filters it is not cleared by Reset: it holds input
+// given on startup, e.g. commits piped in with --stdin.
+func (m *Manager) SetCommitSet(hashes []string) {
+	if len(hashes) == 0 {
+		m.commitSet = nil
+		return
+	}
+	m.commitSet = make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		m.commitSet[h] = true
+	}
+}
+
+// CommitSetSize returns the number of commits in the commit set (0 = none)
+func (m *Manager) CommitSetSize() int {
+	return len(m.commitSet)
+}
+
+// filterCommitsBySet keeps only commits in the commit set
+func (m *Manager) filterCommitsBySet(commits []domain.Commit) []domain.Commit {
+	var result []domain.Commit
+	for _, c := range commits {
+		if m.commitSet[c.Hash] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
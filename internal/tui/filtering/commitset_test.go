@@ -0,0 +1,78 @@
+package filtering
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+func TestParseCommitList(t *testing.T) {
+	commits := []domain.Commit{
+		{Hash: "1111111aaaa"},
+		{Hash: "2222222bbbb"},
+		{Hash: "3333333cccc"},
+		{Hash: "3333333dddd"},
+	}
+	input := strings.Join([]string{
+		"2222222bbbb",                        // rev-list
+		"1111111 Fix the thing",              // log --oneline
+		"+ 2222222bbbb",                      // cherry (duplicate)
+		"# bad: [1111111aaaa] Fix the thing", // bisect log (duplicate)
+		"",
+		"not a hash",
+		"3333333",     // ambiguous
+		"9999999abcd", // unknown
+	}, "\n")
+
+	got, unknown, err := ParseCommitList(strings.NewReader(input), commits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2222222bbbb", "1111111aaaa"}; !slices.Equal(got, want) {
+		t.Errorf("hashes = %v, want %v", got, want)
+	}
+	if want := []string{"3333333", "9999999abcd"}; !slices.Equal(unknown, want) {
+		t.Errorf("unknown = %v, want %v", unknown, want)
+	}
+}
+
+func TestCommitSetSurvivesReset(t *testing.T) {
+	m := New(testRepo())
+	m.SetCommitSet([]string{"c", "a"})
+	m.SetAuthorFilter("alice")
+	m.UpdateFilterActive()
+	if got := hashes(m.ApplyFilters().Commits); got != "ca" {
+		t.Errorf("commits = %s, want ca", got)
+	}
+
+	m.Reset()
+	if got := hashes(m.ApplyFilters().Commits); got != "ca" {
+		t.Errorf("after reset commits = %s, want ca", got)
+	}
+
+	m.SetCommitSet(nil)
+	if got := hashes(m.ApplyFilters().Commits); got != "fcba" {
+		t.Errorf("cleared commits = %s, want fcba", got)
+	}
+}
+
+func TestCommitSetSurvivesReload(t *testing.T) {
+	m := New(testRepo())
+	m.SetCommitSet([]string{"c", "a"})
+	if !m.Active() {
+		t.Fatal("commit set not active")
+	}
+
+	repo := testRepo()
+	repo.Commits = append([]domain.Commit{{Hash: "g", Author: "Bob", Email: "bob@example.com", Parents: []string{"c"}}}, repo.Commits...)
+	repo.Branches[0].HeadHash = "g"
+	m.UpdateRepo(repo)
+	if !m.Active() {
+		t.Error("commit set not active after reload")
+	}
+	if got := hashes(m.ApplyFilters().Commits); got != "ca" {
+		t.Errorf("after reload commits = %s, want ca", got)
+	}
+}
@@ -1,11 +1,14 @@
 package filtering
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/glob"
 	"github.com/nogo/gitree/internal/tui/filter"
+	"github.com/nogo/gitree/internal/tui/keys"
 )
 
 // Manager encapsulates all filtering state and logic
@@ -22,6 +25,8 @@ type Manager struct {
 	timeFilterStart    time.Time
 	timeFilterEnd      time.Time
 
+	commitSet map[string]bool // commits to show (nil = all)
+
 	repo *domain.Repository
 }
 
@@ -51,27 +56,49 @@ func (m *Manager) UpdateRepo(repo *domain.Repository) {
 	m.tagFilter.UpdateTags(repo.Commits)
 }
 
+// SetKeyMap sets the key bindings of the filter overlays
+func (m *Manager) SetKeyMap(km keys.Map) {
+	m.branchFilter.SetKeyMap(km)
+	m.authorFilter.SetKeyMap(km)
+	m.authorHighlight.SetKeyMap(km)
+	m.tagFilter.SetKeyMap(km)
+}
+
 // ApplyFilters applies all active filters and returns the result
 func (m *Manager) ApplyFilters() Result {
 	filtered := m.repo.Commits
 
+	// Apply commit set (e.g. from --stdin)
+	if m.commitSet != nil {
+		filtered = m.filterCommitsBySet(filtered)
+	}
+
 	// Apply branch filter
 	if !m.branchFilter.AllSelected() {
 		selectedBranches := m.branchFilter.SelectedBranches()
 		filtered = m.filterCommitsByBranch(filtered, selectedBranches)
 	}
+	if m.branchFilter.HasExclusions() {
+		filtered = m.excludeReachable(filtered, m.branchHeads(m.branchFilter.ExcludedBranches()))
+	}
 
 	// Apply tag filter (if any tags selected)
 	if m.tagFilter.HasSelection() {
 		selectedTags := m.tagFilter.SelectedTags()
 		filtered = m.filterCommitsByTag(filtered, selectedTags)
 	}
+	if m.tagFilter.HasExclusions() {
+		filtered = m.excludeReachable(filtered, m.tagHeads(m.tagFilter.ExcludedTags()))
+	}
 
 	// Apply author filter
 	if !m.authorFilter.AllSelected() {
 		selectedEmails := m.authorFilter.SelectedEmails()
 		filtered = m.filterCommitsByAuthor(filtered, selectedEmails)
 	}
+	if m.authorFilter.HasExclusions() {
+		filtered = m.excludeAuthors(filtered, m.authorFilter.ExcludedEmails())
+	}
 
 	// Apply time filter
 	if m.timeFilterActive {
@@ -114,9 +141,9 @@ func (m *Manager) Reset() {
 
 // UpdateFilterActive updates filter active state based on selection
 func (m *Manager) UpdateFilterActive() {
-	m.branchFilterActive = !m.branchFilter.AllSelected()
-	m.authorFilterActive = !m.authorFilter.AllSelected()
-	m.tagFilterActive = m.tagFilter.HasSelection()
+	m.branchFilterActive = !m.branchFilter.AllSelected() || m.branchFilter.HasExclusions()
+	m.authorFilterActive = !m.authorFilter.AllSelected() || m.authorFilter.HasExclusions()
+	m.tagFilterActive = m.tagFilter.HasSelection() || m.tagFilter.HasExclusions()
 }
 
 // BranchFilter returns a pointer to the branch filter for UI updates
@@ -159,11 +186,24 @@ func (m *Manager) TimeFilterActive() bool {
 	return m.timeFilterActive
 }
 
+// Active returns whether anything limits the history: a filter or the
+// commit set
+func (m *Manager) Active() bool {
+	return m.branchFilterActive || m.authorFilterActive || m.tagFilterActive ||
+		m.timeFilterActive || m.commitSet != nil
+}
+
 // TimeFilterRange returns the formatted time range
 func (m *Manager) TimeFilterRange() string {
 	if !m.timeFilterActive {
 		return ""
 	}
+	if m.timeFilterEnd.Equal(maxTime) {
+		return "since " + m.timeFilterStart.Format("Jan 2 '06")
+	}
+	if m.timeFilterStart.IsZero() {
+		return "until " + m.timeFilterEnd.Format("Jan 2 '06")
+	}
 	return m.timeFilterStart.Format("Jan 2 '06") + " - " + m.timeFilterEnd.Format("Jan 2 '06")
 }
 
@@ -197,55 +237,135 @@ func (m *Manager) TotalTagCount() int {
 	return m.tagFilter.TotalCount()
 }
 
-// SetBranchFilter selects only branches matching the given name (partial match).
-// If no match is found, keeps all branches selected (default).
-func (m *Manager) SetBranchFilter(name string) {
-	name = strings.ToLower(name)
+// SetBranchFilter applies a comma-separated list of branch patterns
+// (substring or glob). Plain patterns select only matching branches; if none
+// match, all branches stay selected (default). Patterns prefixed with '!'
+// exclude matching branches and every commit reachable from them. Returns
+// whether any pattern matched a branch; a malformed glob changes nothing.
+func (m *Manager) SetBranchFilter(value string) (bool, error) {
+	include, exclude, err := filter.ParsePatterns(value)
+	if err != nil {
+		return false, fmt.Errorf("branch: %w", err)
+	}
 	bf := &m.branchFilter
 
-	// Check if any match exists
-	hasMatch := false
+	included := matchesAny(include, m.branchNames())
+	if included {
+		// Deselect all, then select matching
+		for _, b := range m.repo.Branches {
+			bf.SetSelected(b.Name, false)
+		}
+		for _, b := range m.repo.Branches {
+			if matchesAny(include, []string{b.Name}) {
+				bf.SetSelected(b.Name, true)
+			}
+		}
+	}
+
+	excluded := false
 	for _, b := range m.repo.Branches {
-		if strings.Contains(strings.ToLower(b.Name), name) {
-			hasMatch = true
-			break
+		if matchesAny(exclude, []string{b.Name}) {
+			bf.SetExcluded(b.Name, true)
+			excluded = true
 		}
 	}
+	return included || excluded, nil
+}
+
+// SetAuthorFilter applies a comma-separated list of author patterns matched
+// against names and emails (substring or glob). Plain patterns select only
+// matching authors; if none match, all authors stay selected (default).
+// Patterns prefixed with '!' hide commits by matching authors. Returns
+// whether any pattern matched an author; a malformed glob changes nothing.
+func (m *Manager) SetAuthorFilter(value string) (bool, error) {
+	include, exclude, err := filter.ParsePatterns(value)
+	if err != nil {
+		return false, fmt.Errorf("author: %w", err)
+	}
+	af := &m.authorFilter
 
-	if !hasMatch {
-		return // Keep default (all selected)
+	var included bool
+	for _, p := range include {
+		included = included || af.HasMatching(p)
+	}
+	if included {
+		af.SelectNone()
+		for _, p := range include {
+			af.SelectMatching(p)
+		}
 	}
 
-	// Deselect all, then select matching
-	for _, b := range m.repo.Branches {
-		bf.SetSelected(b.Name, false)
+	excluded := 0
+	for _, p := range exclude {
+		excluded += af.ExcludeMatching(p)
 	}
-	for _, b := range m.repo.Branches {
-		if strings.Contains(strings.ToLower(b.Name), name) {
-			bf.SetSelected(b.Name, true)
+	return included || excluded > 0, nil
+}
+
+// SetTagFilter applies a comma-separated list of tag patterns (substring or
+// glob). Plain patterns select only matching tags, replacing the tags
+// selected before; if none match, the selection is kept. Patterns prefixed
+// with '!' hide the tagged commits and their ancestors. Returns whether any
+// pattern matched a tag; a malformed glob changes nothing.
+func (m *Manager) SetTagFilter(value string) (bool, error) {
+	include, exclude, err := filter.ParsePatterns(value)
+	if err != nil {
+		return false, fmt.Errorf("tag: %w", err)
+	}
+	tf := &m.tagFilter
+
+	var included bool
+	for _, p := range include {
+		included = included || tf.HasMatching(p)
+	}
+	if included {
+		tf.SelectNone()
+		for _, p := range include {
+			tf.SelectMatching(p)
 		}
 	}
+
+	excluded := 0
+	for _, p := range exclude {
+		excluded += tf.ExcludeMatching(p)
+	}
+	return included || excluded > 0, nil
 }
 
-// SetAuthorFilter selects only authors matching the given name (partial match).
-// If no match is found, keeps all authors selected (default).
-func (m *Manager) SetAuthorFilter(name string) {
-	af := &m.authorFilter
-	if !af.HasMatching(name) {
-		return // Keep default (all selected)
+// ExcludedBranchCount returns the number of excluded branches
+func (m *Manager) ExcludedBranchCount() int {
+	return len(m.branchFilter.ExcludedBranches())
+}
+
+// ExcludedAuthorCount returns the number of excluded authors
+func (m *Manager) ExcludedAuthorCount() int {
+	return len(m.authorFilter.ExcludedNames())
+}
+
+// ExcludedTagCount returns the number of excluded tags
+func (m *Manager) ExcludedTagCount() int {
+	return len(m.tagFilter.ExcludedTags())
+}
+
+// branchNames returns the names of all branches
+func (m *Manager) branchNames() []string {
+	names := make([]string, len(m.repo.Branches))
+	for i, b := range m.repo.Branches {
+		names[i] = b.Name
 	}
-	af.SelectNone()
-	af.SelectMatching(name)
+	return names
 }
 
-// SetTagFilter selects only tags matching the given name (partial match).
-// If no match is found, no tags are selected (shows all commits).
-func (m *Manager) SetTagFilter(name string) {
-	tf := &m.tagFilter
-	if !tf.HasMatching(name) {
-		return // Keep default (no tags selected = show all)
+// matchesAny reports whether any name matches any of the patterns
+func matchesAny(patterns []glob.Pattern, names []string) bool {
+	for _, p := range patterns {
+		for _, name := range names {
+			if p.Match(name) {
+				return true
+			}
+		}
 	}
-	tf.SelectMatching(name)
+	return false
 }
 
 // HighlightedEmails returns the emails of the highlighted author
@@ -345,6 +465,97 @@ func (m *Manager) filterCommitsByAuthor(commits []domain.Commit, emails []string
 	return result
 }
 
+// excludeAuthors removes commits whose author email is in emails
+func (m *Manager) excludeAuthors(commits []domain.Commit, emails []string) []domain.Commit {
+	emailSet := make(map[string]bool)
+	for _, e := range emails {
+		emailSet[e] = true
+	}
+
+	var result []domain.Commit
+	for _, c := range commits {
+		if !emailSet[strings.ToLower(c.Email)] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// branchHeads returns the head hashes of the named branches
+func (m *Manager) branchHeads(names []string) []string {
+	nameSet := make(map[string]bool)
+	for _, name := range names {
+		nameSet[name] = true
+	}
+	var heads []string
+	for _, b := range m.repo.Branches {
+		if nameSet[b.Name] {
+			heads = append(heads, b.HeadHash)
+		}
+	}
+	return heads
+}
+
+// tagHeads returns the hashes of commits carrying the named tags
+func (m *Manager) tagHeads(names []string) []string {
+	nameSet := make(map[string]bool)
+	for _, name := range names {
+		nameSet[name] = true
+	}
+	var heads []string
+	for _, c := range m.repo.Commits {
+		for _, tag := range c.Tags {
+			if nameSet[tag] {
+				heads = append(heads, c.Hash)
+				break
+			}
+		}
+	}
+	return heads
+}
+
+// excludeReachable removes commits reachable from heads (like git log ^ref)
+func (m *Manager) excludeReachable(commits []domain.Commit, heads []string) []domain.Commit {
+	if len(heads) == 0 {
+		return commits
+	}
+
+	// Build hash → commit map for parent lookup
+	commitMap := make(map[string]*domain.Commit)
+	for i := range m.repo.Commits {
+		commitMap[m.repo.Commits[i].Hash] = &m.repo.Commits[i]
+	}
+
+	// BFS to find all reachable commits
+	reachable := make(map[string]bool)
+	queue := heads
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		if reachable[hash] {
+			continue
+		}
+		reachable[hash] = true
+
+		if commit, ok := commitMap[hash]; ok {
+			for _, parentHash := range commit.Parents {
+				if !reachable[parentHash] {
+					queue = append(queue, parentHash)
+				}
+			}
+		}
+	}
+
+	var result []domain.Commit
+	for _, c := range commits {
+		if !reachable[c.Hash] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
 // filterCommitsByTime filters commits by time range
 func (m *Manager) filterCommitsByTime(commits []domain.Commit, start, end time.Time) []domain.Commit {
 	var result []domain.Commit
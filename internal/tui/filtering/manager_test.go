@@ -0,0 +1,119 @@
+package filtering
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+// testRepo builds main: a ← b ← c and feature: b ← f (f tagged v2, a tagged v1)
+func testRepo() *domain.Repository {
+	return &domain.Repository{
+		Commits: []domain.Commit{
+			{Hash: "f", Author: "dependabot[bot]", Email: "bot@github.com", Parents: []string{"b"}, Tags: []string{"v2"}},
+			{Hash: "c", Author: "Alice", Email: "alice@example.com", Parents: []string{"b"}},
+			{Hash: "b", Author: "Bob", Email: "bob@example.com", Parents: []string{"a"}},
+			{Hash: "a", Author: "Alice", Email: "alice@example.com", Tags: []string{"v1"}},
+		},
+		Branches: []domain.Branch{
+			{Name: "main", HeadHash: "c"},
+			{Name: "dependabot/go", HeadHash: "f"},
+		},
+	}
+}
+
+func hashes(commits []domain.Commit) string {
+	var hs []string
+	for _, c := range commits {
+		hs = append(hs, c.Hash)
+	}
+	return strings.Join(hs, "")
+}
+
+func TestExclusionFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(m *Manager)
+		expected string
+	}{
+		{"no filters", func(m *Manager) {}, "fcba"},
+		{"exclude author by email glob", func(m *Manager) { m.SetAuthorFilter("!bot@*") }, "cba"},
+		{"exclude author by name", func(m *Manager) { m.SetAuthorFilter("!alice") }, "fb"},
+		{"include and exclude authors", func(m *Manager) { m.SetAuthorFilter("a,!bot") }, "cba"},
+		{"exclude branch hides reachable commits", func(m *Manager) { m.SetBranchFilter("!main") }, "f"},
+		{"exclude branch glob (like git log ^ref)", func(m *Manager) { m.SetBranchFilter("!dependabot/*") }, "c"},
+		{"deselect branch keeps shared history", func(m *Manager) { m.SetBranchFilter("main") }, "cba"},
+		{"exclude tag hides ancestors", func(m *Manager) { m.SetTagFilter("!v1") }, "fcb"},
+		{"include tag minus older tag", func(m *Manager) { m.SetTagFilter("v2,!v1") }, "fb"},
+		{"unknown include keeps all", func(m *Manager) { m.SetAuthorFilter("nobody,!bob") }, "fca"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := New(testRepo())
+			tc.setup(m)
+			m.UpdateFilterActive()
+			if got := hashes(m.ApplyFilters().Commits); got != tc.expected {
+				t.Errorf("commits = %s, want %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSetFilters_ReportMatches(t *testing.T) {
+	m := New(testRepo())
+	tests := []struct {
+		set     func(string) (bool, error)
+		value   string
+		matched bool
+	}{
+		{m.SetAuthorFilter, "nobody", false},
+		{m.SetBranchFilter, "release/*", false},
+		{m.SetTagFilter, "v3", false},
+		{m.SetAuthorFilter, "!bot@*", true},
+		{m.SetBranchFilter, "main", true},
+		{m.SetTagFilter, "v1", true},
+	}
+	for _, tc := range tests {
+		if matched, err := tc.set(tc.value); err != nil || matched != tc.matched {
+			t.Errorf("set(%q) = %v, %v; want %v", tc.value, matched, err, tc.matched)
+		}
+	}
+	if _, err := m.SetBranchFilter("main,!release/[1"); err == nil {
+		t.Error("malformed glob should be reported")
+	}
+
+	// A new tag pattern replaces the tags selected before
+	m.SetTagFilter("v2")
+	m.UpdateFilterActive()
+	if got := m.State().Tags; strings.Join(got, ",") != "v2" {
+		t.Errorf("tags = %q, want only v2", got)
+	}
+}
+
+func TestStateRoundTripWithExclusions(t *testing.T) {
+	m := New(testRepo())
+	m.SetAuthorFilter("!bot@*")
+	m.SetTagFilter("!v1")
+	m.UpdateFilterActive()
+	state := m.State()
+
+	if len(state.Authors) != 0 {
+		t.Errorf("Authors = %q, want empty (everyone not excluded)", state.Authors)
+	}
+	if strings.Join(state.ExcludeAuthors, ",") != "dependabot[bot]" || strings.Join(state.ExcludeTags, ",") != "v1" {
+		t.Errorf("exclusions = %q %q", state.ExcludeAuthors, state.ExcludeTags)
+	}
+
+	restored := New(testRepo())
+	if missing := restored.ApplyState(state); len(missing) != 0 {
+		t.Errorf("missing = %q", missing)
+	}
+	if got := hashes(restored.ApplyFilters().Commits); got != "cb" {
+		t.Errorf("restored commits = %s, want cb", got)
+	}
+	if !restored.AuthorFilterActive() || !restored.TagFilterActive() {
+		t.Error("restored filters should be active")
+	}
+}
@@ -0,0 +1,165 @@
+package filtering
+
+import (
+	"sort"
+	"time"
+)
+
+// State is a snapshot of all filter selections, used to save and restore views.
+// Empty fields mean the filter is not applied.
+type State struct {
+	Branches []string // selected branch names (empty = all branches)
+	Authors  []string // selected normalized author names (empty = all authors)
+	Tags     []string // selected tag names (empty = no tag filter)
+
+	ExcludeBranches []string // branches whose reachable commits are hidden
+	ExcludeAuthors  []string // normalized author names whose commits are hidden
+	ExcludeTags     []string // tags whose tagged commits and ancestors are hidden
+
+	Highlight string    // highlighted author name (empty = none)
+	Since     time.Time // start of time range (zero = unbounded)
+	Until     time.Time // end of time range, exclusive (zero = unbounded)
+}
+
+// IsEmpty returns whether the state applies no filter at all
+func (s State) IsEmpty() bool {
+	return len(s.Branches) == 0 && len(s.Authors) == 0 && len(s.Tags) == 0 &&
+		len(s.ExcludeBranches) == 0 && len(s.ExcludeAuthors) == 0 && len(s.ExcludeTags) == 0 &&
+		s.Highlight == "" && s.Since.IsZero() && s.Until.IsZero()
+}
+
+// State returns a snapshot of the current filter selections
+func (m *Manager) State() State {
+	var s State
+	s.ExcludeBranches = m.branchFilter.ExcludedBranches()
+	sort.Strings(s.ExcludeBranches)
+	s.ExcludeAuthors = m.authorFilter.ExcludedNames()
+	sort.Strings(s.ExcludeAuthors)
+	s.ExcludeTags = m.tagFilter.ExcludedTags()
+	sort.Strings(s.ExcludeTags)
+
+	// Selections are only recorded when narrower than "everything not excluded"
+	if selected := m.branchFilter.SelectedBranches(); len(selected)+len(s.ExcludeBranches) < len(m.repo.Branches) {
+		s.Branches = selected
+		sort.Strings(s.Branches)
+	}
+	if selected := m.authorFilter.SelectedNames(); len(selected)+len(s.ExcludeAuthors) < m.authorFilter.TotalCount() {
+		s.Authors = selected
+		sort.Strings(s.Authors)
+	}
+	if m.tagFilter.HasSelection() {
+		s.Tags = m.tagFilter.SelectedTags()
+		sort.Strings(s.Tags)
+	}
+	s.Highlight = m.authorHighlight.SelectedName()
+	if m.timeFilterActive {
+		s.Since = m.timeFilterStart
+		if !m.timeFilterEnd.Equal(maxTime) {
+			s.Until = m.timeFilterEnd
+		}
+	}
+	return s
+}
+
+// ApplyState replaces all filter selections with the given snapshot.
+// Branches, authors and tags that no longer exist are skipped; if none of the
+// saved branches or authors exist, that filter falls back to showing all.
+// Returns the names that could not be restored.
+func (m *Manager) ApplyState(s State) (missing []string) {
+	m.Reset()
+
+	if len(s.Branches) > 0 {
+		exists := make(map[string]bool)
+		for _, b := range m.repo.Branches {
+			exists[b.Name] = true
+		}
+		var found []string
+		for _, name := range s.Branches {
+			if exists[name] {
+				found = append(found, name)
+			} else {
+				missing = append(missing, name)
+			}
+		}
+		if len(found) > 0 {
+			for _, b := range m.repo.Branches {
+				m.branchFilter.SetSelected(b.Name, false)
+			}
+			for _, name := range found {
+				m.branchFilter.SetSelected(name, true)
+			}
+		}
+	}
+
+	if len(s.Authors) > 0 {
+		m.authorFilter.SelectNone()
+		for _, name := range s.Authors {
+			if !m.authorFilter.SetSelected(name, true) {
+				missing = append(missing, name)
+			}
+		}
+		if m.authorFilter.SelectedCount() == 0 {
+			m.authorFilter.Reset()
+		}
+	}
+
+	for _, tag := range s.Tags {
+		if !m.tagFilter.SetSelected(tag, true) {
+			missing = append(missing, tag)
+		}
+	}
+
+	// Exclusions last: they override selections
+	for _, name := range s.ExcludeBranches {
+		if !m.hasBranch(name) {
+			missing = append(missing, name)
+			continue
+		}
+		m.branchFilter.SetExcluded(name, true)
+	}
+	for _, name := range s.ExcludeAuthors {
+		if !m.authorFilter.SetExcluded(name, true) {
+			missing = append(missing, name)
+		}
+	}
+	for _, tag := range s.ExcludeTags {
+		if !m.tagFilter.SetExcluded(tag, true) {
+			missing = append(missing, tag)
+		}
+	}
+
+	if s.Highlight != "" && !m.authorHighlight.SetHighlighted(s.Highlight) {
+		missing = append(missing, s.Highlight)
+	}
+
+	m.SetTimeRange(s.Since, s.Until)
+
+	m.UpdateFilterActive()
+	return missing
+}
+
+// hasBranch returns whether a branch with the given name exists
+func (m *Manager) hasBranch(name string) bool {
+	for _, b := range m.repo.Branches {
+		if b.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTimeRange sets the time filter from optional bounds.
+// A zero since or until leaves that side open; both zero clears the filter.
+func (m *Manager) SetTimeRange(since, until time.Time) {
+	if since.IsZero() && until.IsZero() {
+		m.ClearTimeFilter()
+		return
+	}
+	if until.IsZero() {
+		until = maxTime
+	}
+	m.SetTimeFilter(since, until, true)
+}
+
+// maxTime is used as the end of a time range that is open towards the future
+var maxTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
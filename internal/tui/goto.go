@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// openGoto opens the palette with the goto command typed
+func (m *Model) openGoto() tea.Cmd {
+	cmd := m.openPalette()
+	m.palette.SetInput(cmdGoto + " ")
+	return cmd
+}
+
+// resolveRevision returns a command resolving a revision expression
+// through the reader
+func (m Model) resolveRevision(rev string) tea.Cmd {
+	reader := m.reader
+	path := m.repoPath
+	return func() tea.Msg {
+		hash, err := reader.ResolveRevision(path, rev)
+		return RevisionResolvedMsg{Rev: rev, Hash: hash, Err: err}
+	}
+}
+
+// handleRevisionResolved moves the cursor to a resolved revision, or asks
+// to clear the filters if they hide it
+func (m *Model) handleRevisionResolved(msg RevisionResolvedMsg) tea.Cmd {
+	if msg.Err != nil {
+		return m.setFlash(msg.Err.Error(), true)
+	}
+	if i := m.indexOf(msg.Hash); i >= 0 {
+		m.recordJump()
+		m.list.SetCursor(i)
+		return m.flashJump(msg)
+	}
+	if !slices.ContainsFunc(m.repo.Commits, func(c domain.Commit) bool { return c.Hash == msg.Hash }) {
+		return m.setFlash(fmt.Sprintf("%s (%s) is not in the loaded history", msg.Rev, shortHash(msg.Hash)), true)
+	}
+	m.pendingGoto = msg
+	return nil
+}
+
+// confirmGoto answers the prompt to clear the filters hiding the goto
+// target: yes clears them and jumps, any other key cancels
+func (m *Model) confirmGoto(key string) tea.Cmd {
+	msg := m.pendingGoto
+	m.pendingGoto = RevisionResolvedMsg{}
+	if !m.keys.Is(key, keys.ConfirmYes) {
+		return nil
+	}
+	m.recordJump()
+	cmd := m.clearFilters()
+	if !m.jumpTo(msg.Hash) {
+		// Only commits piped in with --stdin show
+		return tea.Batch(cmd, m.setFlash(msg.Rev+" is not among the --stdin commits", true))
+	}
+	return tea.Batch(cmd, m.flashJump(msg))
+}
+
+// GotoPrompt returns the question shown while the goto target is hidden
+// by filters ("" if none)
+func (m Model) GotoPrompt() string {
+	if m.pendingGoto.Hash == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s is hidden by filters, clear them? [%s/%s]", m.pendingGoto.Rev,
+		m.keys.Label(keys.ConfirmYes), m.keys.Label(keys.ConfirmNo))
+}
+
+// indexOf returns the row of a shown commit (-1 if filtered out)
+func (m Model) indexOf(hash string) int {
+	for i, c := range m.list.Commits() {
+		if c.Hash == hash {
+			return i
+		}
+	}
+	return -1
+}
+
+// jumpTo moves the cursor to a shown commit. Returns false if it is not
+// in the list.
+func (m *Model) jumpTo(hash string) bool {
+	i := m.indexOf(hash)
+	if i >= 0 {
+		m.list.SetCursor(i)
+	}
+	return i >= 0
+}
+
+// flashJump flashes the row of a goto target until the footer message
+// expires
+func (m *Model) flashJump(msg RevisionResolvedMsg) tea.Cmd {
+	m.list.SetFlash(msg.Hash)
+	text := "at " + shortHash(msg.Hash)
+	if !strings.HasPrefix(msg.Hash, msg.Rev) {
+		text = fmt.Sprintf("%s → %s", msg.Rev, shortHash(msg.Hash))
+	}
+	return m.setFlash(text, false)
+}
+
+// shortHash abbreviates a full hash like the hash column
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
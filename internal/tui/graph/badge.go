@@ -7,22 +7,13 @@ import (
 )
 
 var (
-	BadgeStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("237")).
-			Foreground(lipgloss.Color("252"))
+	BadgeStyle       lipgloss.Style
+	OriginBadgeStyle lipgloss.Style
+	HeadBadgeStyle   lipgloss.Style
+	TagBadgeStyle    lipgloss.Style
 
-	OriginBadgeStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("22")).
-				Foreground(lipgloss.Color("252"))
-
-	HeadBadgeStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("161")).
-			Foreground(lipgloss.Color("255")).
-			Bold(true)
-
-	TagBadgeStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("220")).
-			Foreground(lipgloss.Color("0"))
+	// badgeText is the text color on badges colored like their branch
+	badgeText lipgloss.Color
 )
 
 func (r *Renderer) badgeStyle(ref string) lipgloss.Style {
@@ -34,5 +25,5 @@ func (r *Renderer) badgeStyle(ref string) lipgloss.Style {
 	}
 	// Use branch color for local branches
 	color := r.colors.ForBranch(ref)
-	return BadgeStyle.Background(color.GetForeground()).Foreground(lipgloss.Color("0"))
+	return BadgeStyle.Background(color.GetForeground()).Foreground(badgeText)
 }
@@ -11,14 +11,7 @@ type ColorPalette struct {
 func NewColorPalette() *ColorPalette {
 	return &ColorPalette{
 		branchColors: make(map[string]lipgloss.Style),
-		palette: []lipgloss.Style{
-			lipgloss.NewStyle().Foreground(lipgloss.Color("205")), // pink (main)
-			lipgloss.NewStyle().Foreground(lipgloss.Color("86")),  // cyan
-			lipgloss.NewStyle().Foreground(lipgloss.Color("221")), // yellow
-			lipgloss.NewStyle().Foreground(lipgloss.Color("156")), // green
-			lipgloss.NewStyle().Foreground(lipgloss.Color("213")), // magenta
-			lipgloss.NewStyle().Foreground(lipgloss.Color("81")),  // blue
-		},
+		palette:      branchPalette,
 	}
 }
 
@@ -0,0 +1,86 @@
+package graph
+
+import "github.com/nogo/gitree/internal/domain"
+
+// Node returns the layout node of a shown commit (nil if not shown)
+func (r *Renderer) Node(hash string) *CommitNode {
+	return r.layout.findNode(hash)
+}
+
+// MergeInto finds the merge that brought hash into the history of tip,
+// following tip's first parents like git log --first-parent. direct is
+// true if hash is on that line itself; ok is false if tip does not
+// contain hash.
+func MergeInto(commits []domain.Commit, hash, tip string) (merge string, direct, ok bool) {
+	byHash := make(map[string]*domain.Commit, len(commits))
+	children := make(map[string][]string)
+	for i := range commits {
+		c := &commits[i]
+		byHash[c.Hash] = c
+		for _, p := range c.Parents {
+			children[p] = append(children[p], c.Hash)
+		}
+	}
+
+	// Every commit containing hash
+	contains := map[string]bool{hash: true}
+	queue := []string{hash}
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		for _, child := range children[h] {
+			if !contains[child] {
+				contains[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	// The oldest first-parent commit still containing hash merged it
+	for h := tip; contains[h]; {
+		if h == hash {
+			return "", true, true
+		}
+		merge = h
+		c := byHash[h]
+		if c == nil || len(c.Parents) == 0 {
+			break
+		}
+		h = c.Parents[0]
+	}
+	return merge, false, merge != ""
+}
+
+// Range returns the commits reachable from to but not from from, like
+// git log from..to, in the order of commits
+func Range(commits []domain.Commit, from, to string) []domain.Commit {
+	parents := make(map[string][]string, len(commits))
+	for _, c := range commits {
+		parents[c.Hash] = c.Parents
+	}
+	ancestors := func(hash string) map[string]bool {
+		seen := map[string]bool{hash: true}
+		queue := []string{hash}
+		for len(queue) > 0 {
+			h := queue[0]
+			queue = queue[1:]
+			for _, p := range parents[h] {
+				if !seen[p] {
+					seen[p] = true
+					queue = append(queue, p)
+				}
+			}
+		}
+		return seen
+	}
+
+	excluded := ancestors(from)
+	included := ancestors(to)
+	var result []domain.Commit
+	for _, c := range commits {
+		if included[c.Hash] && !excluded[c.Hash] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+func TestMergeInto(t *testing.T) {
+	// main: m2 -> m1 -> base, where m2 merges feature f2 -> f1 -> base
+	// and m1 merges topic t1 -> base. side is on no branch of main.
+	commits := []domain.Commit{
+		{Hash: "m2", Parents: []string{"m1", "f2"}},
+		{Hash: "side", Parents: []string{"f1"}},
+		{Hash: "f2", Parents: []string{"f1"}},
+		{Hash: "m1", Parents: []string{"base", "t1"}},
+		{Hash: "f1", Parents: []string{"base"}},
+		{Hash: "t1", Parents: []string{"base"}},
+		{Hash: "base", Parents: nil},
+	}
+
+	tests := []struct {
+		hash   string
+		merge  string
+		direct bool
+		ok     bool
+	}{
+		{"f1", "m2", false, true},
+		{"f2", "m2", false, true},
+		{"t1", "m1", false, true},
+		{"m1", "", true, true},
+		{"base", "", true, true},
+		{"side", "", false, false},
+	}
+	for _, tc := range tests {
+		merge, direct, ok := MergeInto(commits, tc.hash, "m2")
+		if merge != tc.merge || direct != tc.direct || ok != tc.ok {
+			t.Errorf("MergeInto(%s) = %q, %v, %v; want %q, %v, %v",
+				tc.hash, merge, direct, ok, tc.merge, tc.direct, tc.ok)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	// m2 merges f2 -> f1 -> base into m1 -> base
+	commits := []domain.Commit{
+		{Hash: "m2", Parents: []string{"m1", "f2"}},
+		{Hash: "f2", Parents: []string{"f1"}},
+		{Hash: "m1", Parents: []string{"base"}},
+		{Hash: "f1", Parents: []string{"base"}},
+		{Hash: "base", Parents: nil},
+	}
+
+	tests := []struct {
+		from, to string
+		want     []string
+	}{
+		{"m1", "m2", []string{"m2", "f2", "f1"}},
+		{"f2", "m2", []string{"m2", "m1"}},
+		{"base", "f2", []string{"f2", "f1"}},
+		{"m2", "f1", nil},
+		{"m2", "m2", nil},
+	}
+	for _, tc := range tests {
+		var got []string
+		for _, c := range Range(commits, tc.from, tc.to) {
+			got = append(got, c.Hash)
+		}
+		if strings.Join(got, " ") != strings.Join(tc.want, " ") {
+			t.Errorf("Range(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
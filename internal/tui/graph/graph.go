@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -137,9 +138,16 @@ func (r *Renderer) RenderBranchBadges(c domain.Commit) string {
 		}
 	}
 
-	// Build badges
+	// Build badges in name order so output is stable
+	names := make([]string, 0, len(groups))
+	for baseName := range groups {
+		names = append(names, baseName)
+	}
+	sort.Strings(names)
+
 	var badges []string
-	for baseName, info := range groups {
+	for _, baseName := range names {
+		info := groups[baseName]
 		var label string
 		if info.hasLocal && info.hasRemote {
 			// Merge: "main | origin" or "main | origin, upstream"
@@ -166,7 +174,7 @@ func (r *Renderer) badgeStyleForGroup(baseName string, hasLocal, hasRemote bool)
 	if hasLocal {
 		// Use local branch color
 		color := r.colors.ForBranch(baseName)
-		return BadgeStyle.Background(color.GetForeground()).Foreground(lipgloss.Color("0"))
+		return BadgeStyle.Background(color.GetForeground()).Foreground(badgeText)
 	}
 	// Remote only
 	return OriginBadgeStyle
@@ -154,21 +154,21 @@ func (r *RowRenderer) getConnector(row, lane int, node *CommitNode, maxLanes int
 	return " "
 }
 
+// LaneColors are the graph line colors, cycled by lane index
+var LaneColors []lipgloss.Color
+
+// LaneColor returns the color used for a given lane
+func LaneColor(lane int) lipgloss.Color {
+	return LaneColors[lane%len(LaneColors)]
+}
+
 // colorForLane returns the style for a given lane
 func (r *RowRenderer) colorForLane(lane int) lipgloss.Style {
-	colors := []lipgloss.Color{
-		lipgloss.Color("205"), // pink
-		lipgloss.Color("86"),  // cyan
-		lipgloss.Color("156"), // green
-		lipgloss.Color("221"), // yellow
-		lipgloss.Color("213"), // magenta
-		lipgloss.Color("81"),  // blue
-	}
-	return lipgloss.NewStyle().Foreground(colors[lane%len(colors)])
+	return lipgloss.NewStyle().Foreground(LaneColor(lane))
 }
 
 // DimmedGraphStyle is used for dimmed graph elements
-var DimmedGraphStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("239"))
+var DimmedGraphStyle lipgloss.Style
 
 // RenderRowDimmed produces a dimmed graph string for a given row index
 func (r *RowRenderer) RenderRowDimmed(row int) string {
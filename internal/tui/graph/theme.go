@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
+
+// branchPalette are the local branch badge colors, assigned in order of
+// appearance
+var branchPalette []lipgloss.Style
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the graph colors and badge styles from t.
+// Renderers created before keep their palette.
+func SetTheme(t theme.Theme) {
+	LaneColors = t.Lanes
+
+	branchPalette = make([]lipgloss.Style, len(t.Branches))
+	for i, c := range t.Branches {
+		branchPalette[i] = lipgloss.NewStyle().Foreground(c)
+	}
+
+	DimmedGraphStyle = lipgloss.NewStyle().Foreground(t.Dimmed)
+
+	BadgeStyle = lipgloss.NewStyle().
+		Background(t.Selection).
+		Foreground(t.Text)
+
+	OriginBadgeStyle = lipgloss.NewStyle().
+		Background(t.RemoteBadge).
+		Foreground(t.Text)
+
+	HeadBadgeStyle = lipgloss.NewStyle().
+		Background(t.HeadBadge).
+		Foreground(t.SelectionText).
+		Bold(true)
+
+	TagBadgeStyle = lipgloss.NewStyle().
+		Background(t.TagBadge).
+		Foreground(t.BadgeText)
+
+	badgeText = t.BadgeText
+
+	if t.NoColor {
+		// Reverse video keeps badges apart from the commit message
+		BadgeStyle = BadgeStyle.Reverse(true)
+		OriginBadgeStyle = OriginBadgeStyle.Reverse(true)
+		HeadBadgeStyle = HeadBadgeStyle.Reverse(true)
+		TagBadgeStyle = TagBadgeStyle.Reverse(true)
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// searchHelp describes the search box, whose keys are typed text
+var searchHelp = []string{
+	" Search box",
+	"   Tab           Cycle substring/regex/fuzzy",
+	"   author: file: after: before: tag:",
+	"   branch: merge:yes msg:/re/ AND OR NOT ( )",
+}
+
+// helpChrome is the height of the help overlay around its lines: border,
+// padding, title and footer
+const helpChrome = 8
+
+// helpSection lists the bindings of mode under its title
+func (m Model) helpSection(mode keys.Mode) []string {
+	lines := []string{" " + mode.Title()}
+	for _, b := range m.keys.Bindings(mode) {
+		lines = append(lines, fmt.Sprintf("   %-13s %s", b.Keys, b.Help))
+	}
+	return lines
+}
+
+// helpLines returns the body of the help overlay, generated from the
+// keymap. Sections are split into two columns when the terminal is wide
+// enough.
+func (m Model) helpLines() []string {
+	join := func(sections ...[]string) []string {
+		var lines []string
+		for _, s := range sections {
+			if len(s) == 0 {
+				continue
+			}
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, s...)
+		}
+		return lines
+	}
+
+	left := join(m.helpSection(keys.ModeList), searchHelp, m.helpSection(keys.ModeFilter),
+		m.helpSection(keys.ModeViews), m.helpSection(keys.ModePalette))
+	var pick []string
+	if m.pick != nil {
+		pick = m.helpSection(keys.ModePick)
+	}
+	right := join(pick, m.helpSection(keys.ModeExpanded), m.helpSection(keys.ModeDiff),
+		m.helpSection(keys.ModeCompare), m.helpSection(keys.ModeRangeDiff),
+		m.helpSection(keys.ModeHistogram), m.helpSection(keys.ModeYank), m.commandHelp(),
+		m.helpSection(keys.ModeCommand), m.helpSection(keys.ModeConfirm))
+
+	leftWidth := 0
+	for _, l := range left {
+		leftWidth = max(leftWidth, lipgloss.Width(l))
+	}
+	rightWidth := 0
+	for _, l := range right {
+		rightWidth = max(rightWidth, lipgloss.Width(l))
+	}
+	gap := 4
+	if leftWidth+gap+rightWidth+helpChrome > m.width {
+		return join(left, right)
+	}
+
+	lines := make([]string, max(len(left), len(right)))
+	for i := range lines {
+		l, r := "", ""
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		lines[i] = strings.TrimRight(l+strings.Repeat(" ", leftWidth+gap-lipgloss.Width(l))+r, " ")
+	}
+	return lines
+}
+
+// helpHeight returns the number of help lines that fit on screen
+func (m Model) helpHeight() int {
+	return max(1, m.height-helpChrome)
+}
+
+// scrollHelp moves the help overlay by delta lines
+func (m *Model) scrollHelp(delta int) {
+	maxScroll := max(0, len(m.helpLines())-m.helpHeight())
+	m.helpScroll = min(max(0, m.helpScroll+delta), maxScroll)
+}
+
+// renderHelp renders the help overlay
+func (m Model) renderHelp() string {
+	lines := m.helpLines()
+	footer := "Press any key to close"
+	if height := m.helpHeight(); len(lines) > height {
+		end := min(m.helpScroll+height, len(lines))
+		lines = lines[m.helpScroll:end]
+		footer = fmt.Sprintf("%s/%s scroll · any key to close", m.keys.Label(keys.Down), m.keys.Label(keys.Up))
+	}
+	help := "Keyboard Shortcuts\n\n" + strings.Join(lines, "\n") + "\n\n" + footer
+
+	style := BorderStyle.Padding(1, 2)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		style.Render(help),
+	)
+}
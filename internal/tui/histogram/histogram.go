@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
 )
 
 // Bin represents a time bucket with commit count
@@ -24,11 +25,16 @@ type Histogram struct {
 	focused        bool
 	visible        bool
 	width          int
-	height         int // bar height (not including axis)
+	height         int // rows of density bars (not including axis)
 	// Viewport for zoom
 	viewStart int // first visible bin index
 	viewEnd   int // last visible bin index (exclusive)
 	zoomLevel int // 0=full, 1=50%, 2=25%, 3=12.5%
+	// Time range behind the selection, kept so Recalculate can
+	// reselect the matching bins when the binning changes
+	rangeStart time.Time
+	rangeEnd   time.Time
+	keys       keys.Map
 }
 
 // New creates a histogram from commits
@@ -38,13 +44,19 @@ func New(commits []domain.Commit, width int) Histogram {
 		selectionEnd:   -1,
 		visible:        true,
 		width:          width,
-		height:         5,
+		height:         1,
 		zoomLevel:      0,
+		keys:           keys.Default(),
 	}
 	h.Recalculate(commits, width)
 	return h
 }
 
+// SetKeyMap sets the key bindings used when focused
+func (h *Histogram) SetKeyMap(km keys.Map) {
+	h.keys = km
+}
+
 // Recalculate rebuilds bins from commits
 func (h *Histogram) Recalculate(commits []domain.Commit, width int) {
 	h.width = width
@@ -126,7 +138,10 @@ func (h *Histogram) Recalculate(commits []domain.Commit, width int) {
 	h.viewEnd = len(h.bins)
 	h.zoomLevel = 0
 
-	// Reapply selection state to bins
+	// Reselect bins for the selected time range (bin boundaries may have moved)
+	if !h.rangeStart.IsZero() || !h.rangeEnd.IsZero() {
+		h.selectBins(h.rangeStart, h.rangeEnd)
+	}
 	h.updateSelectionState()
 }
 
@@ -140,18 +155,18 @@ func (h Histogram) Update(msg tea.Msg) (Histogram, tea.Cmd, bool) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "h", "left":
+		switch key := msg.String(); {
+		case h.keys.Is(key, keys.HistogramLeft):
 			if h.cursor > 0 {
 				h.cursor--
 				h.ensureCursorVisible()
 			}
-		case "l", "right":
+		case h.keys.Is(key, keys.HistogramRight):
 			if h.cursor < len(h.bins)-1 {
 				h.cursor++
 				h.ensureCursorVisible()
 			}
-		case "[":
+		case h.keys.Is(key, keys.HistogramStart):
 			// Set selection start at cursor
 			h.selectionStart = h.cursor
 			if h.selectionEnd < h.selectionStart {
@@ -159,7 +174,7 @@ func (h Histogram) Update(msg tea.Msg) (Histogram, tea.Cmd, bool) {
 			}
 			h.updateSelectionState()
 			selectionChanged = true
-		case "]":
+		case h.keys.Is(key, keys.HistogramEnd):
 			// Set selection end at cursor
 			h.selectionEnd = h.cursor
 			if h.selectionStart < 0 || h.selectionStart > h.selectionEnd {
@@ -167,39 +182,75 @@ func (h Histogram) Update(msg tea.Msg) (Histogram, tea.Cmd, bool) {
 			}
 			h.updateSelectionState()
 			selectionChanged = true
-		case " ", "space":
+		case h.keys.Is(key, keys.HistogramToggle):
 			// Toggle selection at cursor (quick select)
 			selectionChanged = h.toggleSelection()
-		case "enter":
+		case h.keys.Is(key, keys.HistogramApply):
 			// Apply current selection as filter
 			if h.selectionStart >= 0 {
 				selectionChanged = true
 			}
-		case "esc":
+		case h.keys.Is(key, keys.HistogramClear):
 			// Clear selection and return focus to list
 			if h.selectionStart >= 0 {
 				h.clearSelection()
 				selectionChanged = true
 			}
 			h.focused = false
-		case "+", "=":
+		case h.keys.Is(key, keys.HistogramZoomIn):
 			// Zoom in (center on cursor)
 			h.zoomIn()
-		case "-", "_":
+		case h.keys.Is(key, keys.HistogramZoomOut):
 			// Zoom out
 			h.zoomOut()
-		case "H":
+		case h.keys.Is(key, keys.HistogramPanLeft):
 			// Pan left (jump)
 			h.panLeft()
-		case "L":
+		case h.keys.Is(key, keys.HistogramPanRight):
 			// Pan right (jump)
 			h.panRight()
 		}
 	}
 
+	if selectionChanged {
+		h.rangeStart, h.rangeEnd, _ = h.SelectedRange()
+	}
 	return h, nil, selectionChanged
 }
 
+// SelectRange selects the bins overlapping [start, end). A zero start or end
+// leaves that side open. Returns false if no bin overlaps the range.
+func (h *Histogram) SelectRange(start, end time.Time) bool {
+	h.rangeStart = start
+	h.rangeEnd = end
+	ok := h.selectBins(start, end)
+	h.updateSelectionState()
+	if ok {
+		h.cursor = h.selectionStart
+		h.ensureCursorVisible()
+	}
+	return ok
+}
+
+// selectBins sets the selection indices to the bins overlapping [start, end)
+func (h *Histogram) selectBins(start, end time.Time) bool {
+	h.selectionStart = -1
+	h.selectionEnd = -1
+	for i, b := range h.bins {
+		if !start.IsZero() && !b.End.After(start) {
+			continue
+		}
+		if !end.IsZero() && !b.Start.Before(end) {
+			continue
+		}
+		if h.selectionStart < 0 {
+			h.selectionStart = i
+		}
+		h.selectionEnd = i
+	}
+	return h.selectionStart >= 0
+}
+
 // zoomIn halves the visible range, centered on cursor
 func (h *Histogram) zoomIn() {
 	if len(h.bins) == 0 {
@@ -351,6 +402,8 @@ func (h *Histogram) toggleSelection() bool {
 func (h *Histogram) clearSelection() {
 	h.selectionStart = -1
 	h.selectionEnd = -1
+	h.rangeStart = time.Time{}
+	h.rangeEnd = time.Time{}
 	h.updateSelectionState()
 }
 
@@ -421,11 +474,20 @@ func (h *Histogram) Reset() {
 	h.clearSelection()
 }
 
-// Height returns total rendered height (video player style: 5 lines)
+// Height returns total rendered height (video player style: 5 lines
+// with one row of bars)
 func (h Histogram) Height() int {
 	if !h.visible || len(h.bins) == 0 {
 		return 0
 	}
-	// date labels(1) + density bars(1) + track line(1) + playhead(1) + info(1)
-	return 5
+	// date labels(1) + density bars(height) + track line(1) + playhead(1) + info(1)
+	return 4 + h.height
+}
+
+// SetBarHeight sets the rows of density bars (at least 1)
+func (h *Histogram) SetBarHeight(rows int) {
+	if rows < 1 {
+		rows = 1
+	}
+	h.height = rows
 }
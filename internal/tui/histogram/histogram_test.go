@@ -0,0 +1,68 @@
+package histogram
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+func TestSelectRange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 12, 0, 0, 0, time.UTC) }
+	// Newest first, one commit per day over 20 days → daily bins
+	var commits []domain.Commit
+	for d := 20; d >= 1; d-- {
+		commits = append(commits, domain.Commit{Date: day(d)})
+	}
+
+	h := New(commits, 200)
+	if !h.SelectRange(day(5), day(10)) {
+		t.Fatal("SelectRange returned false for a range inside the history")
+	}
+	start, end, ok := h.SelectedRange()
+	if !ok || start.After(day(5)) || end.Before(day(10)) {
+		t.Errorf("SelectedRange = %v..%v, want to cover %v..%v", start, end, day(5), day(10))
+	}
+
+	// Narrower bins after a resize must still cover the requested range
+	h.Recalculate(commits, 30)
+	start, end, ok = h.SelectedRange()
+	if !ok || start.After(day(5)) || end.Before(day(10)) {
+		t.Errorf("after Recalculate SelectedRange = %v..%v, want to cover %v..%v", start, end, day(5), day(10))
+	}
+
+	// Open end selects through the newest bin
+	h.SelectRange(day(15), time.Time{})
+	if _, end, _ := h.SelectedRange(); end.Before(day(20)) {
+		t.Errorf("open-ended selection ends at %v, want after %v", end, day(20))
+	}
+
+	if h.SelectRange(day(25), day(30)) {
+		t.Error("SelectRange after the newest commit should select nothing")
+	}
+
+	h.Reset()
+	h.Recalculate(commits, 200)
+	if h.HasSelection() {
+		t.Error("Reset selection came back after Recalculate")
+	}
+}
+
+func TestBarHeight(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 12, 0, 0, 0, time.UTC) }
+	commits := []domain.Commit{{Date: day(3)}, {Date: day(2)}, {Date: day(2)}, {Date: day(1)}}
+
+	h := New(commits, 40)
+	if got := h.Height(); got != 5 {
+		t.Errorf("default Height = %d, want 5", got)
+	}
+
+	h.SetBarHeight(3)
+	if got := h.Height(); got != 7 {
+		t.Errorf("Height with 3 bar rows = %d, want 7", got)
+	}
+	if lines := strings.Count(h.View(), "\n") + 1; lines != h.Height() {
+		t.Errorf("View has %d lines, want %d", lines, h.Height())
+	}
+}
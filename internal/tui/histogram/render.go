@@ -64,34 +64,43 @@ func renderHistogram(bins []Bin, cursor int, focused bool, width, height int, zo
 	// Line 1: Date labels
 	dateLabels := renderDateLabels(bins, binPositions, availableWidth)
 
-	// Line 2: Density bars (braille)
-	var densityLine strings.Builder
-	densityLine.WriteString("  ")
+	// Line 2: Density bars (braille), stacked over height rows
+	if height < 1 {
+		height = 1
+	}
+	densityLines := make([]strings.Builder, height)
+	for row := range densityLines {
+		densityLines[row].WriteString("  ")
+	}
 	pos := 0
 	for i, bin := range bins {
 		// Fill spaces to reach bin position
 		for pos < binPositions[i] {
-			densityLine.WriteString(" ")
+			for row := range densityLines {
+				densityLines[row].WriteString(" ")
+			}
 			pos++
 		}
-		// Calculate density level (0-4)
+		// Calculate density level (0-4 per row)
 		level := 0
 		if bin.Count > 0 {
-			level = (bin.Count * 4) / maxCount
+			level = (bin.Count * 4 * height) / maxCount
 			if level < 1 {
 				level = 1
 			}
-			if level > 4 {
-				level = 4
-			}
 		}
-		char := string(densityChars[level])
-		if bin.Selected {
-			char = SelectedBarStyle.Render(char)
-		} else if bin.Count > 0 {
-			char = BarStyle.Render(char)
+		for row := range densityLines {
+			// Rows are written top down; the bottom row fills first
+			rowLevel := level - (height-1-row)*4
+			rowLevel = max(0, min(rowLevel, 4))
+			char := string(densityChars[rowLevel])
+			if bin.Selected {
+				char = SelectedBarStyle.Render(char)
+			} else if bin.Count > 0 {
+				char = BarStyle.Render(char)
+			}
+			densityLines[row].WriteString(char)
 		}
-		densityLine.WriteString(char)
 		pos++
 	}
 
@@ -168,8 +177,10 @@ func renderHistogram(bins []Bin, cursor int, focused bool, width, height int, zo
 	result.WriteString("  ")
 	result.WriteString(AxisStyle.Render(dateLabels))
 	result.WriteString("\n")
-	result.WriteString(densityLine.String())
-	result.WriteString("\n")
+	for row := range densityLines {
+		result.WriteString(densityLines[row].String())
+		result.WriteString("\n")
+	}
 	result.WriteString(trackLine.String())
 	result.WriteString("\n")
 	result.WriteString(playheadLine.String())
@@ -1,20 +1,40 @@
 package histogram
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
 
 var (
+	BarStyle             lipgloss.Style
+	SelectedBarStyle     lipgloss.Style
+	AxisStyle            lipgloss.Style
+	FocusedBorderStyle   lipgloss.Style
+	UnfocusedBorderStyle lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
 	BarStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#8BE9FD")) // Cyan
+		Foreground(t.Bar)
 
 	SelectedBarStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#F1FA8C")) // Yellow
+		Foreground(t.BarSelected)
+	if t.NoColor {
+		// Keep the selection visible without colors
+		SelectedBarStyle = SelectedBarStyle.Bold(true)
+	}
 
 	AxisStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")) // Dimmed gray
+		Foreground(t.Muted)
 
 	FocusedBorderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("62"))
+		Foreground(t.Border)
 
 	UnfocusedBorderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("238"))
-)
+		Foreground(t.Separator)
+}
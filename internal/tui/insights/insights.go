@@ -8,13 +8,20 @@ type InsightsView struct {
 	fileStats   []FileStats
 	summary     Summary
 	calendar    CalendarData
+	weekStart   WeekStartDay
 	width       int
 	height      int
 }
 
 // New creates an empty InsightsView with default values.
 func New() InsightsView {
-	return InsightsView{}
+	return InsightsView{weekStart: WeekStartMonday}
+}
+
+// SetWeekStart sets the first day of the week in the heatmap.
+// Takes effect on the next Recalculate.
+func (v *InsightsView) SetWeekStart(day WeekStartDay) {
+	v.weekStart = day
 }
 
 // Recalculate computes all statistics from the provided commits and file changes.
@@ -36,7 +43,7 @@ func (v *InsightsView) Recalculate(commits []*domain.Commit, fileChanges map[str
 	v.authorStats = ComputeAuthorStats(valueCommits, topAuthors)
 	v.fileStats = ComputeFileStats(fileChanges, topFiles)
 	v.summary = ComputeSummary(valueCommits, v.authorStats, v.fileStats)
-	v.calendar = ComputeCalendarData(commits, WeekStartMonday)
+	v.calendar = ComputeCalendarData(commits, v.weekStart)
 }
 
 // SetSize stores the available dimensions for rendering.
@@ -64,10 +64,9 @@ func (v InsightsView) renderWideLayout() string {
 		Render(heatmapPanel)
 
 	// Build vertical separator spanning full height
-	sepStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
 	var sepLines []string
 	for i := 0; i < panelHeight; i++ {
-		sepLines = append(sepLines, sepStyle.Render("│"))
+		sepLines = append(sepLines, SeparatorStyle.Render("│"))
 	}
 	separator := strings.Join(sepLines, "\n")
 
@@ -10,6 +10,15 @@ import (
 // Weekday labels for the calendar (Monday start).
 var weekdayLabels = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
 
+// weekdayLabel returns the label of a day row, following the week start
+// of the calendar
+func weekdayLabel(weeks [][]DayCell, dayIdx int) string {
+	if len(weeks) > 0 && dayIdx < len(weeks[0]) {
+		return weeks[0][dayIdx].Date.Weekday().String()[:3]
+	}
+	return weekdayLabels[dayIdx]
+}
+
 // cellChar returns the block character for a heat level.
 func cellChar(level int) string {
 	switch level {
@@ -59,9 +68,9 @@ func renderCalendar(cal CalendarData, width, height int) string {
 	for dayIdx := 0; dayIdx < 7; dayIdx++ {
 		var row strings.Builder
 
-		// Weekday label (show Mon, Wed, Fri only for compactness)
+		// Weekday label (show every other day for compactness)
 		if dayIdx%2 == 0 {
-			row.WriteString(weekdayLabels[dayIdx][:1] + "  ")
+			row.WriteString(weekdayLabel(visibleWeeks, dayIdx)[:1] + "  ")
 		} else {
 			row.WriteString("   ")
 		}
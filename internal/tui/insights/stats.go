@@ -62,9 +62,12 @@ func ComputeAuthorStats(commits []domain.Commit, topN int) []AuthorStats {
 		result = append(result, *s)
 	}
 
-	// Sort by commit count descending
+	// Sort by commit count descending, ties by email for stable output
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].Commits > result[j].Commits
+		if result[i].Commits != result[j].Commits {
+			return result[i].Commits > result[j].Commits
+		}
+		return result[i].Email < result[j].Email
 	})
 
 	// Limit to topN
@@ -103,9 +106,12 @@ func ComputeFileStats(files map[string][]domain.FileChange, topN int) []FileStat
 		result = append(result, *s)
 	}
 
-	// Sort by change count descending
+	// Sort by change count descending, ties by path for stable output
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].ChangeCount > result[j].ChangeCount
+		if result[i].ChangeCount != result[j].ChangeCount {
+			return result[i].ChangeCount > result[j].ChangeCount
+		}
+		return result[i].Path < result[j].Path
 	})
 
 	// Limit to topN
@@ -1,28 +1,57 @@
 package insights
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
 
 // Heat intensity colors (5 levels, 0-4)
 var (
-	Heat0 = lipgloss.NewStyle().Foreground(lipgloss.Color("240")) // Gray/empty
-	Heat1 = lipgloss.NewStyle().Foreground(lipgloss.Color("22"))  // Light green
-	Heat2 = lipgloss.NewStyle().Foreground(lipgloss.Color("28"))  // Medium green
-	Heat3 = lipgloss.NewStyle().Foreground(lipgloss.Color("34"))  // Dark green
-	Heat4 = lipgloss.NewStyle().Foreground(lipgloss.Color("40"))  // Bright green
+	Heat0 lipgloss.Style // empty
+	Heat1 lipgloss.Style
+	Heat2 lipgloss.Style
+	Heat3 lipgloss.Style
+	Heat4 lipgloss.Style // busiest
 )
 
 // HeatStyles provides indexed access to heat level styles.
-var HeatStyles = []lipgloss.Style{Heat0, Heat1, Heat2, Heat3, Heat4}
+var HeatStyles []lipgloss.Style
 
 // Stats table styles
 var (
-	HeaderStyle  = lipgloss.NewStyle().Bold(true)
-	NameStyle    = lipgloss.NewStyle()
-	CountStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241")) // Dimmed
-	PercentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("81"))  // Cyan
+	HeaderStyle  lipgloss.Style
+	NameStyle    lipgloss.Style
+	CountStyle   lipgloss.Style
+	PercentStyle lipgloss.Style
+)
+
+// Section title and separator styles
+var (
+	SectionTitleStyle lipgloss.Style
+	SeparatorStyle    lipgloss.Style
 )
 
-// Section title style
-var SectionTitleStyle = lipgloss.NewStyle().
-	Bold(true).
-	Foreground(lipgloss.Color("205"))
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	Heat0 = lipgloss.NewStyle().Foreground(t.Heat[0])
+	Heat1 = lipgloss.NewStyle().Foreground(t.Heat[1])
+	Heat2 = lipgloss.NewStyle().Foreground(t.Heat[2])
+	Heat3 = lipgloss.NewStyle().Foreground(t.Heat[3])
+	Heat4 = lipgloss.NewStyle().Foreground(t.Heat[4])
+	HeatStyles = []lipgloss.Style{Heat0, Heat1, Heat2, Heat3, Heat4}
+
+	HeaderStyle = lipgloss.NewStyle().Bold(true)
+	NameStyle = lipgloss.NewStyle()
+	CountStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	PercentStyle = lipgloss.NewStyle().Foreground(t.Author)
+
+	SectionTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Accent)
+
+	SeparatorStyle = lipgloss.NewStyle().Foreground(t.Separator)
+}
@@ -0,0 +1,172 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxJumps is how many positions the jump list keeps
+const maxJumps = 100
+
+// jump is a position in the history. Entries are keyed on the commit hash,
+// so they stay valid when filters or a reload change the rows.
+type jump struct {
+	Hash     string
+	Expanded bool
+	File     string // selected file of the expanded commit or file in the diff view
+	Diff     bool
+}
+
+// jumpList is the back/forward history of jumps, like vim's. pos is the
+// entry Ctrl+O and Ctrl+N move from; len(entries) while no jump was undone.
+type jumpList struct {
+	entries []jump
+	pos     int
+}
+
+// push records the position a jump leaves. Later entries are dropped, and
+// an older entry for the same position is moved to the end.
+func (l *jumpList) push(j jump) {
+	entries := l.entries[:min(l.pos, len(l.entries))]
+	kept := entries[:0]
+	for _, e := range entries {
+		if e != j {
+			kept = append(kept, e)
+		}
+	}
+	l.entries = append(kept, j)
+	if len(l.entries) > maxJumps {
+		l.entries = l.entries[len(l.entries)-maxJumps:]
+	}
+	l.pos = len(l.entries)
+}
+
+// back returns the entry before the current one. Going back from the
+// newest entry records cur, so forward returns to it.
+func (l *jumpList) back(cur jump) (jump, bool) {
+	if l.pos == 0 {
+		return jump{}, false
+	}
+	if l.pos == len(l.entries) {
+		l.push(cur)
+		l.pos = len(l.entries) - 1
+		if l.pos == 0 {
+			return jump{}, false
+		}
+	}
+	l.pos--
+	return l.entries[l.pos], true
+}
+
+// forward returns the entry after the current one
+func (l *jumpList) forward() (jump, bool) {
+	if l.pos+1 >= len(l.entries) {
+		return jump{}, false
+	}
+	l.pos++
+	return l.entries[l.pos], true
+}
+
+// currentJump returns the position of the cursor
+func (m Model) currentJump() (jump, bool) {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return jump{}, false
+	}
+	if m.showCompare || m.showRangeDiff {
+		// Comparisons are not reopened, only their commit
+		return jump{Hash: commit.Hash}, true
+	}
+	j := jump{Hash: commit.Hash, Expanded: m.list.IsExpanded(), Diff: m.showDiff}
+	if m.showDiff {
+		j.File = m.diffView.CurrentFile()
+	} else if f := m.list.SelectedFile(); j.Expanded && f != nil {
+		j.File = f.Path
+	}
+	return j, true
+}
+
+// recordJump remembers the cursor position before a jump moves it
+func (m *Model) recordJump() {
+	if j, ok := m.currentJump(); ok {
+		m.jumps.push(j)
+	}
+}
+
+// jumpBack returns to the position before the last jump
+func (m *Model) jumpBack() tea.Cmd {
+	cur, ok := m.currentJump()
+	if !ok {
+		return nil
+	}
+	j, ok := m.jumps.back(cur)
+	if !ok {
+		return m.setFlash("no earlier jump", true)
+	}
+	return m.restoreJump(j)
+}
+
+// jumpForward redoes a jump undone with jumpBack
+func (m *Model) jumpForward() tea.Cmd {
+	j, ok := m.jumps.forward()
+	if !ok {
+		return m.setFlash("no later jump", true)
+	}
+	return m.restoreJump(j)
+}
+
+// restoreJump moves the cursor to a recorded position and reopens its
+// expanded commit and diff. The file is selected once the files are loaded.
+func (m *Model) restoreJump(j jump) tea.Cmd {
+	if m.showDiff {
+		m.diffView.Hide()
+		m.showDiff = false
+	}
+	if m.showCompare {
+		m.closeCompare()
+	}
+	if m.showRangeDiff {
+		m.closeRangeDiff()
+	}
+	if m.list.IsExpanded() {
+		m.list.Collapse()
+	}
+	m.restoringJump = nil
+	if !m.jumpTo(j.Hash) {
+		return m.setFlash("commit "+shortHash(j.Hash)+" is hidden by filters", true)
+	}
+	if !j.Expanded {
+		return nil
+	}
+	m.list.Expand()
+	m.restoringJump = &j
+	return m.loadExpandedFiles()
+}
+
+// restoreJumpFile selects the file of a restored jump after the files of
+// its commit are loaded, and reopens its diff
+func (m *Model) restoreJumpFile() tea.Cmd {
+	j := m.restoringJump
+	m.restoringJump = nil
+	commit := m.list.SelectedCommit()
+	if j == nil || commit == nil || commit.Hash != j.Hash || !m.list.IsExpanded() {
+		return nil
+	}
+	for i, f := range m.list.ExpandedFiles() {
+		if f.Path == j.File {
+			m.list.SetFileCursor(i)
+			if j.Diff {
+				return m.openDiff()
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// openDiff shows the diff of the file selected in the expanded commit
+func (m *Model) openDiff() tea.Cmd {
+	m.diffView.Show(m.list.ExpandedFiles(), m.list.FileCursor())
+	m.diffView.SetSize(m.width, m.height)
+	m.showDiff = true
+	return m.loadFileDiff()
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestJumpList(t *testing.T) {
+	// Steps: "+h" pushes h, "<h" goes back from h, ">" goes forward.
+	// got lists the returned hashes, "-" when there was none.
+	tests := []struct {
+		name    string
+		steps   string
+		got     string
+		entries string
+	}{
+		{"back on empty list", "<a >", "- -", ""},
+		{"back records the current position", "+a +b <c <c <c", "b a -", "a b c"},
+		{"forward returns to the current position", "+a +b <c <c > > >", "b a b c -", "a b c"},
+		{"back from the only entry", "+a <a", "-", "a"},
+		{"back from another position", "+a <b >", "a b", "a b"},
+		{"new jump drops forward entries", "+a +b +c <d <d +x", "c b", "a x"},
+		{"repeated position moves to the end", "+a +b +a", "", "b a"},
+		{"current position already in the list", "+a +b <a", "b", "b a"},
+	}
+	for _, tc := range tests {
+		var l jumpList
+		var got []string
+		for _, step := range strings.Fields(tc.steps) {
+			var j jump
+			var ok bool
+			switch step[0] {
+			case '+':
+				l.push(jump{Hash: step[1:]})
+				continue
+			case '<':
+				j, ok = l.back(jump{Hash: step[1:]})
+			case '>':
+				j, ok = l.forward()
+			}
+			if !ok {
+				j.Hash = "-"
+			}
+			got = append(got, j.Hash)
+		}
+		if s := strings.Join(got, " "); s != tc.got {
+			t.Errorf("%s: got %q, want %q", tc.name, s, tc.got)
+		}
+		var entries []string
+		for _, e := range l.entries {
+			entries = append(entries, e.Hash)
+		}
+		if s := strings.Join(entries, " "); s != tc.entries {
+			t.Errorf("%s: entries %q, want %q", tc.name, s, tc.entries)
+		}
+	}
+}
+
+func TestJumpListCap(t *testing.T) {
+	var l jumpList
+	for i := range maxJumps + 50 {
+		l.push(jump{Hash: strconv.Itoa(i)})
+	}
+	if len(l.entries) != maxJumps || l.pos != maxJumps {
+		t.Fatalf("len = %d, pos = %d; want %d", len(l.entries), l.pos, maxJumps)
+	}
+	if first := l.entries[0].Hash; first != "50" {
+		t.Errorf("oldest entry = %s, want 50", first)
+	}
+	if j, ok := l.back(jump{Hash: "cur"}); !ok || j.Hash != strconv.Itoa(maxJumps+49) {
+		t.Errorf("back = %v, %v; want the newest entry", j, ok)
+	}
+	if len(l.entries) != maxJumps {
+		t.Errorf("len after back = %d, want %d", len(l.entries), maxJumps)
+	}
+}
@@ -0,0 +1,635 @@
+// Package keys is the registry of actions and their key bindings. Every
+// mode has its own table, so the same key can mean different things in the
+// commit list and the diff view, and every binding can be remapped in the
+// config file.
+package keys
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Mode is a context with its own binding table
+type Mode string
+
+// Modes, as named in the [keys] config section
+const (
+	ModeList      Mode = "list"      // commit list and insights view
+	ModeExpanded  Mode = "expanded"  // files of an expanded commit
+	ModeDiff      Mode = "diff"      // diff view
+	ModeCompare   Mode = "compare"   // comparison of two commits
+	ModeRangeDiff Mode = "rangediff" // pairs of two versions of a series
+	ModeHistogram Mode = "histogram" // focused histogram
+	ModeFilter    Mode = "filter"    // branch, author, tag and highlight overlays
+	ModeViews     Mode = "views"     // saved views picker
+	ModePalette   Mode = "palette"   // command palette, while its filter is typed
+	ModeCommand   Mode = "command"   // output of a background command
+	ModeConfirm   Mode = "confirm"   // yes/no prompts
+	ModeYank      Mode = "yank"      // key after the yank prefix
+	ModePick      Mode = "pick"      // --pick, checked before the list and expanded keys
+)
+
+// Modes lists all modes in help order
+var Modes = []Mode{ModeList, ModeExpanded, ModeDiff, ModeCompare, ModeRangeDiff, ModeHistogram, ModeFilter, ModeViews, ModePalette, ModeCommand, ModeConfirm, ModeYank, ModePick}
+
+// Title returns the heading of the mode in the help overlay
+func (mode Mode) Title() string {
+	switch mode {
+	case ModeList:
+		return "Commit list"
+	case ModeExpanded:
+		return "Expanded commit"
+	case ModeDiff:
+		return "Diff view"
+	case ModeCompare:
+		return "Compare view"
+	case ModeRangeDiff:
+		return "Range-diff view"
+	case ModeHistogram:
+		return "Histogram (focused)"
+	case ModeFilter:
+		return "Filter overlays"
+	case ModeViews:
+		return "Saved views"
+	case ModePalette:
+		return "Command palette"
+	case ModeCommand:
+		return "Command output"
+	case ModeConfirm:
+		return "Confirm prompts"
+	case ModeYank:
+		return "Copy (after yank)"
+	case ModePick:
+		return "Pick mode (--pick)"
+	}
+	return string(mode)
+}
+
+// Actions are identified as "mode.name"; the name is the key in the
+// mode's config table
+const (
+	Down            = "list.down"
+	Up              = "list.up"
+	PageDown        = "list.page_down"
+	PageUp          = "list.page_up"
+	Top             = "list.top"
+	Bottom          = "list.bottom"
+	Expand          = "list.expand"
+	BranchFilter    = "list.branch_filter"
+	AuthorFilter    = "list.author_filter"
+	TagFilter       = "list.tag_filter"
+	AuthorHighlight = "list.author_highlight"
+	Clear           = "list.clear"
+	Views           = "list.views"
+	Search          = "list.search"
+	NextMatch       = "list.next_match"
+	PrevMatch       = "list.prev_match"
+	Histogram       = "list.histogram"
+	FocusHistogram  = "list.focus_histogram"
+	Insights        = "list.insights"
+	Yank            = "list.yank"
+	Palette         = "list.palette"
+	Goto            = "list.goto"
+	JumpBack        = "list.jump_back"
+	JumpForward     = "list.jump_forward"
+	Parent          = "list.parent"
+	OtherParent     = "list.other_parent"
+	Child           = "list.child"
+	MergeInto       = "list.merge"
+	SetMark         = "list.mark"
+	GotoMark        = "list.goto_mark"
+	Marks           = "list.marks"
+	Compare         = "list.compare"
+	RangeDiff       = "list.range_diff"
+	Help            = "list.help"
+	Quit            = "list.quit"
+
+	ExpandedDown     = "expanded.down"
+	ExpandedUp       = "expanded.up"
+	ExpandedOpenDiff = "expanded.open_diff"
+	ExpandedCollapse = "expanded.collapse"
+	ExpandedYank     = "expanded.yank"
+	ExpandedQuit     = "expanded.quit"
+
+	DiffDown     = "diff.down"
+	DiffUp       = "diff.up"
+	DiffPageDown = "diff.page_down"
+	DiffPageUp   = "diff.page_up"
+	DiffTop      = "diff.top"
+	DiffBottom   = "diff.bottom"
+	DiffPrevFile = "diff.prev_file"
+	DiffNextFile = "diff.next_file"
+	DiffSplit    = "diff.split"
+	DiffYank     = "diff.yank"
+	DiffClose    = "diff.close"
+
+	DiffIgnoreAllSpace    = "diff.ignore_all_space"
+	DiffIgnoreSpaceChange = "diff.ignore_space_change"
+	DiffIgnoreBlankLines  = "diff.ignore_blank_lines"
+	DiffMoreContext       = "diff.more_context"
+	DiffLessContext       = "diff.less_context"
+	DiffFunctionContext   = "diff.function_context"
+
+	DiffNextHunk  = "diff.next_hunk"
+	DiffPrevHunk  = "diff.prev_hunk"
+	DiffFoldOpen  = "diff.fold_open"
+	DiffFoldClose = "diff.fold_close"
+	DiffSearch    = "diff.search"
+	DiffNextMatch = "diff.next_match"
+	DiffPrevMatch = "diff.prev_match"
+
+	CompareDown     = "compare.down"
+	CompareUp       = "compare.up"
+	CompareTop      = "compare.top"
+	CompareBottom   = "compare.bottom"
+	CompareOpenDiff = "compare.open_diff"
+	CompareCommits  = "compare.commits"
+	CompareSwap     = "compare.swap"
+	CompareClose    = "compare.close"
+
+	RangeDiffDown      = "rangediff.down"
+	RangeDiffUp        = "rangediff.up"
+	RangeDiffTop       = "rangediff.top"
+	RangeDiffBottom    = "rangediff.bottom"
+	RangeDiffInterdiff = "rangediff.interdiff"
+	RangeDiffClose     = "rangediff.close"
+
+	HistogramLeft     = "histogram.left"
+	HistogramRight    = "histogram.right"
+	HistogramPanLeft  = "histogram.pan_left"
+	HistogramPanRight = "histogram.pan_right"
+	HistogramStart    = "histogram.range_start"
+	HistogramEnd      = "histogram.range_end"
+	HistogramToggle   = "histogram.toggle"
+	HistogramApply    = "histogram.apply"
+	HistogramClear    = "histogram.clear"
+	HistogramZoomIn   = "histogram.zoom_in"
+	HistogramZoomOut  = "histogram.zoom_out"
+	HistogramBack     = "histogram.back"
+	HistogramQuit     = "histogram.quit"
+
+	FilterDown    = "filter.down"
+	FilterUp      = "filter.up"
+	FilterToggle  = "filter.toggle"
+	FilterExclude = "filter.exclude"
+	FilterAll     = "filter.all"
+	FilterNone    = "filter.none"
+	FilterApply   = "filter.apply"
+	FilterCancel  = "filter.cancel"
+
+	ViewsDown   = "views.down"
+	ViewsUp     = "views.up"
+	ViewsApply  = "views.apply"
+	ViewsSave   = "views.save"
+	ViewsDelete = "views.delete"
+	ViewsClose  = "views.close"
+
+	PaletteDown  = "palette.down"
+	PaletteUp    = "palette.up"
+	PaletteRun   = "palette.run"
+	PaletteClose = "palette.close"
+
+	CommandDown   = "command.down"
+	CommandUp     = "command.up"
+	CommandTop    = "command.top"
+	CommandBottom = "command.bottom"
+	CommandClose  = "command.close"
+
+	ConfirmYes = "confirm.yes"
+	ConfirmNo  = "confirm.no"
+
+	YankHash    = "yank.hash"
+	YankSubject = "yank.subject"
+	YankPath    = "yank.path"
+	YankDiff    = "yank.diff"
+
+	PickSelect = "pick.select"
+	PickAccept = "pick.accept"
+	PickExpand = "pick.expand"
+)
+
+// Action is an entry of the registry
+type Action struct {
+	ID   string
+	Keys []string // default keys
+	Help string   // "" continues the previous line, e.g. up after down
+}
+
+// Mode returns the mode the action belongs to
+func (a Action) Mode() Mode {
+	mode, _, _ := strings.Cut(a.ID, ".")
+	return Mode(mode)
+}
+
+// Name returns the action name within its mode
+func (a Action) Name() string {
+	_, name, _ := strings.Cut(a.ID, ".")
+	return name
+}
+
+// titles name actions outside the help overlay (e.g. in the command
+// palette) where their help line does not fit on its own
+var titles = map[string]string{
+	NextMatch:   "Next search match",
+	PrevMatch:   "Previous search match",
+	Help:        "Show help",
+	Goto:        "Go to revision",
+	JumpBack:    "Jump back",
+	JumpForward: "Jump forward",
+	Parent:      "Go to first parent",
+	OtherParent: "Go to next parent of a merge",
+	SetMark:     "Set mark on commit",
+	GotoMark:    "Go to mark",
+	Compare:     "Compare with base commit",
+	YankHash:    "Copy commit hash",
+	YankSubject: "Copy commit subject",
+	YankPath:    "Copy file path",
+	YankDiff:    "Copy file diff",
+}
+
+// Title returns a standalone description of the action
+func (a Action) Title() string {
+	if t, ok := titles[a.ID]; ok {
+		return t
+	}
+	return a.Help
+}
+
+// registry holds every action in help order. Keys are in the notation of
+// tea.KeyMsg.String(), except that the space bar is "space"; a sequence of
+// keys is written with spaces between them, e.g. "z o".
+var registry = []Action{
+	{Down, []string{"j", "down"}, "Move cursor down/up"},
+	{Up, []string{"k", "up"}, ""},
+	{PageDown, []string{"ctrl+d", "pgdown"}, "Page down/up"},
+	{PageUp, []string{"ctrl+u", "pgup"}, ""},
+	{Top, []string{"g", "home"}, "Jump to first/last"},
+	{Bottom, []string{"G", "end"}, ""},
+	{Expand, []string{"enter"}, "Expand commit"},
+	{AuthorFilter, []string{"a"}, "Author filter"},
+	{BranchFilter, []string{"b"}, "Branch filter"},
+	{TagFilter, []string{"t"}, "Tag filter"},
+	{AuthorHighlight, []string{"A"}, "Author highlight"},
+	{Histogram, []string{"r"}, "Toggle histogram (range)"},
+	{FocusHistogram, []string{"tab"}, "Focus histogram"},
+	{Clear, []string{"c"}, "Clear filters and search"},
+	{Views, []string{"v"}, "Saved views"},
+	{Search, []string{"/"}, "Search"},
+	{NextMatch, []string{"n"}, "Next/prev match"},
+	{PrevMatch, []string{"N"}, ""},
+	{Insights, []string{"i"}, "Toggle insights"},
+	{Yank, []string{"y"}, "Copy (yank) prefix"},
+	{Palette, []string{":", "ctrl+p"}, "Command palette"},
+	{Goto, []string{"ctrl+g"}, "Go to revision (tag, branch~n, hash, @{date})"},
+	{JumpBack, []string{"ctrl+o"}, "Jump back/forward (any view)"},
+	{JumpForward, []string{"ctrl+n"}, ""},
+	{Parent, []string{"p"}, "Go to first parent/other parents of a merge"},
+	{OtherParent, []string{"P"}, ""},
+	{Child, []string{"C"}, "Go to child (picker if several)"},
+	{MergeInto, []string{"M"}, "Go to the merge into a branch"},
+	{SetMark, []string{"m"}, "Set mark (m a), go to mark (' a)"},
+	{GotoMark, []string{"'", "`"}, ""},
+	{Marks, []string{"B"}, "List marks"},
+	{Compare, []string{"="}, "Set compare base, compare with it"},
+	{RangeDiff, []string{"R"}, "Range-diff (branch before/after a rebase)"},
+	{Help, []string{"?"}, "This help"},
+	{Quit, []string{"q", "ctrl+c"}, "Quit"},
+
+	{ExpandedDown, []string{"j", "down"}, "Next/prev file"},
+	{ExpandedUp, []string{"k", "up"}, ""},
+	{ExpandedOpenDiff, []string{"enter"}, "Open diff"},
+	{ExpandedCollapse, []string{"esc"}, "Collapse"},
+	{ExpandedYank, []string{"y"}, "Copy (yank) prefix"},
+	{ExpandedQuit, []string{"q", "ctrl+c"}, "Quit"},
+
+	{DiffDown, []string{"j", "down"}, "Scroll down/up"},
+	{DiffUp, []string{"k", "up"}, ""},
+	{DiffPageDown, []string{"ctrl+d", "pgdown", "space"}, "Page down/up"},
+	{DiffPageUp, []string{"ctrl+u", "pgup"}, ""},
+	{DiffTop, []string{"g", "home"}, "Jump to top/bottom"},
+	{DiffBottom, []string{"G", "end"}, ""},
+	{DiffPrevFile, []string{"h", "left"}, "Previous/next file"},
+	{DiffNextFile, []string{"l", "right"}, ""},
+	{DiffSplit, []string{"s"}, "Toggle side-by-side/unified"},
+	{DiffIgnoreAllSpace, []string{"w"}, "Ignore all whitespace (-w)"},
+	{DiffIgnoreSpaceChange, []string{"b"}, "Ignore whitespace changes (-b)"},
+	{DiffIgnoreBlankLines, []string{"B"}, "Ignore blank lines"},
+	{DiffMoreContext, []string{"+"}, "More/fewer context lines"},
+	{DiffLessContext, []string{"-"}, ""},
+	{DiffFunctionContext, []string{"f"}, "Show whole functions (-W)"},
+	{DiffNextHunk, []string{"] c"}, "Next/prev hunk"},
+	{DiffPrevHunk, []string{"[ c"}, ""},
+	{DiffFoldOpen, []string{"z o"}, "Open/close fold (hunk, then file)"},
+	{DiffFoldClose, []string{"z c"}, ""},
+	{DiffSearch, []string{"/"}, "Search in diff"},
+	{DiffNextMatch, []string{"n"}, "Next/prev match"},
+	{DiffPrevMatch, []string{"N"}, ""},
+	{DiffYank, []string{"y"}, "Copy (yank) prefix"},
+	{DiffClose, []string{"q", "esc"}, "Close"},
+
+	{CompareDown, []string{"j", "down"}, "Next/prev file"},
+	{CompareUp, []string{"k", "up"}, ""},
+	{CompareTop, []string{"g", "home"}, "First/last file"},
+	{CompareBottom, []string{"G", "end"}, ""},
+	{CompareOpenDiff, []string{"enter"}, "Open diff"},
+	{CompareCommits, []string{"c"}, "Toggle commits in range"},
+	{CompareSwap, []string{"s"}, "Swap sides"},
+	{CompareClose, []string{"q", "esc"}, "Close"},
+
+	{RangeDiffDown, []string{"j", "down"}, "Next/prev commit"},
+	{RangeDiffUp, []string{"k", "up"}, ""},
+	{RangeDiffTop, []string{"g", "home"}, "First/last commit"},
+	{RangeDiffBottom, []string{"G", "end"}, ""},
+	{RangeDiffInterdiff, []string{"enter"}, "Show interdiff of a modified commit"},
+	{RangeDiffClose, []string{"q", "esc"}, "Close"},
+
+	{HistogramLeft, []string{"h", "left"}, "Move cursor"},
+	{HistogramRight, []string{"l", "right"}, ""},
+	{HistogramPanLeft, []string{"H"}, "Pan view"},
+	{HistogramPanRight, []string{"L"}, ""},
+	{HistogramStart, []string{"["}, "Set range start/end"},
+	{HistogramEnd, []string{"]"}, ""},
+	{HistogramToggle, []string{"space"}, "Toggle selection"},
+	{HistogramZoomIn, []string{"+", "="}, "Zoom in/out"},
+	{HistogramZoomOut, []string{"-", "_"}, ""},
+	{HistogramApply, []string{"enter"}, "Apply filter"},
+	{HistogramClear, []string{"esc"}, "Clear range and return"},
+	{HistogramBack, []string{"tab"}, "Return to list"},
+	{HistogramQuit, []string{"q", "ctrl+c"}, "Quit"},
+
+	{FilterDown, []string{"j", "down"}, "Move cursor"},
+	{FilterUp, []string{"k", "up"}, ""},
+	{FilterToggle, []string{"space"}, "Toggle (highlight: select)"},
+	{FilterExclude, []string{"!", "x"}, "Exclude"},
+	{FilterAll, []string{"a"}, "Select all/none"},
+	{FilterNone, []string{"n"}, ""},
+	{FilterApply, []string{"enter"}, "Apply"},
+	{FilterCancel, []string{"esc"}, "Cancel"},
+
+	{ViewsDown, []string{"j", "down"}, "Move cursor"},
+	{ViewsUp, []string{"k", "up"}, ""},
+	{ViewsApply, []string{"enter"}, "Apply view"},
+	{ViewsSave, []string{"s"}, "Save current filters and search"},
+	{ViewsDelete, []string{"d"}, "Delete view (press twice)"},
+	{ViewsClose, []string{"esc", "q"}, "Close"},
+
+	{PaletteDown, []string{"down", "ctrl+n", "tab"}, "Next/prev item"},
+	{PaletteUp, []string{"up", "ctrl+p", "shift+tab"}, ""},
+	{PaletteRun, []string{"enter"}, "Run item (complete a command taking arguments)"},
+	{PaletteClose, []string{"esc"}, "Close"},
+
+	{CommandDown, []string{"j", "down"}, "Scroll down/up"},
+	{CommandUp, []string{"k", "up"}, ""},
+	{CommandTop, []string{"g", "home"}, "Jump to top/bottom"},
+	{CommandBottom, []string{"G", "end"}, ""},
+	{CommandClose, []string{"esc", "q", "enter"}, "Close"},
+
+	{ConfirmYes, []string{"y", "Y"}, "Yes/no"},
+	{ConfirmNo, []string{"n", "N", "esc", "q", "enter"}, ""},
+
+	{YankHash, []string{"h"}, "Commit hash"},
+	{YankSubject, []string{"s"}, "Commit subject"},
+	{YankPath, []string{"p"}, "File path"},
+	{YankDiff, []string{"d"}, "File diff"},
+
+	{PickSelect, []string{"space"}, "Select commit or file"},
+	{PickAccept, []string{"enter"}, "Print selection and exit"},
+	{PickExpand, []string{"l", "right"}, "Expand commit to pick files"},
+}
+
+// Registry returns all actions in help order
+func Registry() []Action {
+	return slices.Clone(registry)
+}
+
+// find returns the registry entry of id
+func find(id string) (Action, bool) {
+	for _, a := range registry {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return Action{}, false
+}
+
+// Map binds each action ID to its keys
+type Map map[string][]string
+
+// Default returns the built-in bindings
+func Default() Map {
+	m := make(Map, len(registry))
+	for _, a := range registry {
+		m[a.ID] = slices.Clone(a.Keys)
+	}
+	return m
+}
+
+// Actions returns the action names of mode, sorted
+func Actions(mode Mode) []string {
+	var names []string
+	for _, a := range registry {
+		if a.Mode() == mode {
+			names = append(names, a.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// modeNames lists the modes for error messages
+func modeNames() string {
+	names := make([]string, len(Modes))
+	for i, mode := range Modes {
+		names[i] = string(mode)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Apply replaces the keys of the actions in overrides, given per mode and
+// action name as in the config file. An empty key list unbinds the action.
+func (m Map) Apply(overrides map[string]map[string][]string) error {
+	for mode, actions := range overrides {
+		if !slices.Contains(Modes, Mode(mode)) {
+			return fmt.Errorf("keys.%s: unknown mode (use %s)", mode, modeNames())
+		}
+		for name, keys := range actions {
+			id := mode + "." + name
+			if _, ok := find(id); !ok {
+				return fmt.Errorf("keys.%s: unknown action (use %s)", id, strings.Join(Actions(Mode(mode)), ", "))
+			}
+			for _, k := range keys {
+				if strings.TrimSpace(k) == "" && k != " " {
+					return fmt.Errorf("keys.%s: empty key", id)
+				}
+			}
+			m[id] = normalizeAll(keys)
+		}
+	}
+	return nil
+}
+
+// normalize maps the notations of one key to the one used in tables
+func normalize(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+func normalizeAll(keys []string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = normalize(k)
+	}
+	return out
+}
+
+// Is reports whether key, as returned by tea.KeyMsg.String(), is bound to
+// action
+func (m Map) Is(key, action string) bool {
+	return slices.Contains(m[action], normalize(key))
+}
+
+// Lookup returns the action of mode bound to key ("" if none)
+func (m Map) Lookup(mode Mode, key string) string {
+	key = normalize(key)
+	for _, a := range registry {
+		if a.Mode() == mode && slices.Contains(m[a.ID], key) {
+			return a.ID
+		}
+	}
+	return ""
+}
+
+// IsPrefix reports whether key starts a key sequence bound in mode, e.g.
+// "z" for "z o". Keys of a sequence are separated by spaces.
+func (m Map) IsPrefix(mode Mode, key string) bool {
+	prefix := normalize(key) + " "
+	for _, a := range registry {
+		if a.Mode() != mode {
+			continue
+		}
+		for _, k := range m[a.ID] {
+			if strings.HasPrefix(k, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Label returns the first key of action for help and footer hints
+// ("" if unbound)
+func (m Map) Label(action string) string {
+	if keys := m[action]; len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// Binding is one line of the help for a mode
+type Binding struct {
+	Keys string // e.g. "j/k"
+	Help string
+}
+
+// Bindings returns the help lines of mode. Actions sharing a line show
+// their first keys joined with "/"; unbound actions are left out.
+func (m Map) Bindings(mode Mode) []Binding {
+	var lines []Binding
+	for _, a := range registry {
+		if a.Mode() != mode {
+			continue
+		}
+		label := m.Label(a.ID)
+		if a.Help == "" && len(lines) > 0 {
+			last := &lines[len(lines)-1]
+			switch {
+			case label == "":
+			case last.Keys == "":
+				last.Keys = label
+			default:
+				last.Keys += "/" + label
+			}
+			continue
+		}
+		// Unbound lines are kept for the actions continuing them
+		lines = append(lines, Binding{Keys: label, Help: a.Help})
+	}
+	return slices.DeleteFunc(lines, func(b Binding) bool { return b.Keys == "" })
+}
+
+// shadowing is a mode whose keys are checked before those of another:
+// all of its actions, or only the ones listed
+type shadowing struct {
+	mode    Mode
+	actions []string
+}
+
+// jumps are the list actions checked before the keys of every view
+var jumps = shadowing{ModeList, []string{JumpBack, JumpForward}}
+
+// shadowingModes lists, for each mode, the modes whose keys are checked
+// first when it is active
+var shadowingModes = map[Mode][]shadowing{
+	ModeList:      {{mode: ModePick}},
+	ModeExpanded:  {jumps, {mode: ModePick}},
+	ModeDiff:      {jumps},
+	ModeCompare:   {jumps},
+	ModeRangeDiff: {jumps},
+	ModeHistogram: {jumps},
+	ModePick:      {jumps},
+}
+
+// Check reports keys bound to more than one action of a mode, which would
+// leave all but one of them unreachable
+func (m Map) Check() error {
+	var problems []string
+	for _, mode := range Modes {
+		seen := make(map[string]string)
+		for _, a := range registry {
+			if a.Mode() != mode {
+				continue
+			}
+			for _, k := range m[a.ID] {
+				if other, ok := seen[k]; ok {
+					problems = append(problems, fmt.Sprintf("%q is bound to both %s and %s", k, other, a.ID))
+					continue
+				}
+				seen[k] = a.ID
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("keys: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Shadowed reports bindings that never fire because a mode checked first
+// takes the key. Overlaps present in the defaults are intended (pick mode
+// takes over enter) and not reported.
+func (m Map) Shadowed() []string {
+	defaults := Default()
+	var warnings []string
+	for _, mode := range Modes {
+		for _, first := range shadowingModes[mode] {
+			for _, a := range registry {
+				if a.Mode() != mode {
+					continue
+				}
+				for _, k := range m[a.ID] {
+					winner := m.Lookup(first.mode, k)
+					if winner == "" || (first.actions != nil && !slices.Contains(first.actions, winner)) ||
+						(defaults.Is(k, a.ID) && defaults.Is(k, winner)) {
+						continue
+					}
+					warnings = append(warnings, fmt.Sprintf("%q of %s is shadowed by %s", k, a.ID, winner))
+				}
+			}
+		}
+	}
+	return warnings
+}
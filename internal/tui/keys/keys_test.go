@@ -0,0 +1,132 @@
+package keys
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultsHaveNoConflicts(t *testing.T) {
+	m := Default()
+	if err := m.Check(); err != nil {
+		t.Errorf("Check: %v", err)
+	}
+	if w := m.Shadowed(); len(w) > 0 {
+		t.Errorf("Shadowed = %q, want none", w)
+	}
+	for _, a := range Registry() {
+		if len(a.Keys) == 0 {
+			t.Errorf("%s has no default key", a.ID)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	m := Default()
+	err := m.Apply(map[string]map[string][]string{
+		"list": {"help": {"f1", "?"}, "insights": {}},
+		"diff": {"next_file": {"n"}, "page_down": {" "}},
+	})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	if !m.Is("?", Help) || !m.Is("f1", Help) || m.Label(Help) != "f1" {
+		t.Errorf("help keys = %q, want f1 and ?", m[Help])
+	}
+	if m.Is("i", Insights) || m.Label(Insights) != "" {
+		t.Errorf("insights should be unbound, got %q", m[Insights])
+	}
+	if !m.Is("ctrl+c", Quit) || m.Label(Quit) != "q" {
+		t.Errorf("quit should keep its defaults, got %q", m[Quit])
+	}
+	if got := m.Lookup(ModeDiff, " "); got != DiffPageDown || m.Label(DiffPageDown) != "space" {
+		t.Errorf("space in diff = %q, want %s", got, DiffPageDown)
+	}
+	if got := m.Lookup(ModeDiff, "n"); got != DiffNextFile {
+		t.Errorf("n in diff = %q, want %s", got, DiffNextFile)
+	}
+	if got := m.Lookup(ModeList, "n"); got != NextMatch {
+		t.Errorf("n in list = %q, want %s (modes are separate)", got, NextMatch)
+	}
+}
+
+func TestIsPrefix(t *testing.T) {
+	m := Default()
+	if !m.IsPrefix(ModeDiff, "z") || !m.IsPrefix(ModeDiff, "]") {
+		t.Error("z and ] should start diff key sequences")
+	}
+	if m.IsPrefix(ModeDiff, "j") || m.IsPrefix(ModeList, "z") {
+		t.Error("j in diff and z in list start no sequence")
+	}
+	if got := m.Lookup(ModeDiff, "z c"); got != DiffFoldClose {
+		t.Errorf("z c in diff = %q, want %s", got, DiffFoldClose)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]map[string][]string
+		want      string
+	}{
+		{"unknown mode", map[string]map[string][]string{"lst": {"help": {"?"}}}, "keys.lst: unknown mode"},
+		{"unknown action", map[string]map[string][]string{"list": {"halp": {"?"}}}, "keys.list.halp: unknown action"},
+		{"empty key", map[string]map[string][]string{"list": {"help": {""}}}, "keys.list.help: empty key"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Default().Apply(tc.overrides)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckReportsDuplicates(t *testing.T) {
+	m := Default()
+	if err := m.Apply(map[string]map[string][]string{"list": {"help": {"n"}}}); err != nil {
+		t.Fatal(err)
+	}
+	err := m.Check()
+	if err == nil || !strings.Contains(err.Error(), `"n" is bound to both list.next_match and list.help`) {
+		t.Errorf("Check = %v, want the n conflict", err)
+	}
+}
+
+func TestShadowed(t *testing.T) {
+	m := Default()
+	if err := m.Apply(map[string]map[string][]string{"pick": {"expand": {"b"}}}); err != nil {
+		t.Fatal(err)
+	}
+	got := m.Shadowed()
+	if len(got) != 1 || got[0] != `"b" of list.branch_filter is shadowed by pick.expand` {
+		t.Errorf("Shadowed = %q", got)
+	}
+
+	// The jump keys work in every view, but the rest of the list does not
+	m = Default()
+	if err := m.Apply(map[string]map[string][]string{"diff": {"next_file": {"ctrl+o"}, "prev_file": {"j"}}}); err != nil {
+		t.Fatal(err)
+	}
+	got = m.Shadowed()
+	if len(got) != 1 || got[0] != `"ctrl+o" of diff.next_file is shadowed by list.jump_back` {
+		t.Errorf("Shadowed = %q", got)
+	}
+}
+
+func TestBindings(t *testing.T) {
+	m := Default()
+	if err := m.Apply(map[string]map[string][]string{"list": {"down": {}, "next_match": {}}}); err != nil {
+		t.Fatal(err)
+	}
+	lines := m.Bindings(ModeList)
+	if lines[0] != (Binding{Keys: "k", Help: "Move cursor down/up"}) {
+		t.Errorf("first line = %+v, want up alone", lines[0])
+	}
+	for _, b := range lines {
+		if b.Help == "Next/prev match" && b.Keys != "N" {
+			t.Errorf("match line keys = %q, want N", b.Keys)
+		}
+	}
+}
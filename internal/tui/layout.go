@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/keys"
+	"github.com/nogo/gitree/internal/tui/search"
 	"github.com/nogo/gitree/internal/tui/text"
 )
 
@@ -155,16 +157,17 @@ func (m Model) renderInsightsFooter() string {
 	commitStats := fmt.Sprintf("%d/%d commits", filtered, total)
 
 	// Context-sensitive keybindings
-	var keys string
+	var hints string
 	if m.HistogramFocused() {
-		keys = "[←→]nav [+/-]zoom [[]start []]end [enter]apply [tab]back"
+		hints = "[←→]nav [+/-]zoom [[]start []]end [enter]apply [tab]back"
 	} else {
-		keys = "[i]graph [a]uthor [b]ranch [t]ag [r]ange [c]lear [q]uit"
+		hints = m.footerHints(keys.Insights, "graph", keys.AuthorFilter, "author", keys.BranchFilter, "branch",
+			keys.TagFilter, "tag", keys.Histogram, "range", keys.Clear, "clear", keys.Quit, "quit")
 	}
 
 	// Build footer with spacing
 	left := commitStats
-	right := keys
+	right := hints
 
 	spacing := m.width - len(left) - len(right)
 	if spacing < 2 {
@@ -177,14 +180,24 @@ func (m Model) renderInsightsFooter() string {
 func (m Model) renderFooter() string {
 	// Search input mode - show search box in footer
 	if m.SearchInputMode() {
-		left := "Search: " + m.SearchInputView()
-		right := "[Enter] [Esc]"
+		prompt := fmt.Sprintf("Search [%s]: ", m.SearchMode())
+		if m.SearchError() != nil {
+			prompt = fmt.Sprintf("Search [%s] ✗ ", m.SearchMode())
+		}
+		left := prompt + m.SearchInputView()
+		right := "[Tab] mode [Enter] [Esc]"
+		if err := m.SearchError(); err != nil {
+			right = err.Error()
+		}
 
-		spacing := m.width - len("Search: ") - 40 - len(right) // textinput width is 40
+		spacing := m.width - text.Width(prompt) - 40 - text.Width(right) // textinput width is 40
 		if spacing < 2 {
 			spacing = 2
 		}
 
+		if m.SearchError() != nil {
+			return FooterStyle.Render(left+strings.Repeat(" ", spacing)) + ErrorStyle.Render(right)
+		}
 		return FooterStyle.Render(left + strings.Repeat(" ", spacing) + right)
 	}
 
@@ -206,21 +219,36 @@ func (m Model) renderFooter() string {
 	if m.BranchFilterActive() {
 		branchFiltered := m.FilteredBranchCount()
 		branchTotal := m.TotalBranchCount()
-		filterParts = append(filterParts, fmt.Sprintf("branch:%d/%d", branchFiltered, branchTotal))
+		filterParts = append(filterParts, fmt.Sprintf("branch:%d/%d", branchFiltered, branchTotal)+excludedSuffix(m.filters.ExcludedBranchCount()))
 	}
 
 	// Author filter status
 	if m.AuthorFilterActive() {
 		authorFiltered := m.FilteredAuthorCount()
 		authorTotal := m.TotalAuthorCount()
-		filterParts = append(filterParts, fmt.Sprintf("author:%d/%d", authorFiltered, authorTotal))
+		filterParts = append(filterParts, fmt.Sprintf("author:%d/%d", authorFiltered, authorTotal)+excludedSuffix(m.filters.ExcludedAuthorCount()))
 	}
 
 	// Tag filter status
 	if m.TagFilterActive() {
 		tagFiltered := m.FilteredTagCount()
 		tagTotal := m.TotalTagCount()
-		filterParts = append(filterParts, fmt.Sprintf("tag:%d/%d", tagFiltered, tagTotal))
+		filterParts = append(filterParts, fmt.Sprintf("tag:%d/%d", tagFiltered, tagTotal)+excludedSuffix(m.filters.ExcludedTagCount()))
+	}
+
+	// Commits piped in with --stdin
+	if n := m.StdinCommitCount(); n > 0 {
+		filterParts = append(filterParts, fmt.Sprintf("stdin:%d", n))
+	}
+
+	// Multi-selection in --pick mode
+	if n := m.PickCount(); n > 0 {
+		filterParts = append(filterParts, fmt.Sprintf("picked:%d", n))
+	}
+
+	// Base of the next comparison
+	if base := m.CompareBase(); base != "" {
+		filterParts = append(filterParts, "compare:"+base)
 	}
 
 	// Author highlight status
@@ -234,13 +262,19 @@ func (m Model) renderFooter() string {
 	}
 
 	// Search status
-	if m.SearchActive() {
+	if m.SearchLoading() {
+		filterParts = append(filterParts, fmt.Sprintf("%s searching files \"%s\"", m.SpinnerFrame(), m.SearchQuery()))
+	} else if m.SearchActive() {
 		matchCount := m.SearchMatchCount()
+		mode := ""
+		if m.SearchMode() != search.ModeSubstring {
+			mode = m.SearchMode().String() + " "
+		}
 		if matchCount > 0 {
-			filterParts = append(filterParts, fmt.Sprintf("match %d/%d \"%s\"",
-				m.SearchCurrentMatch(), matchCount, m.SearchQuery()))
+			filterParts = append(filterParts, fmt.Sprintf("match %d/%d %s\"%s\"",
+				m.SearchCurrentMatch(), matchCount, mode, m.SearchQuery()))
 		} else {
-			filterParts = append(filterParts, fmt.Sprintf("no matches \"%s\"", m.SearchQuery()))
+			filterParts = append(filterParts, fmt.Sprintf("no matches %s\"%s\"", mode, m.SearchQuery()))
 		}
 	}
 
@@ -250,23 +284,70 @@ func (m Model) renderFooter() string {
 	}
 
 	// Condensed keybindings - context-sensitive
-	var keys string
+	var hints string
 	if m.HistogramFocused() {
-		keys = "[←→]nav [+/-]zoom [[]start []]end [enter]apply [esc]back"
+		hints = "[←→]nav [+/-]zoom [[]start []]end [enter]apply [esc]back"
+	} else if m.CompareBase() != "" {
+		hints = m.footerHints(keys.Compare, "compare", keys.Quit, "")
 	} else if m.SearchActive() && m.SearchMatchCount() > 0 {
-		keys = "[n]ext [N]prev [t]ime [c]lear [q]"
+		hints = m.footerHints(keys.NextMatch, "next", keys.PrevMatch, "prev", keys.Clear, "clear", keys.Quit, "")
 	} else {
-		keys = "[i]nsights [h]elp [/]search [a]uthor [b]ranch [t]ag [r]ange [c]lear [q]"
+		hints = m.footerHints(keys.Insights, "insights", keys.Help, "help", keys.Search, "search",
+			keys.AuthorFilter, "author", keys.BranchFilter, "branch", keys.TagFilter, "tag",
+			keys.Histogram, "range", keys.Views, "views", keys.Clear, "clear", keys.Quit, "")
 	}
 
 	// Build footer with spacing
 	left := fmt.Sprintf("%s %s%s", watchStatus, commitStats, filterStats)
-	right := keys
+	right := hints
+	rightStyle := FooterStyle
+
+	// Pending yank and transient messages replace the keybindings
+	if m.YankPending() {
+		right = m.yankHint()
+	} else if hint := m.markHint(); hint != "" {
+		right = hint
+	} else if prompt := m.GotoPrompt(); prompt != "" {
+		right = prompt
+		rightStyle = FlashStyle
+	} else if flash, isErr := m.Flash(); flash != "" {
+		right = flash
+		rightStyle = FlashStyle
+		if isErr {
+			rightStyle = ErrorStyle
+		}
+	}
 
-	spacing := m.width - len(left) - len(right)
+	spacing := m.width - len(left) - text.Width(right)
 	if spacing < 2 {
 		spacing = 2
 	}
 
-	return FooterStyle.Render(left + strings.Repeat(" ", spacing) + right)
+	return FooterStyle.Render(left+strings.Repeat(" ", spacing)) + rightStyle.Render(right)
+}
+
+// excludedSuffix formats the number of excluded entries of a filter ("" if none)
+func excludedSuffix(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" !%d", n)
+}
+
+// footerHints formats action/word pairs as "[i]nsights", or "[?]help" when
+// the bound key is not the word's first letter. Unbound actions are left out.
+func (m Model) footerHints(pairs ...string) string {
+	var parts []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		key, word := m.keys.Label(pairs[i]), pairs[i+1]
+		switch {
+		case key == "":
+			continue
+		case word != "" && strings.HasPrefix(word, key):
+			parts = append(parts, "["+key+"]"+word[len(key):])
+		default:
+			parts = append(parts, "["+key+"]"+word)
+		}
+	}
+	return strings.Join(parts, " ")
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nogo/gitree/internal/domain"
 	"github.com/nogo/gitree/internal/tui/text"
+	"github.com/nogo/gitree/internal/tui/theme"
 )
 
 const (
@@ -18,47 +19,41 @@ const (
 
 // Styles for expanded view
 var (
-	ExpandedBorderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("62"))
-
-	ExpandedLabelStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("241"))
-
-	ExpandedValueStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("252"))
-
-	ExpandedHashStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("214"))
-
-	ExpandedMessageStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("252"))
-
-	FileAddedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("34"))
-
-	FileModifiedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("214"))
-
-	FileDeletedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("196"))
-
-	FileRenamedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("51"))
-
-	FileSelectedStyle = lipgloss.NewStyle().
-				Bold(true).
-				Background(lipgloss.Color("237"))
-
-	AdditionsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("34"))
-
-	DeletionsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196"))
-
-	ExpandedHelpStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("241"))
+	ExpandedBorderStyle  lipgloss.Style
+	ExpandedLabelStyle   lipgloss.Style
+	ExpandedValueStyle   lipgloss.Style
+	ExpandedHashStyle    lipgloss.Style
+	ExpandedMessageStyle lipgloss.Style
+	FileAddedStyle       lipgloss.Style
+	FileModifiedStyle    lipgloss.Style
+	FileDeletedStyle     lipgloss.Style
+	FileRenamedStyle     lipgloss.Style
+	FileSelectedStyle    lipgloss.Style
+	AdditionsStyle       lipgloss.Style
+	DeletionsStyle       lipgloss.Style
+	ExpandedHelpStyle    lipgloss.Style
 )
 
+// setExpandedStyles rebuilds the styles of the expanded view from t
+func setExpandedStyles(t theme.Theme) {
+	ExpandedBorderStyle = lipgloss.NewStyle().Foreground(t.Border)
+	ExpandedLabelStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	ExpandedValueStyle = lipgloss.NewStyle().Foreground(t.Text)
+	ExpandedHashStyle = lipgloss.NewStyle().Foreground(t.Hash)
+	ExpandedMessageStyle = lipgloss.NewStyle().Foreground(t.Text)
+	FileAddedStyle = lipgloss.NewStyle().Foreground(t.Added)
+	FileModifiedStyle = lipgloss.NewStyle().Foreground(t.Modified)
+	FileDeletedStyle = lipgloss.NewStyle().Foreground(t.Deleted)
+	FileRenamedStyle = lipgloss.NewStyle().Foreground(t.Renamed)
+	FileSelectedStyle = lipgloss.NewStyle().Bold(true).Background(t.Selection)
+	if t.NoColor {
+		FileSelectedStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+	}
+	AdditionsStyle = lipgloss.NewStyle().Foreground(t.Added)
+	DeletionsStyle = lipgloss.NewStyle().Foreground(t.Deleted)
+	ExpandedHelpStyle = lipgloss.NewStyle().Foreground(t.Muted)
+}
+
 // renderExpanded renders the expanded detail section for a commit
 // Returns multiple lines that should be inserted after the commit row
 func (m Model) renderExpanded(commit *domain.Commit, files []domain.FileChange, fileCursor int, fileScrollOffset int, loading bool) []string {
@@ -182,7 +177,7 @@ func (m Model) renderTwoColumnExpanded(commit *domain.Commit, files []domain.Fil
 
 	// Bottom border with help text centered
 	// Bottom: ╚ + inner + ╝ = totalWidth, so inner = totalWidth - 2
-	help := " [j/k] file  [Enter] diff  [Esc] close "
+	help := m.expandedHelp()
 	bottomInner := totalWidth - 2
 	helpLen := len(help)
 	if helpLen > bottomInner {
@@ -225,7 +220,7 @@ func (m Model) renderSingleColumnExpanded(commit *domain.Commit, files []domain.
 	}
 
 	// Bottom border with help text centered
-	help := " [j/k] file  [Enter] diff  [Esc] close "
+	help := m.expandedHelp()
 	borderWidth := totalWidth - 2 // -2 for ╚ and ╝
 	helpLen := len(help)
 	if helpLen > borderWidth {
@@ -356,6 +351,9 @@ func (m Model) renderFilesColumn(files []domain.FileChange, cursor int, scrollOf
 		if selected {
 			cursorStr = "> "
 		}
+		if commit := m.SelectedCommit(); commit != nil && m.isPicked(commit.Hash, f.Path) {
+			cursorStr = cursorStr[:1] + "+"
+		}
 
 		// Path (truncated)
 		pathWidth := width - 20 // space for cursor, status, stats
@@ -385,6 +383,14 @@ func (m Model) renderFilesColumn(files []domain.FileChange, cursor int, scrollOf
 	return lines
 }
 
+// expandedHelp returns the key hints shown in the bottom border
+func (m Model) expandedHelp() string {
+	if m.pickMarker != nil {
+		return " [j/k] file  [Space] pick  [Enter] print  [Esc] close "
+	}
+	return " [j/k] file  [Enter] diff  [Esc] close "
+}
+
 func (m Model) wrapInBorder(content string, totalWidth int) string {
 	innerWidth := totalWidth - 4
 	padded := padRight(content, innerWidth)
@@ -5,7 +5,7 @@ package list
 //
 // Layout: | Cursor | Graph | Message (with badges) | Author | Date | Hash |
 type RowLayout struct {
-	Cursor  int // cursor indicator width (2: "> " or "  ")
+	Cursor  int // cursor indicator and mark gutter width (3: "> a" or "   ")
 	Graph   int // graph column width (capped)
 	Message int // commit message width (includes badges, flexible)
 	Author  int // author name width (fixed)
@@ -15,7 +15,7 @@ type RowLayout struct {
 
 // Layout constants - single source of truth
 const (
-	ColCursor     = 2
+	ColCursor     = 3
 	ColAuthor     = 12
 	ColDate       = 10
 	ColHash       = 7
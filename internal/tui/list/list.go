@@ -6,6 +6,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nogo/gitree/internal/domain"
 	"github.com/nogo/gitree/internal/tui/graph"
+	"github.com/nogo/gitree/internal/tui/keys"
 	"github.com/nogo/gitree/internal/tui/text"
 )
 
@@ -20,7 +21,13 @@ type Model struct {
 	height            int
 	ready             bool
 	highlightedEmails map[string]bool // emails to highlight (nil = no highlight)
+	highlightedHashes map[string]bool // commits to highlight (nil = no highlight)
 	matchIndices      map[int]bool    // indices of search matches (nil = no search)
+	matchHighlighter  func(message string) []text.Span // spans to emphasize in matched messages (nil = none)
+	pickMarker        func(hash, path string) bool     // whether a commit ("" path) or file is picked (nil = none)
+	keys              keys.Map                         // navigation bindings
+	flashHash         string                           // row flashed after a jump ("" = none)
+	marks             map[string]string                // commit hash → mark shown in the gutter
 
 	// Expansion state
 	expanded         bool                 // whether a commit is expanded
@@ -34,9 +41,15 @@ func New(repo *domain.Repository) Model {
 	return Model{
 		commits: repo.Commits,
 		graph:   graph.NewRenderer(repo.Commits, repo.Branches, repo.HEAD),
+		keys:    keys.Default(),
 	}
 }
 
+// SetKeyMap replaces the navigation bindings
+func (m *Model) SetKeyMap(km keys.Map) {
+	m.keys = km
+}
+
 // SetRepo updates the list with new repository data
 func (m *Model) SetRepo(repo *domain.Repository) {
 	// Preserve cursor position if possible
@@ -116,23 +129,23 @@ func (m *Model) recalculateLayout() {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
+		switch key := msg.String(); {
 		// Vertical movement
-		case "j", "down":
+		case m.keys.Is(key, keys.Down):
 			m.cursorDown(1)
-		case "k", "up":
+		case m.keys.Is(key, keys.Up):
 			m.cursorUp(1)
 
 		// Page movement
-		case "ctrl+d", "pgdown":
+		case m.keys.Is(key, keys.PageDown):
 			m.cursorDown(m.height / 2)
-		case "ctrl+u", "pgup":
+		case m.keys.Is(key, keys.PageUp):
 			m.cursorUp(m.height / 2)
 
 		// Jump to edges
-		case "g", "home":
+		case m.keys.Is(key, keys.Top):
 			m.cursorTo(0)
-		case "G", "end":
+		case m.keys.Is(key, keys.Bottom):
 			m.cursorTo(len(m.commits) - 1)
 		}
 		m.syncViewport()
@@ -350,6 +363,47 @@ func (m *Model) SetHighlightedEmails(emails []string) {
 	}
 }
 
+// SetHighlightedHashes sets which commits to highlight (nil = no highlight).
+// Combined with highlighted emails, a commit must match both.
+func (m *Model) SetHighlightedHashes(hashes []string) {
+	if len(hashes) == 0 {
+		m.highlightedHashes = nil
+	} else {
+		m.highlightedHashes = make(map[string]bool, len(hashes))
+		for _, h := range hashes {
+			m.highlightedHashes[h] = true
+		}
+	}
+}
+
+// isDimmed returns whether a commit is outside the highlighted authors or commits
+func (m Model) isDimmed(c domain.Commit) bool {
+	if m.highlightedEmails != nil && !m.highlightedEmails[strings.ToLower(c.Email)] {
+		return true
+	}
+	return m.highlightedHashes != nil && !m.highlightedHashes[c.Hash]
+}
+
+// SetPickMarker sets how picked commits and files are recognized (nil = none)
+func (m *Model) SetPickMarker(fn func(hash, path string) bool) {
+	m.pickMarker = fn
+}
+
+// isPicked returns whether a commit ("" path) or one of its files is picked
+func (m Model) isPicked(hash, path string) bool {
+	return m.pickMarker != nil && m.pickMarker(hash, path)
+}
+
+// SetFlash flashes the row of a commit, e.g. the target of a goto ("" stops)
+func (m *Model) SetFlash(hash string) {
+	m.flashHash = hash
+}
+
+// SetMarks sets the marks shown in the gutter, by commit hash
+func (m *Model) SetMarks(marks map[string]string) {
+	m.marks = marks
+}
+
 // SetMatchIndices sets which commit indices are search matches (nil = no search)
 func (m *Model) SetMatchIndices(indices []int) {
 	if len(indices) == 0 {
@@ -362,11 +416,27 @@ func (m *Model) SetMatchIndices(indices []int) {
 	}
 }
 
+// SetMatchHighlighter sets the function returning matched spans of a commit
+// message; spans are emphasized in the message column of matching rows
+func (m *Model) SetMatchHighlighter(fn func(message string) []text.Span) {
+	m.matchHighlighter = fn
+}
+
+// Node returns the graph node of a shown commit (nil if not shown)
+func (m Model) Node(hash string) *graph.CommitNode {
+	return m.graph.Node(hash)
+}
+
 // Commits returns the current commit list
 func (m Model) Commits() []domain.Commit {
 	return m.commits
 }
 
+// Cursor returns the index of the selected commit
+func (m Model) Cursor() int {
+	return m.cursor
+}
+
 // SetCursor sets the cursor position and syncs viewport
 func (m *Model) SetCursor(pos int) {
 	m.cursorTo(pos)
@@ -438,6 +508,16 @@ func (m Model) SelectedFile() *domain.FileChange {
 	return nil
 }
 
+// SetFileCursor selects a file of the expanded commit
+func (m *Model) SetFileCursor(i int) {
+	m.fileCursor = clamp(i, 0, max(0, len(m.expandedFiles)-1))
+	if m.fileCursor < m.fileScrollOffset {
+		m.fileScrollOffset = m.fileCursor
+	} else if m.fileCursor >= m.fileScrollOffset+maxVisibleFiles {
+		m.fileScrollOffset = m.fileCursor - maxVisibleFiles + 1
+	}
+}
+
 // FileCursorUp moves file cursor up
 func (m *Model) FileCursorUp() {
 	if m.fileCursor > 0 {
@@ -472,15 +552,10 @@ func (m Model) renderRowWithLayout(i int, c domain.Commit, layout RowLayout) str
 	// Build row data
 	row := m.buildRowWithLayout(i, c, isMatch, layout)
 
-	// Determine styling
-	dimmed := false
-	if m.highlightedEmails != nil {
-		dimmed = !m.highlightedEmails[strings.ToLower(c.Email)]
-	}
-
 	style := RowStyle{
 		Selected: selected,
-		Dimmed:   dimmed,
+		Flash:    m.flashHash != "" && c.Hash == m.flashHash,
+		Dimmed:   m.isDimmed(c),
 		Width:    m.width,
 	}
 
@@ -505,10 +580,13 @@ func (m Model) buildRowWithLayout(i int, c domain.Commit, isMatch bool, layout R
 	} else if isMatch {
 		cursor = " *"
 	}
+	if m.isPicked(c.Hash, "") {
+		cursor = cursor[:1] + "+"
+	}
 
 	// Graph cell
 	graphCell := m.graph.RenderGraphCell(i)
-	if m.highlightedEmails != nil && !m.highlightedEmails[strings.ToLower(c.Email)] {
+	if m.isDimmed(c) {
 		graphCell = m.graph.RenderGraphCellDimmed(i)
 	}
 
@@ -519,10 +597,17 @@ func (m Model) buildRowWithLayout(i int, c domain.Commit, isMatch bool, layout R
 	if msgAvail < 5 {
 		msgAvail = 5
 	}
-	message := badges + text.Truncate(c.Message, msgAvail)
+	var message string
+	if isMatch && m.matchHighlighter != nil {
+		spans := m.matchHighlighter(c.Message)
+		message = badges + text.Highlight(c.Message, spans, msgAvail, MatchHighlightStyle.Render)
+	} else {
+		message = badges + text.Truncate(c.Message, msgAvail)
+	}
 
 	return Row{
 		Cursor:  cursor,
+		Mark:    m.marks[c.Hash],
 		Graph:   graphCell,
 		Message: message,
 		Author:  text.Truncate(c.Author, layout.Author),
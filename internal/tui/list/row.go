@@ -9,7 +9,8 @@ import (
 // Row represents a single commit row with all column data.
 // Separates data collection from rendering for cleaner code.
 type Row struct {
-	Cursor  string // "> ", " *", ">*", or "  "
+	Cursor  string // "> ", " *", ">*", "  "; "+" second for picked rows
+	Mark    string // mark name shown in the gutter after the cursor ("" = none)
 	Graph   string // graph visualization (may contain ANSI)
 	Message string // commit message with optional badges (may contain ANSI)
 	Author  string // author name
@@ -20,6 +21,7 @@ type Row struct {
 // RowStyle defines how a row should be styled.
 type RowStyle struct {
 	Selected bool
+	Flash    bool // briefly emphasized, e.g. after a jump
 	Dimmed   bool
 	Width    int // total row width for selected row highlighting
 }
@@ -27,7 +29,7 @@ type RowStyle struct {
 // Render formats the row using the given layout and style.
 func (r Row) Render(layout RowLayout, style RowStyle) string {
 	// Fit each column to its layout width
-	cursor := text.Fit(r.Cursor, layout.Cursor)
+	cursor := text.Fit(r.Cursor, layout.Cursor-1) + r.gutter(style)
 	graph := text.FitAnsi(r.Graph, layout.Graph)
 	message := text.FitAnsi(r.Message, layout.Message)
 	author := text.FitLeft(r.Author, layout.Author)
@@ -35,7 +37,7 @@ func (r Row) Render(layout RowLayout, style RowStyle) string {
 	hash := text.Fit(r.Hash, layout.Hash)
 
 	// Apply styles to non-selected rows (styles defined in styles.go)
-	if !style.Selected {
+	if !style.Selected && !style.Flash {
 		if style.Dimmed {
 			hash = DimmedHashStyle.Render(hash)
 			author = DimmedAuthorStyle.Render(author)
@@ -65,8 +67,22 @@ func (r Row) Render(layout RowLayout, style RowStyle) string {
 
 	// Always pad to full width to ensure old content is cleared
 	// (fixes rendering issues in terminals like Zed that don't auto-clear lines)
+	if style.Flash {
+		return FlashRowStyle.Width(style.Width).Render(row)
+	}
 	if style.Selected {
 		return SelectedRowStyle.Width(style.Width).Render(row)
 	}
 	return text.FitAnsi(row, style.Width)
 }
+
+// gutter renders the mark column
+func (r Row) gutter(style RowStyle) string {
+	if r.Mark == "" {
+		return " "
+	}
+	if style.Selected || style.Flash {
+		return r.Mark
+	}
+	return MarkStyle.Render(r.Mark)
+}
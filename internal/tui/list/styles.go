@@ -1,34 +1,78 @@
 package list
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
 
 var (
-	SelectedRowStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("237")).
-				Foreground(lipgloss.Color("255"))
+	SelectedRowStyle lipgloss.Style
+	FlashRowStyle    lipgloss.Style // row flashed after a jump
+	HashStyle        lipgloss.Style
+	AuthorStyle      lipgloss.Style
+	DateStyle        lipgloss.Style
+	MessageStyle     lipgloss.Style
+
+	// Mark names in the gutter
+	MarkStyle lipgloss.Style
+
+	// Matched text inside the message column during search
+	MatchHighlightStyle lipgloss.Style
+
+	// Dimmed styles for non-highlighted commits
+	DimmedHashStyle    lipgloss.Style
+	DimmedAuthorStyle  lipgloss.Style
+	DimmedDateStyle    lipgloss.Style
+	DimmedMessageStyle lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles of the list and the expanded commit from t
+func SetTheme(t theme.Theme) {
+	SelectedRowStyle = t.Selected()
+
+	FlashRowStyle = lipgloss.NewStyle().
+		Background(t.Match).
+		Foreground(t.BadgeText)
+	if t.NoColor {
+		FlashRowStyle = lipgloss.NewStyle().Reverse(true).Bold(true)
+	}
 
 	HashStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214"))
+		Foreground(t.Hash)
 
 	AuthorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("81"))
+		Foreground(t.Author)
 
 	DateStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("242"))
+		Foreground(t.Date)
 
 	MessageStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
+		Foreground(t.Text)
+
+	MarkStyle = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+
+	MatchHighlightStyle = lipgloss.NewStyle().
+		Foreground(t.Match).
+		Bold(true).
+		Underline(true)
 
-	// Dimmed styles for non-highlighted commits
 	DimmedHashStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("239"))
+		Foreground(t.Dimmed)
 
 	DimmedAuthorStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("239"))
+		Foreground(t.Dimmed)
 
 	DimmedDateStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("239"))
+		Foreground(t.Dimmed)
 
 	DimmedMessageStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("239"))
-)
+		Foreground(t.Dimmed)
+
+	setExpandedStyles(t)
+}
@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
+	"github.com/nogo/gitree/internal/tui/palette"
+)
+
+// markItem prefixes palette items that stand for a mark
+const markItem = "mark:"
+
+// markTarget is the commit a mark resolves to in the loaded history
+type markTarget struct {
+	hash      string // "" if the commit is gone
+	rewritten bool   // found by subject and author after a rebase or amend
+}
+
+// reloadMarks reads the marks of the repository and updates the gutter
+func (m *Model) reloadMarks() error {
+	marks, err := m.markStore.Load()
+	if err != nil {
+		return err
+	}
+	m.marks = marks
+	m.refreshMarks()
+	return nil
+}
+
+// refreshMarks resolves the marks against the loaded history, e.g. after
+// a reload, and shows them in the gutter
+func (m *Model) refreshMarks() {
+	m.markTargets = resolveMarks(m.marks, m.repo)
+	gutter := make(map[string]string, len(m.marks))
+	for _, mk := range m.marks { // sorted by name, the first mark of a commit wins
+		if t := m.markTargets[mk.Name]; t.hash != "" && gutter[t.hash] == "" {
+			gutter[t.hash] = mk.Name
+		}
+	}
+	m.list.SetMarks(gutter)
+}
+
+// resolveMarks finds the commit of each mark: its hash, or else the newest
+// commit with the same subject and author, which a rebase or amend leaves
+func resolveMarks(marks []config.Mark, repo *domain.Repository) map[string]markTarget {
+	targets := make(map[string]markTarget, len(marks))
+	byHash := make(map[string]bool, len(repo.Commits))
+	for _, c := range repo.Commits {
+		byHash[c.Hash] = true
+	}
+	for _, mk := range marks {
+		if byHash[mk.Hash] {
+			targets[mk.Name] = markTarget{hash: mk.Hash}
+			continue
+		}
+		targets[mk.Name] = markTarget{}
+		for _, c := range repo.Commits {
+			if mk.Subject != "" && c.Message == mk.Subject && c.Author == mk.Author {
+				targets[mk.Name] = markTarget{hash: c.Hash, rewritten: true}
+				break
+			}
+		}
+	}
+	return targets
+}
+
+// markHint is shown in the footer while waiting for the mark name
+func (m Model) markHint() string {
+	switch m.markPending {
+	case keys.SetMark:
+		return "mark: press a letter"
+	case keys.GotoMark:
+		names := make([]string, 0, len(m.marks))
+		for _, mk := range m.marks {
+			names = append(names, mk.Name)
+		}
+		if len(names) == 0 {
+			return "go to mark: no marks set"
+		}
+		return "go to mark: " + strings.Join(names, " ")
+	}
+	return ""
+}
+
+// handleMarkKey takes the mark name after m or '. Any other key cancels.
+func (m *Model) handleMarkKey(key string) tea.Cmd {
+	action := m.markPending
+	m.markPending = ""
+	switch {
+	case action == keys.GotoMark && m.keys.Is(key, keys.GotoMark):
+		// '' goes back like in vim
+		return m.jumpBack()
+	case !config.ValidMarkName(key):
+		return nil
+	case action == keys.SetMark:
+		return m.setMark(key)
+	}
+	return m.gotoMark(key)
+}
+
+// setMark marks the selected commit
+func (m *Model) setMark(name string) tea.Cmd {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return nil
+	}
+	mk := config.Mark{Name: name, Hash: commit.Hash, Subject: commit.Message, Author: commit.Author, Set: time.Now()}
+	if err := m.markStore.Save(mk); err != nil {
+		return m.setFlash("mark: "+err.Error(), true)
+	}
+	if err := m.reloadMarks(); err != nil {
+		return m.setFlash("marks: "+err.Error(), true)
+	}
+	return m.setFlash(fmt.Sprintf("mark %s set on %s", name, commit.ShortHash), false)
+}
+
+// gotoMark moves to the commit of a mark
+func (m *Model) gotoMark(name string) tea.Cmd {
+	mk, ok := m.findMark(name)
+	if !ok {
+		return m.setFlash(fmt.Sprintf("mark %s not set", name), true)
+	}
+	t := m.markTargets[name]
+	if t.hash == "" {
+		return m.setFlash(fmt.Sprintf("mark %s: commit %s is no longer in the history", name, shortHash(mk.Hash)), true)
+	}
+	cmd := m.visitCommit(t.hash)
+	if cmd == nil && t.rewritten {
+		cmd = m.setFlash(fmt.Sprintf("mark %s: %s was rewritten, now %s", name, shortHash(mk.Hash), shortHash(t.hash)), false)
+	}
+	return cmd
+}
+
+// deleteMark removes a mark
+func (m *Model) deleteMark(name string) (tea.Cmd, error) {
+	if err := m.markStore.Delete(name); err != nil {
+		return nil, err
+	}
+	if err := m.reloadMarks(); err != nil {
+		return nil, err
+	}
+	return m.setFlash("mark "+name+" deleted", false), nil
+}
+
+// findMark returns the mark with the given name
+func (m Model) findMark(name string) (config.Mark, bool) {
+	for _, mk := range m.marks {
+		if mk.Name == name {
+			return mk, true
+		}
+	}
+	return config.Mark{}, false
+}
+
+// openMarks lists the marks in the palette
+func (m *Model) openMarks() tea.Cmd {
+	items := make([]palette.Item, 0, len(m.marks))
+	for _, mk := range m.marks {
+		title := mk.Name + "  " + mk.Subject
+		t := m.markTargets[mk.Name]
+		switch {
+		case t.hash == "":
+			title += " (gone)"
+		case t.rewritten:
+			title += " (rewritten)"
+		}
+		items = append(items, palette.Item{ID: markItem + mk.Name, Title: title, Keys: shortHash(mk.Hash)})
+	}
+	m.palette.SetSize(m.width, m.height)
+	m.showPalette = true
+	return m.palette.OpenPicker("Marks", items)
+}
+
+// pickedMark returns the mark of a palette item from the marks list
+func pickedMark(id string) (string, bool) {
+	return strings.CutPrefix(id, markItem)
+}
@@ -1,6 +1,9 @@
 package tui
 
-import "github.com/nogo/gitree/internal/domain"
+import (
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/domain"
+)
 
 // RepoChangedMsg signals the repository has changed
 type RepoChangedMsg struct{}
@@ -32,5 +35,53 @@ type InsightsLoadedMsg struct {
 	FileChanges map[string][]domain.FileChange
 }
 
+// SearchFilesLoadedMsg carries file changes loaded for file: search terms
+type SearchFilesLoadedMsg struct {
+	Files map[string][]domain.FileChange
+}
+
 // SpinnerTickMsg triggers spinner animation update
 type SpinnerTickMsg struct{}
+
+// CommandFinishedMsg carries the result of a user-defined command
+type CommandFinishedMsg struct {
+	Command config.Command
+	Line    string // expanded command line
+	Output  string // combined output (background commands only)
+	Err     error
+}
+
+// YankedMsg reports the result of copying to the clipboard
+type YankedMsg struct {
+	What string // what was copied, for the footer
+	Err  error
+}
+
+// FlashExpiredMsg clears the transient footer message set with the same ID
+type FlashExpiredMsg struct {
+	ID int
+}
+
+// CompareLoadedMsg carries the files differing between two commits
+type CompareLoadedMsg struct {
+	From  string
+	To    string
+	Files []domain.FileChange
+	Err   error
+}
+
+// RangeDiffLoadedMsg carries the paired commits of a range-diff
+type RangeDiffLoadedMsg struct {
+	Spec     string // as typed in the palette
+	OldRange string
+	NewRange string
+	Pairs    []domain.RangePair
+	Err      error
+}
+
+// RevisionResolvedMsg carries the commit a goto revision names
+type RevisionResolvedMsg struct {
+	Rev  string // as typed
+	Hash string
+	Err  error
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/dates"
+	"github.com/nogo/gitree/internal/tui/keys"
+	"github.com/nogo/gitree/internal/tui/palette"
+)
+
+// Commands typed in the palette with an argument
+const (
+	cmdGoto      = "goto"
+	cmdSince     = "since"
+	cmdUntil     = "until"
+	cmdAuthor    = "author"
+	cmdBranch    = "branch"
+	cmdTag       = "tag"
+	cmdSearch    = "search"
+	cmdView      = "view"
+	cmdMerge     = "merge"
+	cmdDelMark   = "delmark"
+	cmdRangeDiff = "rangediff"
+)
+
+// paletteCommands are listed after the actions
+var paletteCommands = []palette.Item{
+	{ID: cmdGoto, Title: "Go to revision", Args: "<revision>"},
+	{ID: cmdSince, Title: "Show commits since", Args: "<date>"},
+	{ID: cmdUntil, Title: "Show commits until", Args: "<date>"},
+	{ID: cmdAuthor, Title: "Filter authors", Args: "<pattern>"},
+	{ID: cmdBranch, Title: "Filter branches", Args: "<pattern>"},
+	{ID: cmdTag, Title: "Filter tags", Args: "<pattern>"},
+	{ID: cmdSearch, Title: "Search commits", Args: "<query>"},
+	{ID: cmdView, Title: "Apply saved view", Args: "<name>"},
+	{ID: cmdMerge, Title: "Go to the merge into branch", Args: "<branch>"},
+	{ID: cmdDelMark, Title: "Delete mark", Args: "<name>"},
+	{ID: cmdRangeDiff, Title: "Range-diff two versions of a series", Args: "<branch> | <old>...<new> | <base>..<old> <base>..<new>"},
+}
+
+// paletteSkipped are list actions left out of the palette: cursor
+// movement, prefixes, the palette itself, and goto, merge and range-diff,
+// which are commands
+var paletteSkipped = []string{keys.Down, keys.Up, keys.PageDown, keys.PageUp, keys.Top, keys.Bottom, keys.Yank, keys.SetMark, keys.GotoMark, keys.Palette, keys.Goto, keys.MergeInto, keys.RangeDiff}
+
+// paletteItems lists the actions of the commit list and the copy actions
+// with their current keys, then the typed commands
+func (m Model) paletteItems() []palette.Item {
+	var items []palette.Item
+	for _, a := range keys.Registry() {
+		switch {
+		case a.Mode() == keys.ModeList && !slices.Contains(paletteSkipped, a.ID):
+			items = append(items, palette.Item{ID: a.ID, Title: a.Title(), Keys: m.keys.Label(a.ID)})
+		case a.Mode() == keys.ModeYank:
+			label := ""
+			if prefix, key := m.keys.Label(keys.Yank), m.keys.Label(a.ID); prefix != "" && key != "" {
+				label = prefix + " " + key
+			}
+			items = append(items, palette.Item{ID: a.ID, Title: a.Title(), Keys: label})
+		}
+	}
+	for _, it := range paletteCommands {
+		switch it.ID {
+		case cmdGoto:
+			it.Keys = m.keys.Label(keys.Goto)
+		case cmdMerge:
+			it.Keys = m.keys.Label(keys.MergeInto)
+		case cmdRangeDiff:
+			it.Keys = m.keys.Label(keys.RangeDiff)
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+// openPalette shows the command palette
+func (m *Model) openPalette() tea.Cmd {
+	m.palette.SetSize(m.width, m.height)
+	m.showPalette = true
+	return m.palette.Open(m.paletteItems())
+}
+
+// runPaletteRequest runs an item chosen in the palette with the handler its
+// key uses. Errors keep the palette open.
+func (m *Model) runPaletteRequest(req palette.Request) tea.Cmd {
+	m.showPalette = false
+	var cmd tea.Cmd
+	var err error
+	switch req.ID {
+	case cmdGoto:
+		cmd = m.resolveRevision(req.Args)
+	case cmdSince:
+		cmd, err = m.setTimeBound(req.Args, true)
+	case cmdUntil:
+		cmd, err = m.setTimeBound(req.Args, false)
+	case cmdAuthor:
+		cmd, err = m.applyPatternFilter("author", req.Args, m.filters.SetAuthorFilter)
+	case cmdBranch:
+		cmd, err = m.applyPatternFilter("branch", req.Args, m.filters.SetBranchFilter)
+	case cmdTag:
+		cmd, err = m.applyPatternFilter("tag", req.Args, m.filters.SetTagFilter)
+	case cmdSearch:
+		if err = m.search.SetQuery(req.Args); err == nil {
+			cmd = m.executeSearch()
+		}
+	case cmdView:
+		cmd, err = m.applyViewNamed(req.Args)
+	case cmdMerge:
+		cmd, err = m.gotoMergeInto(req.Args)
+	case cmdDelMark:
+		cmd, err = m.deleteMark(req.Args)
+	case cmdRangeDiff:
+		cmd, err = m.startRangeDiff(req.Args)
+	default:
+		if hash, ok := pickedCommit(req.ID); ok {
+			cmd = m.visitCommit(hash)
+		} else if name, ok := pickedMark(req.ID); ok {
+			cmd = m.gotoMark(name)
+		} else if strings.HasPrefix(req.ID, string(keys.ModeYank)+".") {
+			cmd = m.yankAction(req.ID)
+		} else {
+			_, cmd = m.runAction(req.ID)
+		}
+	}
+	if err != nil {
+		m.palette.SetError(err)
+		m.showPalette = true
+		return nil
+	}
+	return cmd
+}
+
+// applyPatternFilter sets a filter from a palette command's patterns with
+// set, which reports whether any of them matched
+func (m *Model) applyPatternFilter(what, patterns string, set func(string) (bool, error)) (tea.Cmd, error) {
+	matched, err := set(patterns)
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		return nil, fmt.Errorf("no %s matches %q", what, patterns)
+	}
+	return m.applyFilter(), nil
+}
+
+// setTimeBound sets the start or end of the time filter from a date
+// expression, keeping the other end
+func (m *Model) setTimeBound(expr string, since bool) (tea.Cmd, error) {
+	state := m.filters.State()
+	var err error
+	if since {
+		state.Since, err = dates.Since(expr, time.Now())
+	} else {
+		state.Until, err = dates.Until(expr, time.Now())
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.filters.SetTimeRange(state.Since, state.Until)
+	m.histogram.Reset()
+	m.histogram.SelectRange(state.Since, state.Until)
+	return m.applyFilter(), nil
+}
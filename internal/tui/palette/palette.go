@@ -0,0 +1,276 @@
+// Package palette is the command palette: a fuzzy-searchable list of every
+// action, which also runs typed commands with arguments (":goto v1.2.0").
+package palette
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/keys"
+	"github.com/nogo/gitree/internal/tui/search"
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+// Item is an entry of the palette
+type Item struct {
+	ID    string // action ID, or the command name for items taking arguments
+	Title string
+	Keys  string // current key binding ("" if unbound)
+	Args  string // argument placeholder, e.g. "<revision>"; "" for plain actions
+}
+
+// Request is returned by Update when the user runs an item
+type Request struct {
+	ID   string
+	Args string
+}
+
+// match is an item passing the current filter
+type match struct {
+	item  Item
+	score int
+	spans []text.Span // matched runes of the title
+}
+
+// Palette lists the items matching the typed text
+type Palette struct {
+	title   string
+	items   []Item
+	matches []match
+	cursor  int
+	input   textinput.Model
+	err     string // why the last request failed
+	keys    keys.Map
+	width   int
+	height  int
+}
+
+// New creates an empty palette
+func New() Palette {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.CharLimit = 200
+	ti.Width = 40
+	return Palette{input: ti, keys: keys.Default()}
+}
+
+// SetKeyMap sets the key bindings. Keys that type text are better left
+// unbound, since the filter is typed while the palette is open.
+func (p *Palette) SetKeyMap(km keys.Map) {
+	p.keys = km
+}
+
+// SetSize sets the overlay area
+func (p *Palette) SetSize(w, h int) {
+	p.width = w
+	p.height = h
+}
+
+// Open shows the actions and commands with an empty input
+func (p *Palette) Open(items []Item) tea.Cmd {
+	p.input.Placeholder = "action or command, e.g. goto v1.2.0"
+	return p.open("Command palette", items)
+}
+
+// OpenPicker shows items to choose from, e.g. the children of a commit
+func (p *Palette) OpenPicker(title string, items []Item) tea.Cmd {
+	p.input.Placeholder = "filter"
+	return p.open(title, items)
+}
+
+func (p *Palette) open(title string, items []Item) tea.Cmd {
+	p.title = title
+	p.items = items
+	p.err = ""
+	p.input.SetValue("")
+	p.filter()
+	return p.input.Focus()
+}
+
+// SetInput replaces the typed text, e.g. to start with a command name
+func (p *Palette) SetInput(value string) {
+	p.input.SetValue(value)
+	p.input.CursorEnd()
+	p.filter()
+}
+
+// SetError shows why the last request failed, keeping the palette open
+func (p *Palette) SetError(err error) {
+	p.err = err.Error()
+}
+
+// maxVisibleItems calculates how many items can be displayed
+func (p Palette) maxVisibleItems() int {
+	return max(3, p.height-12)
+}
+
+// parse splits the input into a command name and its arguments. A leading
+// ":" is accepted for vim habits.
+func (p Palette) parse() (name, args string, hasArgs bool) {
+	value := strings.TrimPrefix(strings.TrimLeft(p.input.Value(), " "), ":")
+	name, args, hasArgs = strings.Cut(value, " ")
+	return name, strings.TrimSpace(args), hasArgs
+}
+
+// command returns the item taking arguments called name
+func (p Palette) command(name string) (Item, bool) {
+	for _, it := range p.items {
+		if it.Args != "" && strings.EqualFold(it.ID, name) {
+			return it, true
+		}
+	}
+	return Item{}, false
+}
+
+// filter recomputes the matches for the input. Once a command name is
+// followed by a space, only that command is listed.
+func (p *Palette) filter() {
+	p.matches = nil
+	p.cursor = 0
+	name, args, hasArgs := p.parse()
+	if it, ok := p.command(name); ok && hasArgs {
+		p.matches = append(p.matches, match{item: it})
+		return
+	}
+
+	pattern := strings.TrimSpace(name + " " + args)
+	for _, it := range p.items {
+		score, spans, ok := search.FuzzyMatch(it.Title, pattern)
+		if !ok {
+			// Command names and action IDs match too, without highlighting
+			if score, _, ok = search.FuzzyMatch(it.ID, pattern); !ok {
+				continue
+			}
+			spans = nil
+		}
+		p.matches = append(p.matches, match{item: it, score: score, spans: spans})
+	}
+	if pattern != "" {
+		sort.SliceStable(p.matches, func(a, b int) bool {
+			return p.matches[a].score > p.matches[b].score
+		})
+	}
+}
+
+// Update handles input and returns (updated palette, cmd, request, closed)
+func (p Palette) Update(msg tea.Msg) (Palette, tea.Cmd, Request, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil, Request{}, false
+	}
+
+	switch key := keyMsg.String(); {
+	case p.keys.Is(key, keys.PaletteClose):
+		p.input.Blur()
+		return p, nil, Request{}, true
+	case p.keys.Is(key, keys.PaletteDown):
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+		return p, nil, Request{}, false
+	case p.keys.Is(key, keys.PaletteUp):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return p, nil, Request{}, false
+	case p.keys.Is(key, keys.PaletteRun):
+		return p.run()
+	}
+
+	var cmd tea.Cmd
+	before := p.input.Value()
+	p.input, cmd = p.input.Update(keyMsg)
+	if p.input.Value() != before {
+		p.err = ""
+		p.filter()
+	}
+	return p, cmd, Request{}, false
+}
+
+// run returns the request for the selected item. A command without
+// arguments is completed in the input instead.
+func (p Palette) run() (Palette, tea.Cmd, Request, bool) {
+	if p.cursor >= len(p.matches) {
+		return p, nil, Request{}, false
+	}
+	it := p.matches[p.cursor].item
+	if it.Args == "" {
+		return p, nil, Request{ID: it.ID}, false
+	}
+	name, args, _ := p.parse()
+	if !strings.EqualFold(name, it.ID) || args == "" {
+		p.SetInput(it.ID + " ")
+		return p, nil, Request{}, false
+	}
+	return p, nil, Request{ID: it.ID, Args: args}, false
+}
+
+// View renders the palette centered in its area
+func (p Palette) View() string {
+	innerWidth := max(40, min(p.width-6, 80))
+
+	var lines []string
+	lines = append(lines, TitleStyle.Render(p.title))
+	lines = append(lines, p.input.View())
+	lines = append(lines, "")
+
+	if len(p.matches) == 0 {
+		lines = append(lines, HintStyle.Render("  No matches"))
+	}
+
+	maxVisible := p.maxVisibleItems()
+	start := 0
+	if p.cursor >= maxVisible {
+		start = p.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(p.matches))
+	if start > 0 {
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+	}
+	for i := start; i < end; i++ {
+		lines = append(lines, p.renderMatch(p.matches[i], i == p.cursor, innerWidth))
+	}
+	if end < len(p.matches) {
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("  ↓ %d more", len(p.matches)-end)))
+	}
+
+	lines = append(lines, "")
+	if p.err != "" {
+		lines = append(lines, ErrorStyle.Render(p.err))
+	} else {
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("[%s] Run  [%s/%s] Select  [%s] Close",
+			p.keys.Label(keys.PaletteRun), p.keys.Label(keys.PaletteUp), p.keys.Label(keys.PaletteDown),
+			p.keys.Label(keys.PaletteClose))))
+	}
+
+	return lipgloss.Place(
+		p.width, p.height,
+		lipgloss.Center, lipgloss.Center,
+		PaletteStyle.Width(innerWidth).Render(strings.Join(lines, "\n")),
+	)
+}
+
+// renderMatch renders one item: title (with arguments) left, keys right.
+// width includes the padding of the overlay.
+func (p Palette) renderMatch(m match, selected bool, width int) string {
+	title := m.item.Title
+	if m.item.Args != "" {
+		title += " — " + m.item.ID + " " + m.item.Args
+	}
+	keys := m.item.Keys
+	content := width - 4
+	titleWidth := max(10, content-2-len([]rune(keys))-1)
+
+	if selected {
+		line := "> " + text.Truncate(title, titleWidth)
+		pad := max(1, content-len([]rune(line))-len([]rune(keys)))
+		return SelectedStyle.Render(line + strings.Repeat(" ", pad) + keys)
+	}
+	rendered := text.Highlight(title, m.spans, titleWidth, MatchStyle.Render)
+	pad := max(1, content-2-lipgloss.Width(rendered)-len([]rune(keys)))
+	return "  " + rendered + strings.Repeat(" ", pad) + KeyStyle.Render(keys)
+}
@@ -0,0 +1,90 @@
+package palette
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+var testItems = []Item{
+	{ID: "list.insights", Title: "Toggle insights", Keys: "i"},
+	{ID: "list.search", Title: "Search commits", Keys: "/"},
+	{ID: "goto", Title: "Jump to ref or commit", Args: "<ref|hash>"},
+}
+
+// typed returns a palette with items and s typed into it
+func typed(s string) Palette {
+	p := New()
+	p.Open(testItems)
+	for _, r := range s {
+		p, _, _, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return p
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", []string{"list.insights", "list.search", "goto"}},
+		{"ins", []string{"list.insights"}},
+		{":goto", []string{"goto"}},
+		{"goto v1.2.0", []string{"goto"}},
+		{"zzz", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			p := typed(tc.input)
+			var got []string
+			for _, m := range p.matches {
+				got = append(got, m.item.ID)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("matches = %q, want %q", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("matches = %q, want %q", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	_, _, req, _ := typed("search").Update(enter)
+	if req != (Request{ID: "list.search"}) {
+		t.Errorf("action request = %+v", req)
+	}
+
+	// A command without arguments is completed in the input
+	p, _, req, _ := typed("jump").Update(enter)
+	if req.ID != "" || p.input.Value() != "goto " {
+		t.Errorf("request = %+v, input = %q; want completion", req, p.input.Value())
+	}
+
+	_, _, req, _ = typed(":goto  origin/main~3 ").Update(enter)
+	if req != (Request{ID: "goto", Args: "origin/main~3"}) {
+		t.Errorf("command request = %+v", req)
+	}
+}
+
+func TestKeyMap(t *testing.T) {
+	km := keys.Default()
+	if err := km.Apply(map[string]map[string][]string{"palette": {"run": {"ctrl+r"}}}); err != nil {
+		t.Fatal(err)
+	}
+	p := typed("search")
+	p.SetKeyMap(km)
+
+	if _, _, req, _ := p.Update(tea.KeyMsg{Type: tea.KeyEnter}); req.ID != "" {
+		t.Errorf("enter request = %+v, want none", req)
+	}
+	if _, _, req, _ := p.Update(tea.KeyMsg{Type: tea.KeyCtrlR}); req != (Request{ID: "list.search"}) {
+		t.Errorf("ctrl+r request = %+v", req)
+	}
+}
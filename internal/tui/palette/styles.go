@@ -0,0 +1,44 @@
+package palette
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
+
+var (
+	PaletteStyle  lipgloss.Style
+	TitleStyle    lipgloss.Style
+	SelectedStyle lipgloss.Style
+	KeyStyle      lipgloss.Style
+	MatchStyle    lipgloss.Style
+	HintStyle     lipgloss.Style
+	ErrorStyle    lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	PaletteStyle = t.Overlay()
+
+	TitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Accent)
+
+	SelectedStyle = t.Selected()
+
+	KeyStyle = lipgloss.NewStyle().
+		Foreground(t.Hash)
+
+	MatchStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Match)
+
+	HintStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(t.Error)
+}
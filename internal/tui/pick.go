@@ -0,0 +1,121 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/placeholder"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// Pick is a commit, or a file in a commit, chosen in --pick mode
+type Pick struct {
+	Commit domain.Commit
+	Path   string // "" when the commit itself was picked
+}
+
+// PickPlaceholders are the placeholders available in --pick formats
+var PickPlaceholders = []string{"hash", "short", "path", "subject"}
+
+// Format expands a --pick template for this pick
+func (p Pick) Format(tmpl string) string {
+	return placeholder.Expand(tmpl, map[string]string{
+		"hash":    p.Commit.Hash,
+		"short":   p.Commit.ShortHash,
+		"path":    p.Path,
+		"subject": p.Commit.Message,
+	})
+}
+
+// pickState is shared by all copies of the model so the list's marker
+// function always sees the current selection
+type pickState struct {
+	items []Pick // multi-selection in the order it was made
+	done  []Pick // confirmed selection (nil = cancelled)
+}
+
+func (s *pickState) index(hash, path string) int {
+	for i, p := range s.items {
+		if p.Commit.Hash == hash && p.Path == path {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *pickState) has(hash, path string) bool {
+	return s.index(hash, path) >= 0
+}
+
+func (s *pickState) toggle(p Pick) {
+	if i := s.index(p.Commit.Hash, p.Path); i >= 0 {
+		s.items = append(s.items[:i], s.items[i+1:]...)
+		return
+	}
+	s.items = append(s.items, p)
+}
+
+// EnablePick turns on pick mode: Enter quits and reports the selection
+// through Picks instead of expanding commits and opening diffs
+func (m *Model) EnablePick() {
+	m.pick = &pickState{}
+	m.list.SetPickMarker(m.pick.has)
+}
+
+// Picks returns the confirmed selection, or nil if the user quit without picking
+func (m Model) Picks() []Pick {
+	if m.pick == nil {
+		return nil
+	}
+	return m.pick.done
+}
+
+// PickCount returns the number of multi-selected commits and files
+func (m Model) PickCount() int {
+	if m.pick == nil {
+		return 0
+	}
+	return len(m.pick.items)
+}
+
+// handlePickKey handles the keys that differ in pick mode.
+// Returns false for keys that keep their normal meaning.
+func (m *Model) handlePickKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return false, nil
+	}
+
+	// Current item: the file under the cursor when expanded, else the commit
+	current := Pick{Commit: *commit}
+	if m.list.IsExpanded() {
+		file := m.list.SelectedFile()
+		if file == nil {
+			return false, nil
+		}
+		current.Path = file.Path
+	}
+
+	switch key := msg.String(); {
+	case m.keys.Is(key, keys.PickSelect):
+		m.pick.toggle(current)
+		if !m.list.IsExpanded() {
+			m.list.SetCursor(m.list.Cursor() + 1)
+		}
+		return true, nil
+
+	case m.keys.Is(key, keys.PickAccept):
+		m.pick.done = m.pick.items
+		if len(m.pick.done) == 0 {
+			m.pick.done = []Pick{current}
+		}
+		return true, tea.Quit
+
+	case m.keys.Is(key, keys.PickExpand):
+		// Enter is taken, so expand to pick files
+		if !m.list.IsExpanded() {
+			m.list.Expand()
+			return true, m.loadExpandedFiles()
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// errRangeDiffArgs explains the forms the rangediff command takes
+var errRangeDiffArgs = errors.New("use <branch>, <old>...<new> or <base>..<old> <base>..<new>")
+
+// openRangeDiff opens the palette with the rangediff command and the
+// checked out branch typed, which compares it with its previous position
+func (m *Model) openRangeDiff() tea.Cmd {
+	cmd := m.openPalette()
+	branch := ""
+	if m.branchTip(m.repo.HEAD) != "" {
+		branch = m.repo.HEAD
+	}
+	m.palette.SetInput(cmdRangeDiff + " " + branch)
+	return cmd
+}
+
+// startRangeDiff opens the range-diff view for the arguments of the
+// rangediff command:
+//
+//	<branch>                    the branch before and after its last change (reflog)
+//	<old>...<new>               both tips from their merge base
+//	<base>..<old> <base>..<new> two explicit ranges
+func (m *Model) startRangeDiff(args string) (tea.Cmd, error) {
+	fields := strings.Fields(args)
+	var oldTip, newTip, oldRange, newRange string
+	switch {
+	case len(fields) == 2 && strings.Contains(fields[0], "..") && strings.Contains(fields[1], ".."):
+		oldRange, newRange = fields[0], fields[1]
+	case len(fields) == 1 && strings.Contains(fields[0], "..."):
+		oldTip, newTip, _ = strings.Cut(fields[0], "...")
+	case len(fields) == 1 && !strings.Contains(fields[0], ".."):
+		oldTip, newTip = fields[0]+"@{1}", fields[0]
+	default:
+		return nil, errRangeDiffArgs
+	}
+	if (oldRange == "" && (oldTip == "" || newTip == "")) || strings.Contains(oldTip+newTip, "..") {
+		return nil, errRangeDiffArgs
+	}
+
+	spec := strings.Join(fields, " ")
+	m.rangeView.Show(spec)
+	m.rangeView.SetSize(m.width, m.height)
+	m.showRangeDiff = true
+
+	reader := m.reader
+	repoPath := m.repoPath
+	return func() tea.Msg {
+		msg := RangeDiffLoadedMsg{Spec: spec, OldRange: oldRange, NewRange: newRange}
+		if oldRange == "" {
+			base, err := reader.MergeBase(repoPath, oldTip, newTip)
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			msg.OldRange = base + ".." + oldTip
+			msg.NewRange = base + ".." + newTip
+		}
+		msg.Pairs, msg.Err = reader.RangeDiff(repoPath, msg.OldRange, msg.NewRange)
+		return msg
+	}, nil
+}
+
+// handleRangeDiffLoaded shows the pairs of a range-diff still open
+func (m *Model) handleRangeDiffLoaded(msg RangeDiffLoadedMsg) {
+	if !m.showRangeDiff || m.rangeView.Spec() != msg.Spec {
+		return
+	}
+	m.rangeView.SetPairs(msg.OldRange, msg.NewRange, msg.Pairs, msg.Err)
+}
+
+// closeRangeDiff hides the range-diff view
+func (m *Model) closeRangeDiff() {
+	m.rangeView.Hide()
+	m.showRangeDiff = false
+}
+
+// handleRangeDiffKey handles a key in the range-diff view
+func (m *Model) handleRangeDiffKey(msg tea.KeyMsg) tea.Cmd {
+	switch key := msg.String(); {
+	case m.keys.Is(key, keys.RangeDiffClose):
+		m.closeRangeDiff()
+		return nil
+	case m.keys.Is(key, keys.RangeDiffInterdiff):
+		return m.openInterdiff()
+	}
+	m.rangeView, _ = m.rangeView.Update(msg)
+	return nil
+}
+
+// openInterdiff shows how the patch of the selected pair changed
+func (m *Model) openInterdiff() tea.Cmd {
+	pair := m.rangeView.Selected()
+	if pair == nil {
+		return nil
+	}
+	switch pair.Status {
+	case domain.RangeMatched:
+		return m.setFlash("patch unchanged", false)
+	case domain.RangeAdded:
+		return m.setFlash("only in the new series", false)
+	case domain.RangeDropped:
+		return m.setFlash("only in the old series", false)
+	}
+	title := fmt.Sprintf("%s → %s %s", pair.Old.ShortHash, pair.New.ShortHash, pair.New.Message)
+	m.diffView.ShowPatch(title, pair.Interdiff)
+	m.diffView.SetSize(m.width, m.height)
+	m.showDiff = true
+	return nil
+}
@@ -0,0 +1,295 @@
+// Package rangediff lists the commits of two versions of a series paired
+// like git range-diff: matched, modified, added or dropped.
+package rangediff
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+// View shows the pairs of a range-diff
+type View struct {
+	visible  bool
+	loading  bool
+	err      string
+	spec     string // as typed, shown until the ranges are resolved
+	oldRange string
+	newRange string
+	pairs    []domain.RangePair
+	cursor   int
+	width    int
+	height   int
+	status   string // transient message shown instead of the key hints
+	keys     keys.Map
+}
+
+// New creates a hidden range-diff view
+func New() View {
+	return View{keys: keys.Default()}
+}
+
+// SetKeyMap sets the key bindings
+func (v *View) SetKeyMap(km keys.Map) {
+	v.keys = km
+}
+
+// SetSize sets the view dimensions
+func (v *View) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+}
+
+// Show opens the view while the commits of the ranges spec names are
+// paired
+func (v *View) Show(spec string) {
+	v.visible = true
+	v.loading = true
+	v.err = ""
+	v.spec = spec
+	v.oldRange = ""
+	v.newRange = ""
+	v.pairs = nil
+	v.cursor = 0
+}
+
+// SetPairs sets the ranges and their paired commits, or the error pairing
+// them
+func (v *View) SetPairs(oldRange, newRange string, pairs []domain.RangePair, err error) {
+	v.loading = false
+	v.oldRange = oldRange
+	v.newRange = newRange
+	v.pairs = pairs
+	if err != nil {
+		v.err = err.Error()
+	}
+	// Start on the first change
+	for i, p := range pairs {
+		if p.Status != domain.RangeMatched {
+			v.cursor = i
+			break
+		}
+	}
+}
+
+// Hide closes the view
+func (v *View) Hide() {
+	v.visible = false
+	v.pairs = nil
+}
+
+// IsVisible returns whether the view is shown
+func (v View) IsVisible() bool {
+	return v.visible
+}
+
+// Spec returns the ranges as given to Show
+func (v View) Spec() string {
+	return v.spec
+}
+
+// Selected returns the pair under the cursor (nil if none)
+func (v View) Selected() *domain.RangePair {
+	if v.cursor < 0 || v.cursor >= len(v.pairs) {
+		return nil
+	}
+	return &v.pairs[v.cursor]
+}
+
+// SetStatus shows a message in place of the key hints ("" restores them)
+func (v *View) SetStatus(status string) {
+	v.status = status
+}
+
+// Update handles the keys moving the cursor
+func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !v.visible || !ok {
+		return v, nil
+	}
+
+	switch key := keyMsg.String(); {
+	case v.keys.Is(key, keys.RangeDiffDown):
+		if v.cursor < len(v.pairs)-1 {
+			v.cursor++
+		}
+	case v.keys.Is(key, keys.RangeDiffUp):
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case v.keys.Is(key, keys.RangeDiffTop):
+		v.cursor = 0
+	case v.keys.Is(key, keys.RangeDiffBottom):
+		v.cursor = max(0, len(v.pairs)-1)
+	}
+	return v, nil
+}
+
+func (v View) contentWidth() int {
+	// Account for border and padding
+	return max(20, v.width-8)
+}
+
+func (v View) contentHeight() int {
+	// Account for border, header (2), separators and footer
+	return max(5, v.height-9)
+}
+
+// View renders the pairs
+func (v View) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	width := v.contentWidth()
+	height := v.contentHeight()
+
+	var body []string
+	switch {
+	case v.loading:
+		body = []string{InfoStyle.Render("Pairing commits...")}
+	case v.err != "":
+		body = []string{ErrorStyle.Render(v.err)}
+	case len(v.pairs) == 0:
+		body = []string{InfoStyle.Render("Both ranges are empty")}
+	default:
+		body = v.renderPairs(width, height)
+	}
+	for len(body) < height {
+		body = append(body, "")
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		v.renderHeader(width),
+		v.renderRanges(width),
+		strings.Repeat("─", width),
+		strings.Join(body[:height], "\n"),
+		strings.Repeat("─", width),
+		v.renderFooter(),
+	)
+
+	// The width of the container includes its padding
+	return ContainerStyle.
+		Width(width + 2).
+		Height(v.height - 4).
+		Render(content)
+}
+
+// renderHeader renders the title left and the counts per status right
+func (v View) renderHeader(width int) string {
+	left := TitleStyle.Render("Range-diff")
+
+	var right string
+	if !v.loading && v.err == "" {
+		var counts [4]int
+		for _, p := range v.pairs {
+			counts[p.Status]++
+		}
+		right = strings.Join([]string{
+			MatchedStyle.Render(fmt.Sprintf("%d matched", counts[domain.RangeMatched])),
+			ModifiedStyle.Render(fmt.Sprintf("%d modified", counts[domain.RangeModified])),
+			AddedStyle.Render(fmt.Sprintf("%d added", counts[domain.RangeAdded])),
+			DroppedStyle.Render(fmt.Sprintf("%d dropped", counts[domain.RangeDropped])),
+		}, "  ")
+	}
+
+	spacing := max(1, width-lipgloss.Width(left)-lipgloss.Width(right))
+	return left + strings.Repeat(" ", spacing) + right
+}
+
+// renderRanges renders the old and new range, or the spec until they are
+// resolved
+func (v View) renderRanges(width int) string {
+	if v.oldRange == "" {
+		return text.Truncate(v.spec, width)
+	}
+	return text.TruncateAnsi(LabelStyle.Render("old ")+shortRange(v.oldRange)+LabelStyle.Render("  →  new ")+shortRange(v.newRange), width)
+}
+
+// shortRange abbreviates a full hash the range starts from, like the merge
+// base of two tips, to the width of the hash column
+func shortRange(r string) string {
+	base, tip, ok := strings.Cut(r, "..")
+	if ok && len(base) == 40 && strings.Trim(base, "0123456789abcdef") == "" {
+		return base[:7] + ".." + tip
+	}
+	return r
+}
+
+// renderPairs renders the pairs with the cursor kept in view
+func (v View) renderPairs(width, height int) []string {
+	visible := max(1, height)
+	if len(v.pairs) > visible && visible > 1 {
+		// Last line shows the position
+		visible--
+	}
+	offset := max(0, v.cursor-visible+1)
+	end := min(offset+visible, len(v.pairs))
+
+	// Positions are as wide as the longest series
+	digits := len(fmt.Sprint(len(v.pairs)))
+
+	var lines []string
+	for i := offset; i < end; i++ {
+		lines = append(lines, v.renderPair(v.pairs[i], i == v.cursor, digits, width))
+	}
+	if len(v.pairs) > visible {
+		lines = append(lines, LabelStyle.Render(fmt.Sprintf("  (%d/%d)", v.cursor+1, len(v.pairs))))
+	}
+	return lines
+}
+
+// renderPair renders a pair as git range-diff does:
+// "1: abc1234 ! 1: def5678 subject"
+func (v View) renderPair(p domain.RangePair, selected bool, digits, width int) string {
+	side := func(index int, c *domain.Commit) string {
+		if c == nil {
+			return fmt.Sprintf("%*s: %s", digits, "-", strings.Repeat("-", 7))
+		}
+		return fmt.Sprintf("%*d: %s", digits, index, c.ShortHash)
+	}
+
+	var sign string
+	var style lipgloss.Style
+	subject := ""
+	switch p.Status {
+	case domain.RangeMatched:
+		sign, style = "=", MatchedStyle
+	case domain.RangeModified:
+		sign, style = "!", ModifiedStyle
+	case domain.RangeAdded:
+		sign, style = ">", AddedStyle
+	case domain.RangeDropped:
+		sign, style = "<", DroppedStyle
+	}
+	if p.New != nil {
+		subject = p.New.Message
+	} else if p.Old != nil {
+		subject = p.Old.Message
+	}
+
+	cursor := "  "
+	if selected {
+		cursor = "> "
+	}
+	line := cursor + side(p.OldIndex, p.Old) + " " + sign + " " + side(p.NewIndex, p.New) + " " + subject
+	if selected {
+		return SelectedStyle.Render(text.Fit(line, width))
+	}
+	return style.Render(text.Truncate(line, width))
+}
+
+func (v View) renderFooter() string {
+	if v.status != "" {
+		return StatusStyle.Render(v.status)
+	}
+	k := v.keys.Label
+	return FooterStyle.Render(fmt.Sprintf("[%s/%s] commit  [%s] interdiff  [%s] back",
+		k(keys.RangeDiffDown), k(keys.RangeDiffUp), k(keys.RangeDiffInterdiff), k(keys.RangeDiffClose)))
+}
@@ -0,0 +1,70 @@
+package rangediff
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
+
+var (
+	ContainerStyle lipgloss.Style
+	TitleStyle     lipgloss.Style
+	LabelStyle     lipgloss.Style
+	SelectedStyle  lipgloss.Style
+
+	// Pairs by status
+	MatchedStyle  lipgloss.Style
+	ModifiedStyle lipgloss.Style
+	AddedStyle    lipgloss.Style
+	DroppedStyle  lipgloss.Style
+
+	FooterStyle lipgloss.Style
+	StatusStyle lipgloss.Style // transient message in the footer
+	InfoStyle   lipgloss.Style
+	ErrorStyle  lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	ContainerStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+
+	TitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Accent)
+
+	LabelStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	SelectedStyle = t.Selected()
+
+	MatchedStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	ModifiedStyle = lipgloss.NewStyle().
+		Foreground(t.Modified)
+
+	AddedStyle = lipgloss.NewStyle().
+		Foreground(t.Added)
+
+	DroppedStyle = lipgloss.NewStyle().
+		Foreground(t.Deleted)
+
+	FooterStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	StatusStyle = lipgloss.NewStyle().
+		Foreground(t.Success)
+
+	InfoStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Italic(true)
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(t.Error)
+}
@@ -0,0 +1,134 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+// Fuzzy scoring weights
+const (
+	fuzzyMatchScore   = 16
+	fuzzyConsecutive  = 12 // bonus for adjacent matched runes
+	fuzzyWordBoundary = 10 // bonus for matching at the start of a word
+	fuzzyFirstRune    = 8  // bonus for matching the first rune of the text
+	fuzzyGapPenalty   = 1  // per skipped rune between matches
+	fuzzyLeadingMax   = 10 // cap for penalty of unmatched runes before the first match
+)
+
+// fuzzyResult is a scored fuzzy match for one commit
+type fuzzyResult struct {
+	index     int
+	score     int
+	positions []int // matched rune positions in the message
+}
+
+// fuzzyMatch matches pattern as a case-insensitive subsequence of s.
+// Returns the score and matched rune positions; ok is false if s does not
+// contain every rune of pattern in order.
+func fuzzyMatch(s, pattern string) (score int, positions []int, ok bool) {
+	pat := []rune(strings.ToLower(pattern))
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+	runes := []rune(s)
+	lower := []rune(strings.ToLower(s))
+
+	// Forward pass: find the earliest end of a subsequence match
+	pi := 0
+	end := -1
+	for i := 0; i < len(lower) && pi < len(pat); i++ {
+		if lower[i] == pat[pi] {
+			pi++
+			if pi == len(pat) {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward pass: tighten the match to the shortest window ending at end
+	positions = make([]int, len(pat))
+	pi = len(pat) - 1
+	for i := end; i >= 0 && pi >= 0; i-- {
+		if lower[i] == pat[pi] {
+			positions[pi] = i
+			pi--
+		}
+	}
+
+	// Score the match
+	for k, pos := range positions {
+		score += fuzzyMatchScore
+		if pos == 0 {
+			score += fuzzyFirstRune
+		}
+		if pos > 0 && isWordBoundary(runes[pos-1], runes[pos]) {
+			score += fuzzyWordBoundary
+		}
+		if k > 0 {
+			gap := pos - positions[k-1] - 1
+			if gap == 0 {
+				score += fuzzyConsecutive
+			} else {
+				score -= gap * fuzzyGapPenalty
+			}
+		}
+	}
+	leading := positions[0]
+	if leading > fuzzyLeadingMax {
+		leading = fuzzyLeadingMax
+	}
+	score -= leading
+
+	return score, positions, true
+}
+
+// isWordBoundary reports whether cur starts a new word after prev
+func isWordBoundary(prev, cur rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// fuzzySearch scores commit messages and returns matches ordered by
+// descending score (ties keep list order)
+func fuzzySearch(commits []domain.Commit, pattern string) []fuzzyResult {
+	var results []fuzzyResult
+	for i, c := range commits {
+		score, positions, ok := fuzzyMatch(c.Message, pattern)
+		if ok {
+			results = append(results, fuzzyResult{index: i, score: score, positions: positions})
+		}
+	}
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].score > results[b].score
+	})
+	return results
+}
+
+// positionsToSpans merges sorted rune positions into contiguous spans
+func positionsToSpans(positions []int) []text.Span {
+	var spans []text.Span
+	for _, p := range positions {
+		if n := len(spans); n > 0 && spans[n-1].End == p {
+			spans[n-1].End = p + 1
+			continue
+		}
+		spans = append(spans, text.Span{Start: p, End: p + 1})
+	}
+	return spans
+}
+
+// FuzzyMatch scores pattern against s like the fuzzy search mode, for other
+// pickers. Returns the matched spans for highlighting.
+func FuzzyMatch(s, pattern string) (score int, spans []text.Span, ok bool) {
+	score, positions, ok := fuzzyMatch(s, pattern)
+	return score, positionsToSpans(positions), ok
+}
@@ -0,0 +1,163 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		s, pattern    string
+		ok            bool
+		wantPositions []int
+	}{
+		{"Fix parser bug", "fpb", true, []int{0, 4, 11}},
+		{"Fix parser bug", "parser", true, []int{4, 5, 6, 7, 8, 9}},
+		{"Fix parser bug", "PARS", true, []int{4, 5, 6, 7}},
+		{"Fix parser bug", "xyz", false, nil},
+		{"abc", "abcd", false, nil},
+		{"anything", "", true, nil},
+		// Backward pass tightens to the shortest window
+		{"aab", "ab", true, []int{1, 2}},
+	}
+
+	for _, tc := range tests {
+		_, positions, ok := fuzzyMatch(tc.s, tc.pattern)
+		if ok != tc.ok {
+			t.Errorf("fuzzyMatch(%q, %q) ok = %v, want %v", tc.s, tc.pattern, ok, tc.ok)
+			continue
+		}
+		if len(positions) != len(tc.wantPositions) {
+			t.Errorf("fuzzyMatch(%q, %q) positions = %v, want %v", tc.s, tc.pattern, positions, tc.wantPositions)
+			continue
+		}
+		for i := range positions {
+			if positions[i] != tc.wantPositions[i] {
+				t.Errorf("fuzzyMatch(%q, %q) positions = %v, want %v", tc.s, tc.pattern, positions, tc.wantPositions)
+				break
+			}
+		}
+	}
+}
+
+func TestFuzzySearchRanking(t *testing.T) {
+	commits := []domain.Commit{
+		{Message: "Refactor the config loader"}, // scattered c-o-n-f
+		{Message: "Add config file"},            // consecutive at word start
+		{Message: "Unrelated change"},           // no match
+		{Message: "conf: tweak"},                // consecutive at text start
+	}
+
+	results := fuzzySearch(commits, "conf")
+	if len(results) != 3 {
+		t.Fatalf("fuzzySearch returned %d results, want 3", len(results))
+	}
+	if results[0].index != 3 {
+		t.Errorf("best match index = %d, want 3 (match at text start)", results[0].index)
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].score > results[i-1].score {
+			t.Errorf("results not sorted by score: %v", results)
+		}
+	}
+}
+
+func TestSearchModes(t *testing.T) {
+	commits := []domain.Commit{
+		{Hash: "a1", Message: "JIRA-12 fix login", FullMessage: "JIRA-12 fix login"},
+		{Hash: "b2", Message: "update docs", FullMessage: "update docs"},
+		{Hash: "c3", Message: "jira-7 fix logout", FullMessage: "jira-7 fix logout"},
+	}
+
+	t.Run("regex smart case", func(t *testing.T) {
+		s := New()
+		s.SetMode(ModeRegex)
+		if err := s.compile(`jira-\d+`); err != nil {
+			t.Fatal(err)
+		}
+		s.query, s.active = `jira-\d+`, true
+		s.Execute(commits, nil)
+		if s.MatchCount() != 2 {
+			t.Errorf("lowercase pattern matched %d, want 2", s.MatchCount())
+		}
+
+		if err := s.compile(`JIRA-\d+`); err != nil {
+			t.Fatal(err)
+		}
+		s.query = `JIRA-\d+`
+		s.Execute(commits, nil)
+		if s.MatchCount() != 1 {
+			t.Errorf("uppercase pattern matched %d, want 1", s.MatchCount())
+		}
+	})
+
+	t.Run("regex compile error", func(t *testing.T) {
+		s := New()
+		s.SetMode(ModeRegex)
+		if err := s.validate(`fix(`); err == nil {
+			t.Error("expected compile error for unbalanced group")
+		}
+	})
+
+	t.Run("fuzzy walks by score", func(t *testing.T) {
+		s := New()
+		s.SetMode(ModeFuzzy)
+		s.query, s.active = "fxlgt", true
+		s.Execute(commits, nil)
+		if s.MatchCount() != 1 || s.CurrentMatchCommitIndex() != 2 {
+			t.Errorf("fuzzy matches = %v, want [2]", s.Matches())
+		}
+	})
+
+	t.Run("cycle modes", func(t *testing.T) {
+		s := New()
+		want := []Mode{ModeRegex, ModeFuzzy, ModeSubstring}
+		for _, m := range want {
+			s.CycleMode()
+			if s.Mode() != m {
+				t.Errorf("CycleMode() = %v, want %v", s.Mode(), m)
+			}
+		}
+	})
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    Mode
+		query   string
+		message string
+		want    []text.Span
+	}{
+		{"substring terms", ModeSubstring, "fix log", "Fix login", []text.Span{{Start: 0, End: 3}, {Start: 4, End: 7}}},
+		{"negated terms skipped", ModeSubstring, "fix -login", "fix login", []text.Span{{Start: 0, End: 3}}},
+		{"field terms skipped", ModeSubstring, "author:fix", "fix", nil},
+		{"msg regex", ModeSubstring, `msg:/\d+/`, "JIRA-12 and 7", []text.Span{{Start: 5, End: 7}, {Start: 12, End: 13}}},
+		{"overlapping merged", ModeSubstring, "logi gin", "login", []text.Span{{Start: 0, End: 5}}},
+		{"regex mode", ModeRegex, `o.`, "foo bar", []text.Span{{Start: 1, End: 3}}},
+		{"fuzzy mode", ModeFuzzy, "fb", "foo bar", []text.Span{{Start: 0, End: 1}, {Start: 4, End: 5}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := New()
+			s.SetMode(tc.mode)
+			if err := s.compile(tc.query); err != nil {
+				t.Fatal(err)
+			}
+			s.query, s.active = tc.query, true
+			got := s.Highlight(tc.message)
+			if len(got) != len(tc.want) {
+				t.Fatalf("Highlight = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("Highlight = %v, want %v", got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package search
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nogo/gitree/internal/tui/text"
+)
+
+// highlightTerm is a positive message term of a query used for highlighting
+type highlightTerm struct {
+	substr string         // lowercase substring (if re is nil)
+	re     *regexp.Regexp // regex term
+}
+
+// collectHighlightTerms walks the query tree and returns terms that can match
+// the message. Terms under NOT are skipped since they never appear in matches.
+func collectHighlightTerms(n node, negated bool, out *[]highlightTerm) {
+	switch n := n.(type) {
+	case andNode:
+		collectHighlightTerms(n.left, negated, out)
+		collectHighlightTerms(n.right, negated, out)
+	case orNode:
+		collectHighlightTerms(n.left, negated, out)
+		collectHighlightTerms(n.right, negated, out)
+	case notNode:
+		collectHighlightTerms(n.inner, !negated, out)
+	case textNode:
+		if !negated && n.text != "" {
+			*out = append(*out, highlightTerm{substr: n.text})
+		}
+	case fieldNode:
+		if !negated && n.field == "msg" {
+			if n.re != nil {
+				*out = append(*out, highlightTerm{re: n.re})
+			} else if n.value != "" {
+				*out = append(*out, highlightTerm{substr: strings.ToLower(n.value)})
+			}
+		}
+	}
+}
+
+// Highlight returns the spans of message matched by the query's positive
+// text and msg: terms.
+func (q *Query) Highlight(message string) []text.Span {
+	var terms []highlightTerm
+	collectHighlightTerms(q.root, false, &terms)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var spans []text.Span
+	lower := []rune(strings.ToLower(message))
+	for _, t := range terms {
+		if t.re != nil {
+			spans = append(spans, regexSpans(t.re, message)...)
+		} else {
+			spans = append(spans, substringSpans(lower, []rune(t.substr))...)
+		}
+	}
+	return mergeSpans(spans)
+}
+
+// substringSpans finds all non-overlapping occurrences of sub in s (rune offsets)
+func substringSpans(s, sub []rune) []text.Span {
+	if len(sub) == 0 {
+		return nil
+	}
+	var spans []text.Span
+	for i := 0; i+len(sub) <= len(s); {
+		if runesEqual(s[i:i+len(sub)], sub) {
+			spans = append(spans, text.Span{Start: i, End: i + len(sub)})
+			i += len(sub)
+			continue
+		}
+		i++
+	}
+	return spans
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// regexSpans converts regex byte matches in s to rune spans
+func regexSpans(re *regexp.Regexp, s string) []text.Span {
+	var spans []text.Span
+	for _, loc := range re.FindAllStringIndex(s, -1) {
+		if loc[0] == loc[1] {
+			continue // skip empty matches
+		}
+		start := utf8.RuneCountInString(s[:loc[0]])
+		end := start + utf8.RuneCountInString(s[loc[0]:loc[1]])
+		spans = append(spans, text.Span{Start: start, End: end})
+	}
+	return spans
+}
+
+// mergeSpans sorts spans and merges overlapping or adjacent ones
+func mergeSpans(spans []text.Span) []text.Span {
+	if len(spans) < 2 {
+		return spans
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].Start < spans[j].Start
+	})
+	merged := []text.Span{spans[0]}
+	for _, sp := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if sp.Start <= last.End {
+			if sp.End > last.End {
+				last.End = sp.End
+			}
+			continue
+		}
+		merged = append(merged, sp)
+	}
+	return merged
+}
@@ -0,0 +1,549 @@
+package search
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/glob"
+)
+
+// Query is a parsed search expression.
+//
+// Syntax:
+//
+//	fix bug                  bare terms (substring of message or hash), implicit AND
+//	"fix bug"                quoted phrase
+//	author:alice             author name or email (glob if it contains * ? [)
+//	file:*.go                changed file path (glob matches full path or base name)
+//	after:2025-01-01         committed on or after date
+//	before:2025-02-01        committed before date
+//	tag:v1.*  branch:main    refs pointing at the commit
+//	merge:yes                merge commits (merge:no for non-merges)
+//	msg:/JIRA-\d+/           full message by regex (or msg:word for substring)
+//	hash:abc123              hash prefix
+//	a OR b, a AND b, NOT a   operators (also |, &, -a, !a); NOT > AND > OR
+//	(a OR b) c               grouping
+type Query struct {
+	raw        string
+	root       node
+	needsFiles bool
+}
+
+// FileSource returns the paths changed by a commit and whether they are known.
+type FileSource func(hash string) ([]string, bool)
+
+// ParseError describes a syntax error at a position in the query.
+type ParseError struct {
+	Pos int // 0-indexed byte offset
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("col %d: %s", e.Pos+1, e.Msg)
+}
+
+// Parse parses a search query. An empty query matches every commit.
+func Parse(s string) (*Query, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, input: s}
+	q := &Query{raw: s}
+	if len(tokens) == 0 {
+		q.root = allNode{}
+		return q, nil
+	}
+	q.root, err = p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		if tok.kind == tokRParen {
+			return nil, &ParseError{Pos: tok.pos, Msg: "unmatched ')'"}
+		}
+		return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	q.needsFiles = p.needsFiles
+	return q, nil
+}
+
+// Match reports whether a commit satisfies the query.
+// files may be nil when no file: terms are present.
+func (q *Query) Match(c *domain.Commit, files FileSource) bool {
+	return q.root.match(c, files)
+}
+
+// NeedsFiles returns true if the query contains file: terms,
+// which require the changed paths of each commit.
+func (q *Query) NeedsFiles() bool {
+	return q.needsFiles
+}
+
+// String returns a normalized prefix form of the query (used in tests).
+func (q *Query) String() string {
+	return q.root.String()
+}
+
+// Raw returns the query as typed.
+func (q *Query) Raw() string {
+	return q.raw
+}
+
+// --- AST ---
+
+type node interface {
+	match(c *domain.Commit, files FileSource) bool
+	String() string
+}
+
+type allNode struct{}
+
+func (allNode) match(*domain.Commit, FileSource) bool { return true }
+func (allNode) String() string                        { return "*" }
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(c *domain.Commit, f FileSource) bool {
+	return n.left.match(c, f) && n.right.match(c, f)
+}
+func (n andNode) String() string { return "(and " + n.left.String() + " " + n.right.String() + ")" }
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(c *domain.Commit, f FileSource) bool {
+	return n.left.match(c, f) || n.right.match(c, f)
+}
+func (n orNode) String() string { return "(or " + n.left.String() + " " + n.right.String() + ")" }
+
+type notNode struct{ inner node }
+
+func (n notNode) match(c *domain.Commit, f FileSource) bool { return !n.inner.match(c, f) }
+func (n notNode) String() string                            { return "(not " + n.inner.String() + ")" }
+
+// textNode is a bare term: substring of message or hash (case-insensitive)
+type textNode struct{ text string }
+
+func (n textNode) match(c *domain.Commit, _ FileSource) bool {
+	return containsIgnoreCase(c.Message, n.text) ||
+		containsIgnoreCase(c.FullMessage, n.text) ||
+		containsIgnoreCase(c.Hash, n.text) ||
+		containsIgnoreCase(c.ShortHash, n.text)
+}
+func (n textNode) String() string { return fmt.Sprintf("%q", n.text) }
+
+// fieldNode is a field:value term
+type fieldNode struct {
+	field string
+	value string
+	re    *regexp.Regexp // compiled msg:/regex/ (nil otherwise)
+	fn    func(c *domain.Commit, files FileSource) bool
+}
+
+func (n fieldNode) match(c *domain.Commit, f FileSource) bool { return n.fn(c, f) }
+func (n fieldNode) String() string                            { return n.field + ":" + fmt.Sprintf("%q", n.value) }
+
+// --- Lexer ---
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+	tokTerm
+)
+
+type token struct {
+	kind  tokenKind
+	pos   int
+	text  string // raw text for errors, or term text
+	field string // field name for field:value terms
+	regex bool   // value was written as /regex/
+}
+
+// knownFields lists recognized field prefixes. Unknown prefixes are treated
+// as plain text so messages like "feat: x" remain searchable.
+var knownFields = map[string]bool{
+	"author": true,
+	"file":   true,
+	"after":  true,
+	"before": true,
+	"tag":    true,
+	"branch": true,
+	"merge":  true,
+	"msg":    true,
+	"hash":   true,
+}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(s) {
+		ch := s[i]
+		switch {
+		case ch == ' ' || ch == '\t':
+			i++
+		case ch == '(':
+			tokens = append(tokens, token{kind: tokLParen, pos: i, text: "("})
+			i++
+		case ch == ')':
+			tokens = append(tokens, token{kind: tokRParen, pos: i, text: ")"})
+			i++
+		case ch == '|':
+			tokens = append(tokens, token{kind: tokOr, pos: i, text: "|"})
+			i++
+		case ch == '&':
+			tokens = append(tokens, token{kind: tokAnd, pos: i, text: "&"})
+			i++
+		case (ch == '-' || ch == '!') && atTermStart(s, i):
+			tokens = append(tokens, token{kind: tokNot, pos: i, text: string(ch)})
+			i++
+		case ch == '"':
+			text, next, err := lexQuoted(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokTerm, pos: i, text: text})
+			i = next
+		default:
+			tok, next, err := lexWord(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i = next
+		}
+	}
+	return tokens, nil
+}
+
+// atTermStart reports whether a '-' or '!' at i prefixes a term (negation)
+// rather than being part of a word like "fix-up".
+func atTermStart(s string, i int) bool {
+	if i+1 >= len(s) {
+		return false
+	}
+	next := s[i+1]
+	return next != ' ' && next != '\t' && next != ')'
+}
+
+func lexQuoted(s string, start int) (string, int, error) {
+	var b strings.Builder
+	i := start + 1
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				b.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+			i++
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return "", 0, &ParseError{Pos: start, Msg: "unterminated quote"}
+}
+
+func lexRegex(s string, start int) (string, int, error) {
+	var b strings.Builder
+	i := start + 1
+	for i < len(s) {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] == '/' {
+			b.WriteByte('/')
+			i += 2
+			continue
+		}
+		if s[i] == '/' {
+			return b.String(), i + 1, nil
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return "", 0, &ParseError{Pos: start, Msg: "unterminated regex, expected closing '/'"}
+}
+
+func isWordEnd(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '(' || ch == ')' || ch == '"'
+}
+
+func lexWord(s string, start int) (token, int, error) {
+	i := start
+	for i < len(s) && !isWordEnd(s[i]) && s[i] != ':' {
+		i++
+	}
+	word := s[start:i]
+
+	// Field prefix?
+	if i < len(s) && s[i] == ':' && knownFields[strings.ToLower(word)] {
+		field := strings.ToLower(word)
+		valStart := i + 1
+		// A quote starts a quoted value; any other word end leaves it empty
+		if valStart >= len(s) || isWordEnd(s[valStart]) && s[valStart] != '"' {
+			return token{}, 0, &ParseError{Pos: start, Msg: fmt.Sprintf("missing value after %q", field+":")}
+		}
+		switch {
+		case s[valStart] == '"':
+			val, next, err := lexQuoted(s, valStart)
+			if err != nil {
+				return token{}, 0, err
+			}
+			return token{kind: tokTerm, pos: start, field: field, text: val}, next, nil
+		case s[valStart] == '/' && field == "msg":
+			val, next, err := lexRegex(s, valStart)
+			if err != nil {
+				return token{}, 0, err
+			}
+			return token{kind: tokTerm, pos: start, field: field, text: val, regex: true}, next, nil
+		default:
+			j := valStart
+			for j < len(s) && !isWordEnd(s[j]) {
+				j++
+			}
+			return token{kind: tokTerm, pos: start, field: field, text: s[valStart:j]}, j, nil
+		}
+	}
+
+	// Plain word (may contain ':' for unknown prefixes such as "feat:")
+	for i < len(s) && !isWordEnd(s[i]) {
+		i++
+	}
+	word = s[start:i]
+	switch word {
+	case "AND":
+		return token{kind: tokAnd, pos: start, text: word}, i, nil
+	case "OR":
+		return token{kind: tokOr, pos: start, text: word}, i, nil
+	case "NOT":
+		return token{kind: tokNot, pos: start, text: word}, i, nil
+	}
+	return token{kind: tokTerm, pos: start, text: word}, i, nil
+}
+
+// --- Parser ---
+
+type parser struct {
+	tokens     []token
+	input      string
+	pos        int
+	needsFiles bool
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return token{kind: tokEOF, pos: len(p.input)}
+}
+
+func (p *parser) next() token {
+	tok := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr: and ( OR and )*
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd: not ( [AND] not )*
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		switch tok.kind {
+		case tokAnd:
+			p.next()
+		case tokTerm, tokNot, tokLParen:
+			// implicit AND
+		default:
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+// parseNot: NOT not | primary
+func (p *parser) parseNot() (node, error) {
+	if p.peek().kind == tokNot {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary: '(' or ')' | term
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &ParseError{Pos: tok.pos, Msg: "unclosed '('"}
+		}
+		return inner, nil
+	case tokTerm:
+		return p.buildTerm(tok)
+	case tokEOF:
+		return nil, &ParseError{Pos: tok.pos, Msg: "unexpected end of query"}
+	case tokRParen:
+		return nil, &ParseError{Pos: tok.pos, Msg: "unexpected ')'"}
+	default:
+		return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("expected a term after %q", tok.text)}
+	}
+}
+
+func (p *parser) buildTerm(tok token) (node, error) {
+	if tok.field == "" {
+		return textNode{text: strings.ToLower(tok.text)}, nil
+	}
+
+	value := tok.text
+	lower := strings.ToLower(value)
+	n := fieldNode{field: tok.field, value: value}
+
+	// Name fields match like the filters, compiled once
+	var pattern glob.Pattern
+	switch tok.field {
+	case "author", "file", "tag", "branch":
+		var err error
+		if pattern, err = glob.Compile(value); err != nil {
+			return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("invalid %s pattern %q", tok.field, value)}
+		}
+	}
+
+	switch tok.field {
+	case "author":
+		n.fn = func(c *domain.Commit, _ FileSource) bool {
+			return pattern.Match(c.Author) || pattern.Match(c.Email)
+		}
+	case "file":
+		p.needsFiles = true
+		n.fn = func(c *domain.Commit, files FileSource) bool {
+			if files == nil {
+				return false
+			}
+			paths, ok := files(c.Hash)
+			if !ok {
+				return false
+			}
+			for _, fp := range paths {
+				if matchPath(fp, pattern) {
+					return true
+				}
+			}
+			return false
+		}
+	case "after", "before":
+		t, err := parseDate(value)
+		if err != nil {
+			return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("invalid %s date %q, want YYYY-MM-DD", tok.field, value)}
+		}
+		if tok.field == "after" {
+			n.fn = func(c *domain.Commit, _ FileSource) bool { return !c.Date.Before(t) }
+		} else {
+			n.fn = func(c *domain.Commit, _ FileSource) bool { return c.Date.Before(t) }
+		}
+	case "tag":
+		n.fn = func(c *domain.Commit, _ FileSource) bool { return anyMatch(c.Tags, pattern) }
+	case "branch":
+		n.fn = func(c *domain.Commit, _ FileSource) bool { return anyMatch(c.BranchRefs, pattern) }
+	case "merge":
+		var want bool
+		switch lower {
+		case "yes", "true", "y", "1":
+			want = true
+		case "no", "false", "n", "0":
+			want = false
+		default:
+			return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("invalid merge value %q (want yes or no)", value)}
+		}
+		n.fn = func(c *domain.Commit, _ FileSource) bool { return (len(c.Parents) > 1) == want }
+	case "msg":
+		if tok.regex {
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, &ParseError{Pos: tok.pos, Msg: "invalid regex: " + regexErrorText(err)}
+			}
+			n.re = re
+			n.fn = func(c *domain.Commit, _ FileSource) bool {
+				return re.MatchString(c.FullMessage) || re.MatchString(c.Message)
+			}
+		} else {
+			n.fn = func(c *domain.Commit, _ FileSource) bool {
+				return containsIgnoreCase(c.Message, lower) || containsIgnoreCase(c.FullMessage, lower)
+			}
+		}
+	case "hash":
+		n.fn = func(c *domain.Commit, _ FileSource) bool {
+			return strings.HasPrefix(strings.ToLower(c.Hash), lower)
+		}
+	}
+	return n, nil
+}
+
+// regexErrorText trims the "error parsing regexp: " prefix for footer display
+func regexErrorText(err error) string {
+	return strings.TrimPrefix(err.Error(), "error parsing regexp: ")
+}
+
+// parseDate parses YYYY-MM-DD in local time
+func parseDate(s string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", s, time.Local)
+}
+
+// matchPath matches a file path: globs are tried against the full path and
+// the base name
+func matchPath(p string, pattern glob.Pattern) bool {
+	return pattern.Match(p) || pattern.Match(path.Base(p))
+}
+
+func anyMatch(values []string, pattern glob.Pattern) bool {
+	for _, v := range values {
+		if pattern.Match(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nogo/gitree/internal/domain"
+)
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"", "*"},
+		{"fix", `"fix"`},
+		{"fix bug", `(and "fix" "bug")`},
+		{"a OR b c", `(or "a" (and "b" "c"))`},
+		{"a b OR c", `(or (and "a" "b") "c")`},
+		{"a OR b AND c", `(or "a" (and "b" "c"))`},
+		{"NOT a b", `(and (not "a") "b")`},
+		{"NOT a OR b", `(or (not "a") "b")`},
+		{"(a OR b) c", `(and (or "a" "b") "c")`},
+		{"a | b & c", `(or "a" (and "b" "c"))`},
+		{"-author:bot fix", `(and (not author:"bot") "fix")`},
+		{"!merge:yes", `(not merge:"yes")`},
+		{"NOT NOT a", `(not (not "a"))`},
+		{"((a))", `"a"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tc.query, err)
+			}
+			if got := q.String(); got != tc.expected {
+				t.Errorf("Parse(%q) = %s, want %s", tc.query, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseQuoting(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`"fix bug"`, `"fix bug"`},
+		{`"a OR b"`, `"a or b"`},
+		{`author:"Jane Doe"`, `author:"Jane Doe"`},
+		{`"say \"hi\""`, `"say \"hi\""`},
+		{`msg:/JIRA-\d+/`, `msg:"JIRA-\\d+"`},
+		{`msg:/a\/b/`, `msg:"a/b"`},
+		{`msg:/(x|y) z/ w`, `(and msg:"(x|y) z" "w")`},
+		{`feat: thing`, `(and "feat:" "thing")`},
+		{`fix-up`, `"fix-up"`},
+		{`file:*.go`, `file:"*.go"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tc.query, err)
+			}
+			if got := q.String(); got != tc.expected {
+				t.Errorf("Parse(%q) = %s, want %s", tc.query, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantMsg string
+		wantPos int
+	}{
+		{"(a OR b", "unclosed '('", 0},
+		{"a)", "unmatched ')'", 1},
+		{"a OR", "unexpected end of query", 4},
+		{"OR a", `expected a term after "OR"`, 0},
+		{`"abc`, "unterminated quote", 0},
+		{"msg:/abc", "unterminated regex", 4},
+		{"msg:/a(/", "invalid regex", 0},
+		{"after:yesterday", "invalid after date", 0},
+		{"merge:maybe", "invalid merge value", 0},
+		{"file:[a", "invalid file pattern", 0},
+		{"fix author:a[", "invalid author pattern", 4},
+		{"author: x", "missing value", 0},
+		{"author:", "missing value", 0},
+		{"author:(x)", "missing value", 0},
+		{"author:\tx", "missing value", 0},
+		{"(file:)", "missing value", 1},
+		{"a msg:", "missing value", 2},
+		{"a ()", "unexpected ')'", 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := Parse(tc.query)
+			if err == nil {
+				t.Fatalf("Parse(%q) expected error", tc.query)
+			}
+			pe, ok := err.(*ParseError)
+			if !ok {
+				t.Fatalf("Parse(%q) error type = %T, want *ParseError", tc.query, err)
+			}
+			if !strings.Contains(pe.Msg, tc.wantMsg) {
+				t.Errorf("Parse(%q) msg = %q, want containing %q", tc.query, pe.Msg, tc.wantMsg)
+			}
+			if pe.Pos != tc.wantPos {
+				t.Errorf("Parse(%q) pos = %d, want %d", tc.query, pe.Pos, tc.wantPos)
+			}
+		})
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 12, 0, 0, 0, time.Local) }
+	commits := []domain.Commit{
+		{Hash: "aaa1111", Author: "Alice", Email: "alice@example.com", Date: day(1), Message: "Fix parser", FullMessage: "Fix parser\n\nJIRA-42", Tags: []string{"v1.0.0"}, Parents: []string{"p"}},
+		{Hash: "bbb2222", Author: "dependabot[bot]", Email: "bot@github.com", Date: day(5), Message: "Bump deps", FullMessage: "Bump deps", Parents: []string{"p"}},
+		{Hash: "ccc3333", Author: "Bob", Email: "bob@example.com", Date: day(10), Message: "Merge branch 'x'", FullMessage: "Merge branch 'x'", BranchRefs: []string{"main", "origin/main"}, Parents: []string{"p", "q"}},
+	}
+	files := map[string][]string{
+		"aaa1111": {"internal/parser.go"},
+		"bbb2222": {"go.mod", "go.sum"},
+		"ccc3333": {"README.md"},
+	}
+	fileSource := func(hash string) ([]string, bool) {
+		f, ok := files[hash]
+		return f, ok
+	}
+
+	tests := []struct {
+		query    string
+		expected []int
+	}{
+		{"author:alice", []int{0}},
+		{"author:*@example.com", []int{0, 2}},
+		{"-author:*bot*", []int{0, 2}},
+		{"file:*.go", []int{0}},
+		{"file:go.", []int{1}},
+		{"after:2025-01-05", []int{1, 2}},
+		{"before:2025-01-05", []int{0}},
+		{"after:2025-01-02 before:2025-01-10", []int{1}},
+		{"tag:v1.*", []int{0}},
+		{"branch:main", []int{2}},
+		{"branch:origin*n", []int{2}}, // * crosses /, as in the branch filter
+		{"file:internal/*", []int{0}},
+		{"file:PARSER.go", []int{0}},
+		{"merge:yes", []int{2}},
+		{"merge:no", []int{0, 1}},
+		{`msg:/JIRA-\d+/`, []int{0}},
+		{"msg:bump", []int{1}},
+		{"hash:bbb", []int{1}},
+		{"fix OR bump", []int{0, 1}},
+		{"(fix OR bump) -author:*bot*", []int{0}},
+		{`"merge branch"`, []int{2}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tc.query, err)
+			}
+			got := matchCommits(commits, q, fileSource)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("matches = %v, want %v", got, tc.expected)
+			}
+			for i := range got {
+				if got[i] != tc.expected[i] {
+					t.Errorf("matches = %v, want %v", got, tc.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestQueryNeedsFiles(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"fix", false},
+		{"author:alice", false},
+		{"file:*.go", true},
+		{"fix OR NOT file:README.md", true},
+	}
+
+	for _, tc := range tests {
+		q, err := Parse(tc.query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tc.query, err)
+		}
+		if got := q.NeedsFiles(); got != tc.expected {
+			t.Errorf("Parse(%q).NeedsFiles() = %v, want %v", tc.query, got, tc.expected)
+		}
+	}
+}
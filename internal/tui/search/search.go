@@ -1,19 +1,59 @@
 package search
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/text"
 )
 
+// Mode selects how the search text is interpreted
+type Mode int
+
+const (
+	ModeSubstring Mode = iota // substring terms plus the structured query language
+	ModeRegex                 // regular expression over the commit message
+	ModeFuzzy                 // ranked fuzzy match over the commit subject
+)
+
+// String returns the short mode name shown in the footer
+func (m Mode) String() string {
+	switch m {
+	case ModeRegex:
+		return "regex"
+	case ModeFuzzy:
+		return "fuzzy"
+	default:
+		return "substring"
+	}
+}
+
+// ParseMode returns the mode with the given name (as returned by String)
+func ParseMode(name string) (Mode, bool) {
+	switch strings.ToLower(name) {
+	case "", "substring":
+		return ModeSubstring, true
+	case "regex":
+		return ModeRegex, true
+	case "fuzzy":
+		return ModeFuzzy, true
+	}
+	return ModeSubstring, false
+}
+
 type Search struct {
 	active       bool
 	inputMode    bool // true when typing in search box
+	mode         Mode
 	query        string
-	matches      []int // indices into commits
-	currentMatch int   // index into matches (-1 if no matches)
+	parsed       *Query         // parsed query in substring mode (nil until executed)
+	regex        *regexp.Regexp // compiled pattern in regex mode (nil until executed)
+	err          error          // parse/compile error for the text being typed
+	matches      []int          // indices into commits
+	currentMatch int            // index into matches (-1 if no matches)
 	textInput    textinput.Model
 }
 
@@ -32,10 +72,25 @@ func New() Search {
 func (s *Search) Activate() {
 	s.active = true
 	s.inputMode = true
+	s.err = nil
 	s.textInput.SetValue("")
 	s.textInput.Focus()
 }
 
+// SetQuery activates search with the given query without input mode,
+// e.g. when restoring a saved view. The caller runs Execute afterwards.
+func (s *Search) SetQuery(query string) error {
+	if err := s.compile(query); err != nil {
+		return err
+	}
+	s.query = query
+	s.err = nil
+	s.active = true
+	s.inputMode = false
+	s.textInput.SetValue(query)
+	return nil
+}
+
 // IsActive returns whether search mode is active (has results or input open)
 func (s Search) IsActive() bool {
 	return s.active
@@ -51,6 +106,34 @@ func (s Search) Query() string {
 	return s.query
 }
 
+// Err returns the parse error of the query being typed (nil if valid)
+func (s Search) Err() error {
+	return s.err
+}
+
+// Mode returns the current search mode
+func (s Search) Mode() Mode {
+	return s.mode
+}
+
+// SetMode sets the search mode
+func (s *Search) SetMode(mode Mode) {
+	s.mode = mode
+}
+
+// CycleMode switches to the next mode (substring → regex → fuzzy)
+// and revalidates the text being typed
+func (s *Search) CycleMode() {
+	s.mode = (s.mode + 1) % 3
+	s.err = s.validate(s.textInput.Value())
+}
+
+// NeedsFiles returns whether the active query has file: terms
+// and therefore needs changed paths for each commit
+func (s Search) NeedsFiles() bool {
+	return s.mode == ModeSubstring && s.parsed != nil && s.parsed.NeedsFiles()
+}
+
 // Matches returns indices of matching commits
 func (s Search) Matches() []int {
 	return s.matches
@@ -85,10 +168,18 @@ func (s Search) Update(msg tea.Msg) (Search, tea.Cmd, bool, bool) {
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyEnter:
-			// Execute search
+			// Execute search (stay in input mode on syntax errors)
+			if err := s.compile(s.textInput.Value()); err != nil {
+				s.err = err
+				return s, nil, false, false
+			}
 			s.query = s.textInput.Value()
+			s.err = nil
 			s.inputMode = false
 			return s, nil, true, false
+		case tea.KeyTab:
+			s.CycleMode()
+			return s, nil, false, false
 		case tea.KeyEsc:
 			// Cancel input, keep previous results if any
 			s.inputMode = false
@@ -101,11 +192,62 @@ func (s Search) Update(msg tea.Msg) (Search, tea.Cmd, bool, bool) {
 
 	var cmd tea.Cmd
 	s.textInput, cmd = s.textInput.Update(msg)
+
+	// Validate as the user types so errors show up in the footer
+	s.err = s.validate(s.textInput.Value())
 	return s, cmd, false, false
 }
 
-// Execute runs the search on the given commits
-func (s *Search) Execute(commits []domain.Commit) {
+// validate checks the text for the current mode without changing state
+func (s Search) validate(value string) error {
+	switch s.mode {
+	case ModeSubstring:
+		_, err := Parse(value)
+		return err
+	case ModeRegex:
+		_, err := compileRegex(value)
+		return err
+	}
+	return nil
+}
+
+// compile prepares the matcher for the current mode
+func (s *Search) compile(value string) error {
+	s.parsed = nil
+	s.regex = nil
+	switch s.mode {
+	case ModeSubstring:
+		q, err := Parse(value)
+		if err != nil {
+			return err
+		}
+		s.parsed = q
+	case ModeRegex:
+		re, err := compileRegex(value)
+		if err != nil {
+			return err
+		}
+		s.regex = re
+	}
+	return nil
+}
+
+// compileRegex compiles a pattern with smart case:
+// case-insensitive unless the pattern contains an uppercase letter
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if strings.ToLower(pattern) == pattern {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, &ParseError{Pos: 0, Msg: "invalid regex: " + regexErrorText(err)}
+	}
+	return re, nil
+}
+
+// Execute runs the search on the given commits.
+// files supplies changed paths for file: terms (may be nil).
+func (s *Search) Execute(commits []domain.Commit, files FileSource) {
 	if s.query == "" {
 		s.matches = nil
 		s.currentMatch = -1
@@ -113,7 +255,28 @@ func (s *Search) Execute(commits []domain.Commit) {
 		return
 	}
 
-	s.matches = searchCommits(commits, s.query)
+	switch s.mode {
+	case ModeRegex:
+		s.matches = nil
+		if s.regex != nil {
+			for i, c := range commits {
+				if s.regex.MatchString(c.FullMessage) || s.regex.MatchString(c.Message) {
+					s.matches = append(s.matches, i)
+				}
+			}
+		}
+	case ModeFuzzy:
+		// Matches are kept in score order so n/N walk best matches first
+		s.matches = nil
+		for _, r := range fuzzySearch(commits, s.query) {
+			s.matches = append(s.matches, r.index)
+		}
+	default:
+		s.matches = nil
+		if s.parsed != nil {
+			s.matches = matchCommits(commits, s.parsed, files)
+		}
+	}
 	if len(s.matches) > 0 {
 		s.currentMatch = 0
 	} else {
@@ -145,25 +308,56 @@ func (s *Search) Clear() {
 	s.active = false
 	s.inputMode = false
 	s.query = ""
+	s.parsed = nil
+	s.regex = nil
+	s.err = nil
 	s.matches = nil
 	s.currentMatch = -1
 	s.textInput.SetValue("")
 }
 
+// Highlight returns the spans of a commit message matched by the active search
+func (s Search) Highlight(message string) []text.Span {
+	if !s.active || s.query == "" {
+		return nil
+	}
+	switch s.mode {
+	case ModeRegex:
+		if s.regex != nil {
+			return regexSpans(s.regex, message)
+		}
+	case ModeFuzzy:
+		if _, positions, ok := fuzzyMatch(message, s.query); ok {
+			return positionsToSpans(positions)
+		}
+	default:
+		if s.parsed != nil {
+			return s.parsed.Highlight(message)
+		}
+	}
+	return nil
+}
+
 // InputView returns the text input view for rendering in footer
 func (s Search) InputView() string {
 	return s.textInput.View()
 }
 
-// searchCommits finds commits matching the query (case-insensitive)
+// searchCommits finds commits matching the query string.
+// Returns nil if the query does not parse.
 func searchCommits(commits []domain.Commit, query string) []int {
-	query = strings.ToLower(query)
+	q, err := Parse(query)
+	if err != nil {
+		return nil
+	}
+	return matchCommits(commits, q, nil)
+}
+
+// matchCommits returns indices of commits matching a parsed query
+func matchCommits(commits []domain.Commit, q *Query, files FileSource) []int {
 	var matches []int
-	for i, c := range commits {
-		if containsIgnoreCase(c.Message, query) ||
-			containsIgnoreCase(c.FullMessage, query) ||
-			containsIgnoreCase(c.Hash, query) ||
-			containsIgnoreCase(c.ShortHash, query) {
+	for i := range commits {
+		if q.Match(&commits[i], files) {
 			matches = append(matches, i)
 		}
 	}
@@ -1,28 +1,85 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/command"
+	"github.com/nogo/gitree/internal/tui/compare"
+	"github.com/nogo/gitree/internal/tui/detail"
+	"github.com/nogo/gitree/internal/tui/diff"
+	"github.com/nogo/gitree/internal/tui/filter"
+	"github.com/nogo/gitree/internal/tui/graph"
+	"github.com/nogo/gitree/internal/tui/histogram"
+	"github.com/nogo/gitree/internal/tui/insights"
+	"github.com/nogo/gitree/internal/tui/list"
+	"github.com/nogo/gitree/internal/tui/palette"
+	"github.com/nogo/gitree/internal/tui/rangediff"
+	"github.com/nogo/gitree/internal/tui/text"
+	"github.com/nogo/gitree/internal/tui/theme"
+	"github.com/nogo/gitree/internal/tui/views"
+)
 
 var (
+	HeaderStyle          lipgloss.Style
+	HeaderDimStyle       lipgloss.Style
+	HeaderHighlightStyle lipgloss.Style
+	FooterStyle          lipgloss.Style
+	FlashStyle           lipgloss.Style
+	ErrorStyle           lipgloss.Style
+	SeparatorStyle       lipgloss.Style
+	ColumnHeaderStyle    lipgloss.Style
+	BorderStyle          lipgloss.Style
+)
+
+func init() {
+	setStyles(theme.Dark())
+}
+
+// SetTheme rebuilds the styles of all views from t
+func SetTheme(t theme.Theme) {
+	setStyles(t)
+	command.SetTheme(t)
+	compare.SetTheme(t)
+	detail.SetTheme(t)
+	diff.SetTheme(t)
+	filter.SetTheme(t)
+	graph.SetTheme(t)
+	histogram.SetTheme(t)
+	insights.SetTheme(t)
+	list.SetTheme(t)
+	palette.SetTheme(t)
+	rangediff.SetTheme(t)
+	text.SetTheme(t)
+	views.SetTheme(t)
+}
+
+// setStyles rebuilds the styles of this package from t
+func setStyles(t theme.Theme) {
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205"))
+		Bold(true).
+		Foreground(t.Accent)
 
 	HeaderDimStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+		Foreground(t.Muted)
 
 	HeaderHighlightStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("205"))
+		Foreground(t.Accent)
 
 	FooterStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+		Foreground(t.Muted)
+
+	FlashStyle = lipgloss.NewStyle().
+		Foreground(t.Success)
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(t.Error)
 
 	SeparatorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("238"))
+		Foreground(t.Separator)
 
 	ColumnHeaderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("245"))
+		Foreground(t.Muted)
 
 	BorderStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62"))
-)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border)
+}
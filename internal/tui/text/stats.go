@@ -4,14 +4,25 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
 )
 
 // Styles for stats display
 var (
-	AdditionsStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("34"))
-	DeletionsStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	AdditionsStyle lipgloss.Style
+	DeletionsStyle lipgloss.Style
 )
 
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	AdditionsStyle = lipgloss.NewStyle().Foreground(t.Added)
+	DeletionsStyle = lipgloss.NewStyle().Foreground(t.Deleted)
+}
+
 // FileStats represents additions/deletions for display.
 type FileStats struct {
 	Additions int
@@ -190,3 +190,92 @@ func FitLeft(s string, width int) string {
 	return strings.Repeat(" ", width-len(runes)) + s
 }
 
+// Span is a half-open range [Start, End) of rune offsets in a plain string.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Highlight truncates a plain string to max runes (like Truncate) and renders
+// the runes covered by spans with the given style function.
+// Spans must be sorted and non-overlapping.
+func Highlight(s string, spans []Span, max int, style func(...string) string) string {
+	truncated := len([]rune(s)) > max
+	s = Truncate(s, max)
+	if len(spans) == 0 {
+		return s
+	}
+	runes := []rune(s)
+	visible := len(runes)
+	if truncated && visible > 0 {
+		// Never highlight the ellipsis added by Truncate
+		visible--
+	}
+
+	var result strings.Builder
+	pos := 0
+	for _, sp := range spans {
+		start, end := sp.Start, sp.End
+		if start < pos {
+			start = pos
+		}
+		if end > visible {
+			end = visible
+		}
+		if start >= end {
+			continue
+		}
+		result.WriteString(string(runes[pos:start]))
+		result.WriteString(style(string(runes[start:end])))
+		pos = end
+	}
+	result.WriteString(string(runes[pos:]))
+	return result.String()
+}
+
+// WrapAnsi breaks a string with ANSI codes into lines of at most width
+// display columns. Colors active at a break are reset at the end of the
+// line and set again at the start of the next.
+func WrapAnsi(s string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+
+	var lines []string
+	var line, seq strings.Builder
+	active := "" // escape sequences since the last reset
+	displayCount := 0
+	inEscape := false
+
+	for _, r := range s {
+		if r == '\x1b' {
+			inEscape = true
+			seq.Reset()
+		}
+		if inEscape {
+			seq.WriteRune(r)
+			if r == 'm' {
+				inEscape = false
+				if code := seq.String(); code == "\x1b[0m" || code == "\x1b[m" {
+					active = ""
+				} else {
+					active += code
+				}
+				line.WriteString(seq.String())
+			}
+			continue
+		}
+		if displayCount == width {
+			if active != "" {
+				line.WriteString("\x1b[0m")
+			}
+			lines = append(lines, line.String())
+			line.Reset()
+			line.WriteString(active)
+			displayCount = 0
+		}
+		line.WriteRune(r)
+		displayCount++
+	}
+	return append(lines, line.String())
+}
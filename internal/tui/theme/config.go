@@ -0,0 +1,128 @@
+package theme
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/config"
+)
+
+// colorFields maps the color names of the config file to theme fields
+func (t *Theme) colorFields() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"accent":          &t.Accent,
+		"text":            &t.Text,
+		"muted":           &t.Muted,
+		"dimmed":          &t.Dimmed,
+		"border":          &t.Border,
+		"separator":       &t.Separator,
+		"selection":       &t.Selection,
+		"selection_text":  &t.SelectionText,
+		"hash":            &t.Hash,
+		"author":          &t.Author,
+		"date":            &t.Date,
+		"match":           &t.Match,
+		"checked":         &t.Checked,
+		"success":         &t.Success,
+		"warning":         &t.Warning,
+		"error":           &t.Error,
+		"added":           &t.Added,
+		"modified":        &t.Modified,
+		"deleted":         &t.Deleted,
+		"renamed":         &t.Renamed,
+		"diff_added":      &t.DiffAdded,
+		"diff_deleted":    &t.DiffDeleted,
+		"diff_hunk":       &t.DiffHunk,
+		"diff_added_bg":   &t.DiffAddedBg,
+		"diff_deleted_bg": &t.DiffDeletedBg,
+		"keyword":         &t.Keyword,
+		"string":          &t.String,
+		"comment":         &t.Comment,
+		"number":          &t.Number,
+		"function":        &t.Function,
+		"type":            &t.Type,
+		"bar":             &t.Bar,
+		"bar_selected":    &t.BarSelected,
+		"badge_text":      &t.BadgeText,
+		"remote_badge":    &t.RemoteBadge,
+		"head_badge":      &t.HeadBadge,
+		"tag_badge":       &t.TagBadge,
+	}
+}
+
+// ColorNames lists the colors that can be set in the config file
+func ColorNames() []string {
+	var t Theme
+	var names []string
+	for name := range t.colorFields() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FromConfig builds the theme selected in the config file. NO_COLOR in the
+// environment wins over the configured theme.
+func FromConfig(c config.Theme) (Theme, error) {
+	t, err := ByName(c.Name)
+	if err != nil {
+		return Theme{}, fmt.Errorf("theme: %w", err)
+	}
+
+	fields := t.colorFields()
+	for name, value := range c.Colors {
+		field, ok := fields[name]
+		if !ok {
+			return Theme{}, fmt.Errorf("theme.colors: unknown color %q (use %s)", name, strings.Join(ColorNames(), ", "))
+		}
+		color, err := ParseColor(value)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme.colors.%s: %w", name, err)
+		}
+		*field = color
+	}
+
+	if c.Lanes != nil {
+		if t.Lanes, err = parseColors("theme.lanes", c.Lanes); err != nil {
+			return Theme{}, err
+		}
+	}
+	if c.Branches != nil {
+		if t.Branches, err = parseColors("theme.branches", c.Branches); err != nil {
+			return Theme{}, err
+		}
+	}
+	if c.Heat != nil {
+		if len(c.Heat) != len(t.Heat) {
+			return Theme{}, fmt.Errorf("theme.heat: want %d colors from empty to busiest, got %d", len(t.Heat), len(c.Heat))
+		}
+		heat, err := parseColors("theme.heat", c.Heat)
+		if err != nil {
+			return Theme{}, err
+		}
+		copy(t.Heat[:], heat)
+	}
+
+	if NoColorEnv() {
+		t.NoColor = true
+	}
+	return t, nil
+}
+
+// parseColors parses a non-empty color list
+func parseColors(setting string, values []string) ([]lipgloss.Color, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%s: needs at least one color", setting)
+	}
+	colors := make([]lipgloss.Color, len(values))
+	for i, v := range values {
+		c, err := ParseColor(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", setting, i, err)
+		}
+		colors[i] = c
+	}
+	return colors, nil
+}
@@ -0,0 +1,218 @@
+// Package theme holds the colors of the UI. Each view package builds its
+// styles from a Theme in its SetTheme function.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme names accepted in the config file
+const (
+	NameDark    = "dark"
+	NameLight   = "light"
+	NameNoColor = "none" // no colors, selection shown in reverse video
+)
+
+// Names lists the built-in themes
+var Names = []string{NameDark, NameLight, NameNoColor}
+
+// Theme is the set of colors used by all views
+type Theme struct {
+	Accent        lipgloss.Color // titles and headers
+	Text          lipgloss.Color // commit messages, file paths
+	Muted         lipgloss.Color // hints, labels, footer
+	Dimmed        lipgloss.Color // commits outside a highlight
+	Border        lipgloss.Color // overlay borders, focused panels
+	Separator     lipgloss.Color // separators, unfocused panels
+	Selection     lipgloss.Color // background of the cursor row
+	SelectionText lipgloss.Color
+	Hash          lipgloss.Color
+	Author        lipgloss.Color
+	Date          lipgloss.Color
+	Match         lipgloss.Color // search matches
+	Checked       lipgloss.Color // selected filter entries
+	Success       lipgloss.Color // transient confirmations
+	Warning       lipgloss.Color
+	Error         lipgloss.Color
+	Added         lipgloss.Color // file status and stats
+	Modified      lipgloss.Color
+	Deleted       lipgloss.Color
+	Renamed       lipgloss.Color
+	DiffAdded     lipgloss.Color
+	DiffDeleted   lipgloss.Color
+	DiffHunk      lipgloss.Color
+	DiffAddedBg   lipgloss.Color // background of highlighted code lines
+	DiffDeletedBg lipgloss.Color
+	Keyword       lipgloss.Color // syntax highlighting in diffs
+	String        lipgloss.Color
+	Comment       lipgloss.Color
+	Number        lipgloss.Color
+	Function      lipgloss.Color
+	Type          lipgloss.Color
+	Bar           lipgloss.Color // histogram
+	BarSelected   lipgloss.Color
+	BadgeText     lipgloss.Color // text on colored branch badges
+	RemoteBadge   lipgloss.Color // background of remote branch badges
+	HeadBadge     lipgloss.Color
+	TagBadge      lipgloss.Color
+	Lanes         []lipgloss.Color // graph lines, cycled by lane
+	Branches      []lipgloss.Color // local branch badges, in order of appearance
+	Heat          [5]lipgloss.Color
+
+	// NoColor marks the selection with reverse video since it has no
+	// background color
+	NoColor bool
+}
+
+// Dark is the default theme for dark terminals
+func Dark() Theme {
+	return Theme{
+		Accent:        "205",
+		Text:          "252",
+		Muted:         "241",
+		Dimmed:        "239",
+		Border:        "62",
+		Separator:     "238",
+		Selection:     "237",
+		SelectionText: "255",
+		Hash:          "214",
+		Author:        "81",
+		Date:          "242",
+		Match:         "220",
+		Checked:       "156",
+		Success:       "86",
+		Warning:       "214",
+		Error:         "196",
+		Added:         "34",
+		Modified:      "214",
+		Deleted:       "196",
+		Renamed:       "51",
+		DiffAdded:     "#50FA7B",
+		DiffDeleted:   "#FF5555",
+		DiffHunk:      "#6272A4",
+		DiffAddedBg:   "#1C3323",
+		DiffDeletedBg: "#3D1E24",
+		Keyword:       "#FF79C6",
+		String:        "#F1FA8C",
+		Comment:       "#6272A4",
+		Number:        "#BD93F9",
+		Function:      "#50FA7B",
+		Type:          "#8BE9FD",
+		Bar:           "#8BE9FD",
+		BarSelected:   "#F1FA8C",
+		BadgeText:     "0",
+		RemoteBadge:   "22",
+		HeadBadge:     "161",
+		TagBadge:      "220",
+		Lanes:         []lipgloss.Color{"205", "86", "156", "221", "213", "81"},
+		Branches:      []lipgloss.Color{"205", "86", "221", "156", "213", "81"},
+		Heat:          [5]lipgloss.Color{"240", "22", "28", "34", "40"},
+	}
+}
+
+// Light is a theme for terminals with a light background
+func Light() Theme {
+	return Theme{
+		Accent:        "162",
+		Text:          "235",
+		Muted:         "244",
+		Dimmed:        "250",
+		Border:        "61",
+		Separator:     "252",
+		Selection:     "254",
+		SelectionText: "232",
+		Hash:          "130",
+		Author:        "25",
+		Date:          "243",
+		Match:         "166",
+		Checked:       "28",
+		Success:       "29",
+		Warning:       "166",
+		Error:         "160",
+		Added:         "28",
+		Modified:      "130",
+		Deleted:       "160",
+		Renamed:       "30",
+		DiffAdded:     "28",
+		DiffDeleted:   "160",
+		DiffHunk:      "61",
+		DiffAddedBg:   "194",
+		DiffDeletedBg: "224",
+		Keyword:       "127",
+		String:        "28",
+		Comment:       "245",
+		Number:        "91",
+		Function:      "25",
+		Type:          "30",
+		Bar:           "31",
+		BarSelected:   "172",
+		BadgeText:     "231",
+		RemoteBadge:   "151",
+		HeadBadge:     "161",
+		TagBadge:      "178",
+		Lanes:         []lipgloss.Color{"162", "30", "28", "130", "91", "25"},
+		Branches:      []lipgloss.Color{"162", "30", "130", "28", "91", "25"},
+		Heat:          [5]lipgloss.Color{"252", "151", "114", "71", "28"},
+	}
+}
+
+// NoColor is the dark theme with colors disabled
+func NoColor() Theme {
+	t := Dark()
+	t.NoColor = true
+	return t
+}
+
+// ByName returns a built-in theme
+func ByName(name string) (Theme, error) {
+	switch name {
+	case NameDark, "":
+		return Dark(), nil
+	case NameLight:
+		return Light(), nil
+	case NameNoColor:
+		return NoColor(), nil
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (use dark, light or none)", name)
+}
+
+// NoColorEnv returns whether NO_COLOR asks to disable colors (no-color.org)
+func NoColorEnv() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ParseColor accepts an xterm-256 color number or a #rrggbb hex color
+func ParseColor(s string) (lipgloss.Color, error) {
+	if hexColorRe.MatchString(s) {
+		return lipgloss.Color(s), nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(s), nil
+	}
+	return "", fmt.Errorf("invalid color %q (use 0-255 or #rrggbb)", s)
+}
+
+// Selected returns the style of the cursor row in lists and pickers
+func (t Theme) Selected() lipgloss.Style {
+	if t.NoColor {
+		return lipgloss.NewStyle().Reverse(true)
+	}
+	return lipgloss.NewStyle().
+		Background(t.Selection).
+		Foreground(t.SelectionText)
+}
+
+// Overlay returns the bordered box style of overlays and popups
+func (t Theme) Overlay() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(1, 2)
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/tui/search"
+	"github.com/nogo/gitree/internal/tui/views"
+)
+
+// viewDateLayout is used for view dates that fall on local midnight
+const viewDateLayout = "2006-01-02"
+
+// currentView captures the filters and search as an unnamed view
+func (m Model) currentView() config.View {
+	state := m.filters.State()
+	v := config.View{
+		Branches:        state.Branches,
+		Authors:         state.Authors,
+		Tags:            state.Tags,
+		ExcludeBranches: state.ExcludeBranches,
+		ExcludeAuthors:  state.ExcludeAuthors,
+		ExcludeTags:     state.ExcludeTags,
+		Highlight:       state.Highlight,
+	}
+	if !state.Since.IsZero() {
+		v.Since = formatViewTime(state.Since, false)
+	}
+	if !state.Until.IsZero() {
+		v.Until = formatViewTime(state.Until, true)
+	}
+	if m.search.IsActive() && m.search.Query() != "" {
+		v.Search = m.search.Query()
+		if m.search.Mode() != search.ModeSubstring {
+			v.SearchMode = m.search.Mode().String()
+		}
+	}
+	return v
+}
+
+// ApplyView replaces all filters and the search with a saved view.
+// Branches, authors and tags missing from the repository are skipped;
+// invalid dates or search queries are reported as errors. Returns the
+// command that reloads what depends on the filters, e.g. the search.
+func (m *Model) ApplyView(v config.View) (tea.Cmd, error) {
+	state, err := views.State(v, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	mode, ok := search.ParseMode(v.SearchMode)
+	if !ok {
+		return nil, fmt.Errorf("view %q: unknown search mode %q", v.Name, v.SearchMode)
+	}
+
+	// Validate the search before touching any state
+	s := m.search
+	s.Clear()
+	s.SetMode(mode)
+	if v.Search != "" {
+		if err := s.SetQuery(v.Search); err != nil {
+			return nil, fmt.Errorf("view %q: search: %w", v.Name, err)
+		}
+	}
+
+	m.filters.ApplyState(state)
+	m.histogram.Reset()
+	if !state.Since.IsZero() || !state.Until.IsZero() {
+		m.histogram.SelectRange(state.Since, state.Until)
+	}
+	m.search = s
+	m.list.SetMatchIndices(nil)
+	m.list.SetMatchHighlighter(nil)
+	m.applyHighlight()
+	return m.applyAllFilters(), nil
+}
+
+// openViews loads saved views and shows the picker
+func (m *Model) openViews() {
+	m.viewPicker.Open()
+	m.viewPicker.SetSize(m.width, m.height)
+	m.reloadViews()
+	m.showViews = true
+}
+
+// reloadViews refreshes the picker from disk
+func (m *Model) reloadViews() {
+	list, err := m.viewStore.Load()
+	m.viewPicker.SetViews(list)
+	if err != nil {
+		m.viewPicker.SetResult("", err)
+	}
+}
+
+// handleViewRequest performs an action chosen in the views picker
+func (m *Model) handleViewRequest(req views.Request) tea.Cmd {
+	switch req.Action {
+	case views.ActionApply:
+		cmd, err := m.applyViewNamed(req.Name)
+		if err != nil {
+			m.viewPicker.SetResult("", err)
+			return nil
+		}
+		m.showViews = false
+		return cmd
+
+	case views.ActionSave:
+		v := m.currentView()
+		v.Name = req.Name
+		v.Scope = req.Scope
+		err := m.viewStore.Save(v)
+		m.reloadViews()
+		m.viewPicker.SetResult(fmt.Sprintf("Saved %q (%s)", req.Name, req.Scope), err)
+
+	case views.ActionDelete:
+		err := m.viewStore.Delete(req.Name, req.Scope)
+		m.reloadViews()
+		m.viewPicker.SetResult(fmt.Sprintf("Deleted %q (%s)", req.Name, req.Scope), err)
+	}
+	return nil
+}
+
+// applyViewNamed applies the saved view called name
+func (m *Model) applyViewNamed(name string) (tea.Cmd, error) {
+	list, err := m.viewStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	v, ok := config.FindView(list, name)
+	if !ok {
+		return nil, fmt.Errorf("view %q not found", name)
+	}
+	return m.ApplyView(v)
+}
+
+// formatViewTime formats a filter boundary for a view file, using a plain
+// date when the time is local midnight. An until date names the last
+// included day, matching how --until and views parse it.
+func formatViewTime(t time.Time, until bool) string {
+	t = t.Local()
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		if until {
+			t = t.AddDate(0, 0, -1)
+		}
+		return t.Format(viewDateLayout)
+	}
+	return t.Format(time.RFC3339)
+}
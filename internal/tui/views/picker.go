@@ -0,0 +1,322 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// Action is what the picker asks the app to do
+type Action int
+
+const (
+	ActionNone   Action = iota
+	ActionApply         // apply the named view
+	ActionSave          // save the current filters and search under Name in Scope
+	ActionDelete        // delete the named view from Scope
+)
+
+// Request is returned by Update when the user picked an action
+type Request struct {
+	Action Action
+	Name   string
+	Scope  config.Scope
+}
+
+// Picker lists saved views and lets the user apply, save or delete them
+type Picker struct {
+	views         []config.View
+	cursor        int
+	scrollOffset  int
+	naming        bool // typing a name for a new view
+	input         textinput.Model
+	scope         config.Scope // scope for the new view
+	confirmDelete bool         // "d" pressed once on the current view
+	message       string       // result of the last action
+	err           error        // error of the last action
+	keys          keys.Map
+	width         int
+	height        int
+}
+
+// New creates an empty picker
+func New() Picker {
+	ti := textinput.New()
+	ti.Placeholder = "view name"
+	ti.CharLimit = 60
+	ti.Width = 30
+	return Picker{input: ti, keys: keys.Default()}
+}
+
+// SetKeyMap sets the key bindings
+func (p *Picker) SetKeyMap(km keys.Map) {
+	p.keys = km
+}
+
+// SetSize sets the overlay area
+func (p *Picker) SetSize(w, h int) {
+	p.width = w
+	p.height = h
+}
+
+// SetViews replaces the listed views, keeping the cursor in range
+func (p *Picker) SetViews(views []config.View) {
+	p.views = views
+	if p.cursor >= len(views) {
+		p.cursor = len(views) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	p.adjustScroll()
+}
+
+// Open resets transient state before showing the picker
+func (p *Picker) Open() {
+	p.naming = false
+	p.confirmDelete = false
+	p.message = ""
+	p.err = nil
+	p.input.Blur()
+}
+
+// SetResult shows the outcome of the last request in the picker
+func (p *Picker) SetResult(message string, err error) {
+	p.message = message
+	p.err = err
+}
+
+// maxVisibleItems calculates how many items can be displayed
+func (p Picker) maxVisibleItems() int {
+	maxItems := p.height - 13
+	if maxItems < 3 {
+		maxItems = 3
+	}
+	return maxItems
+}
+
+// adjustScroll keeps cursor within visible range
+func (p *Picker) adjustScroll() {
+	maxVisible := p.maxVisibleItems()
+	if len(p.views) <= maxVisible {
+		p.scrollOffset = 0
+		return
+	}
+	if p.cursor < p.scrollOffset {
+		p.scrollOffset = p.cursor
+	}
+	if p.cursor >= p.scrollOffset+maxVisible {
+		p.scrollOffset = p.cursor - maxVisible + 1
+	}
+}
+
+// Update handles input and returns (updated picker, cmd, request, cancelled)
+func (p Picker) Update(msg tea.Msg) (Picker, tea.Cmd, Request, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil, Request{}, false
+	}
+
+	if p.naming {
+		return p.updateNaming(keyMsg)
+	}
+
+	key := keyMsg.String()
+	if !p.keys.Is(key, keys.ViewsDelete) {
+		p.confirmDelete = false
+	}
+
+	switch {
+	case p.keys.Is(key, keys.ViewsDown):
+		if p.cursor < len(p.views)-1 {
+			p.cursor++
+			p.adjustScroll()
+		}
+	case p.keys.Is(key, keys.ViewsUp):
+		if p.cursor > 0 {
+			p.cursor--
+			p.adjustScroll()
+		}
+	case p.keys.Is(key, keys.ViewsApply):
+		if v, ok := p.current(); ok {
+			return p, nil, Request{Action: ActionApply, Name: v.Name, Scope: v.Scope}, false
+		}
+	case p.keys.Is(key, keys.ViewsSave):
+		p.naming = true
+		p.scope = config.ScopeRepo
+		p.message = ""
+		p.err = nil
+		p.input.SetValue("")
+		return p, p.input.Focus(), Request{}, false
+	case p.keys.Is(key, keys.ViewsDelete):
+		v, ok := p.current()
+		if !ok {
+			break
+		}
+		if !p.confirmDelete {
+			p.confirmDelete = true
+			return p, nil, Request{}, false
+		}
+		p.confirmDelete = false
+		return p, nil, Request{Action: ActionDelete, Name: v.Name, Scope: v.Scope}, false
+	case p.keys.Is(key, keys.ViewsClose):
+		return p, nil, Request{}, true
+	}
+	return p, nil, Request{}, false
+}
+
+// updateNaming handles input while typing the name of a new view
+func (p Picker) updateNaming(msg tea.KeyMsg) (Picker, tea.Cmd, Request, bool) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		name := strings.TrimSpace(p.input.Value())
+		if name == "" {
+			return p, nil, Request{}, false
+		}
+		p.naming = false
+		p.input.Blur()
+		return p, nil, Request{Action: ActionSave, Name: name, Scope: p.scope}, false
+	case tea.KeyTab:
+		if p.scope == config.ScopeRepo {
+			p.scope = config.ScopeGlobal
+		} else {
+			p.scope = config.ScopeRepo
+		}
+		return p, nil, Request{}, false
+	case tea.KeyEsc:
+		p.naming = false
+		p.input.Blur()
+		return p, nil, Request{}, false
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd, Request{}, false
+}
+
+// current returns the view under the cursor
+func (p Picker) current() (config.View, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.views) {
+		return config.View{}, false
+	}
+	return p.views[p.cursor], true
+}
+
+// IsNaming returns whether the picker is capturing text input
+func (p Picker) IsNaming() bool {
+	return p.naming
+}
+
+func (p Picker) View() string {
+	var lines []string
+	lines = append(lines, TitleStyle.Render("Saved views"))
+	apply, del := p.keys.Label(keys.ViewsApply), p.keys.Label(keys.ViewsDelete)
+	lines = append(lines, HintStyle.Render(fmt.Sprintf("%s=apply  %s=save current  %s %s=delete",
+		apply, p.keys.Label(keys.ViewsSave), del, del)))
+	lines = append(lines, "")
+
+	if len(p.views) == 0 {
+		lines = append(lines, HintStyle.Render("  No saved views"))
+	}
+
+	maxVisible := p.maxVisibleItems()
+	totalItems := len(p.views)
+
+	if p.scrollOffset > 0 {
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("  ↑ %d more", p.scrollOffset)))
+	}
+
+	endIdx := p.scrollOffset + maxVisible
+	if endIdx > totalItems {
+		endIdx = totalItems
+	}
+
+	for i := p.scrollOffset; i < endIdx; i++ {
+		v := p.views[i]
+		scope := ScopeStyle.Render(fmt.Sprintf("[%s]", v.Scope))
+		summary := HintStyle.Render(Summary(v))
+		line := fmt.Sprintf("  %s %s  %s", v.Name, scope, summary)
+		if i == p.cursor {
+			line = SelectedStyle.Render(fmt.Sprintf("> %s [%s]  %s", v.Name, v.Scope, Summary(v)))
+		}
+		lines = append(lines, line)
+	}
+
+	if endIdx < totalItems {
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("  ↓ %d more", totalItems-endIdx)))
+	}
+
+	lines = append(lines, "")
+	switch {
+	case p.naming:
+		lines = append(lines, "Name: "+p.input.View())
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("scope: %s  [Tab] switch  [Enter] Save  [Esc] Back", p.scope)))
+	case p.confirmDelete:
+		v, _ := p.current()
+		lines = append(lines, WarnStyle.Render(fmt.Sprintf("Press %s again to delete %q (%s)", del, v.Name, v.Scope)))
+	case p.err != nil:
+		lines = append(lines, ErrorStyle.Render(p.err.Error()))
+	case p.message != "":
+		lines = append(lines, HintStyle.Render(p.message))
+	default:
+		lines = append(lines, HintStyle.Render(fmt.Sprintf("[%s] Apply  [%s] Close", apply, p.keys.Label(keys.ViewsClose))))
+	}
+
+	content := strings.Join(lines, "\n")
+
+	innerWidth := p.width - 6
+	if innerWidth < 30 {
+		innerWidth = 30
+	}
+
+	return lipgloss.Place(
+		p.width, p.height,
+		lipgloss.Center, lipgloss.Center,
+		PickerStyle.Width(innerWidth).Render(content),
+	)
+}
+
+// Summary describes the filters of a view in one line
+func Summary(v config.View) string {
+	var parts []string
+	if len(v.Branches) > 0 {
+		parts = append(parts, "branch:"+strings.Join(v.Branches, ","))
+	}
+	if len(v.Authors) > 0 {
+		parts = append(parts, "author:"+strings.Join(v.Authors, ","))
+	}
+	if len(v.Tags) > 0 {
+		parts = append(parts, "tag:"+strings.Join(v.Tags, ","))
+	}
+	for _, b := range v.ExcludeBranches {
+		parts = append(parts, "!branch:"+b)
+	}
+	for _, a := range v.ExcludeAuthors {
+		parts = append(parts, "!author:"+a)
+	}
+	for _, t := range v.ExcludeTags {
+		parts = append(parts, "!tag:"+t)
+	}
+	if v.Highlight != "" {
+		parts = append(parts, "highlight:"+v.Highlight)
+	}
+	if v.Since != "" || v.Until != "" {
+		parts = append(parts, fmt.Sprintf("%s..%s", v.Since, v.Until))
+	}
+	if v.Search != "" {
+		mode := ""
+		if v.SearchMode != "" && v.SearchMode != "substring" {
+			mode = v.SearchMode + " "
+		}
+		parts = append(parts, fmt.Sprintf("%s%q", mode, v.Search))
+	}
+	if len(parts) == 0 {
+		return "no filters"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,44 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nogo/gitree/internal/config"
+	"github.com/nogo/gitree/internal/dates"
+	"github.com/nogo/gitree/internal/tui/filtering"
+)
+
+// State converts the filters of a saved view into a filter snapshot,
+// resolving relative dates against now. The search is not part of it.
+func State(v config.View, now time.Time) (filtering.State, error) {
+	state := filtering.State{
+		Branches:        v.Branches,
+		Authors:         v.Authors,
+		Tags:            v.Tags,
+		ExcludeBranches: v.ExcludeBranches,
+		ExcludeAuthors:  v.ExcludeAuthors,
+		ExcludeTags:     v.ExcludeTags,
+		Highlight:       v.Highlight,
+	}
+	var err error
+	if state.Since, err = parseTime(v.Since, false, now); err != nil {
+		return state, fmt.Errorf("view %q: since: %w", v.Name, err)
+	}
+	if state.Until, err = parseTime(v.Until, true, now); err != nil {
+		return state, fmt.Errorf("view %q: until: %w", v.Name, err)
+	}
+	return state, nil
+}
+
+// parseTime parses an absolute or relative view date ("" = unbounded)
+func parseTime(s string, until bool, now time.Time) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return time.Time{}, nil
+	}
+	if until {
+		return dates.Until(s, now)
+	}
+	return dates.Since(s, now)
+}
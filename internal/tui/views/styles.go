@@ -0,0 +1,43 @@
+package views
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nogo/gitree/internal/tui/theme"
+)
+
+var (
+	PickerStyle   lipgloss.Style
+	TitleStyle    lipgloss.Style
+	SelectedStyle lipgloss.Style
+	ScopeStyle    lipgloss.Style
+	HintStyle     lipgloss.Style
+	WarnStyle     lipgloss.Style
+	ErrorStyle    lipgloss.Style
+)
+
+func init() {
+	SetTheme(theme.Dark())
+}
+
+// SetTheme rebuilds the styles from t
+func SetTheme(t theme.Theme) {
+	PickerStyle = t.Overlay()
+
+	TitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Accent)
+
+	SelectedStyle = t.Selected()
+
+	ScopeStyle = lipgloss.NewStyle().
+		Foreground(t.Author)
+
+	HintStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	WarnStyle = lipgloss.NewStyle().
+		Foreground(t.Warning)
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(t.Error)
+}
@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nogo/gitree/internal/clipboard"
+	"github.com/nogo/gitree/internal/domain"
+	"github.com/nogo/gitree/internal/tui/keys"
+)
+
+// flashDuration is how long transient footer messages stay visible
+const flashDuration = 2 * time.Second
+
+// yankHint is shown in the footer while waiting for the key after the prefix
+func (m Model) yankHint() string {
+	return "yank: " + m.footerHints(keys.YankHash, "hash", keys.YankSubject, "subject", keys.YankPath, "path", keys.YankDiff, "diff")
+}
+
+// handleYankKey handles the yank prefix and the key following it.
+// Returns false if the key is not part of a yank.
+func (m *Model) handleYankKey(key string) (bool, tea.Cmd) {
+	if !m.yankPending {
+		if (m.showCompare || m.showRangeDiff) && !m.showDiff {
+			return false, nil
+		}
+		prefix := keys.Yank
+		if m.showDiff {
+			prefix = keys.DiffYank
+		} else if m.list.IsExpanded() {
+			prefix = keys.ExpandedYank
+		}
+		if !m.keys.Is(key, prefix) {
+			return false, nil
+		}
+		m.yankPending = true
+		m.diffView.SetStatus(m.yankHint())
+		return true, nil
+	}
+
+	// Any other key cancels
+	m.yankPending = false
+	m.diffView.SetStatus(m.flash)
+	return true, m.yankAction(m.keys.Lookup(keys.ModeYank, key))
+}
+
+// yankAction copies what a yank action names, for its key or the command
+// palette
+func (m *Model) yankAction(action string) tea.Cmd {
+	commit := m.list.SelectedCommit()
+	if commit == nil {
+		return nil
+	}
+	switch action {
+	case keys.YankHash:
+		return yank("hash "+commit.ShortHash, commit.Hash)
+	case keys.YankSubject:
+		return yank("subject", commit.Message)
+	case keys.YankPath:
+		if path := m.yankPath(); path != "" {
+			return yank(path, path)
+		}
+		return m.setFlash("no file selected", true)
+	case keys.YankDiff:
+		if m.showDiff {
+			if m.diffView.Diff() == "" {
+				return m.setFlash("no diff to yank", true)
+			}
+			return yank("diff of "+m.diffView.CurrentFile(), m.diffView.Diff())
+		}
+		if path := m.yankPath(); path != "" {
+			return m.yankFileDiff(commit.Hash, path)
+		}
+		return m.setFlash("expand a commit and select a file to yank its diff", true)
+	}
+	return nil
+}
+
+// yankPath returns the file shown in the diff view or selected in the
+// expanded commit ("" if none)
+func (m Model) yankPath() string {
+	if m.showDiff {
+		return m.diffView.CurrentFile()
+	}
+	if m.list.IsExpanded() {
+		if f := m.list.SelectedFile(); f != nil {
+			return f.Path
+		}
+	}
+	return ""
+}
+
+// yank returns a command copying text to the clipboard
+func yank(what, text string) tea.Cmd {
+	return func() tea.Msg {
+		return YankedMsg{What: what, Err: clipboard.Copy(text)}
+	}
+}
+
+// yankFileDiff returns a command loading the diff of a file and copying it
+func (m Model) yankFileDiff(hash, path string) tea.Cmd {
+	reader := m.reader
+	repoPath := m.repoPath
+	return func() tea.Msg {
+		diff, isBinary, err := reader.LoadFileDiff(repoPath, hash, path, domain.DefaultDiffOptions())
+		if err == nil && isBinary {
+			err = errors.New("binary file")
+		}
+		if err == nil {
+			err = clipboard.Copy(diff)
+		}
+		return YankedMsg{What: "diff of " + path, Err: err}
+	}
+}
+
+// setFlash shows a transient message in the footer
+func (m *Model) setFlash(text string, isErr bool) tea.Cmd {
+	m.flash = text
+	m.flashErr = isErr
+	m.flashID++
+	m.diffView.SetStatus(text)
+	m.compareView.SetStatus(text)
+	m.rangeView.SetStatus(text)
+	id := m.flashID
+	return tea.Tick(flashDuration, func(time.Time) tea.Msg {
+		return FlashExpiredMsg{ID: id}
+	})
+}
+
+// Flash returns the transient footer message and whether it is an error
+func (m Model) Flash() (string, bool) {
+	return m.flash, m.flashErr
+}
+
+// YankPending returns whether y was pressed and the next key picks what to copy
+func (m Model) YankPending() bool {
+	return m.yankPending
+}